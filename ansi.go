@@ -0,0 +1,141 @@
+package sanitize
+
+import (
+	"strings"
+)
+
+// Kinds of terminal escape sequences (see ansiIntroducer)
+const (
+	ansiNone   = iota // Not an escape sequence
+	ansiCSI           // Control sequence (ESC [): colors, cursor movement, screen modes
+	ansiOSC           // Operating system command (ESC ]): hyperlinks (OSC 8), window titles
+	ansiString        // Device control, SOS, PM and APC strings (ESC P, ESC X, ESC ^, ESC _)
+	ansiEscape        // Any other escape (ESC c reset, ESC 7, ESC ( 0 character sets)
+)
+
+// ansiIntroducers are the characters that can start an escape sequence (ESC and the C1 forms)
+const ansiIntroducers = "\x1b\u0090\u0098\u009b\u009d\u009e\u009f"
+
+// ANSI removes the terminal escape sequences from the string: colors and other control
+// sequences (ESC [ ... m, cursor movement, clear screen), operating system commands such
+// as hyperlinks (OSC 8) and window title changes (OSC 0), device control strings and all
+// other escapes, in both the 7-bit (ESC) and the 8-bit C1 forms. These sequences can be
+// used to hide or spoof the content of logs viewed in a terminal. An unterminated string
+// sequence (OSC, DCS) is removed up to the end of the string. Use WithKeepANSIColors to
+// keep the color and style sequences (SGR).
+//
+//	View examples: ansi_test.go
+func ANSI(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(stripANSI(original, o != nil && o.keepANSIColors))
+}
+
+// stripANSI removes the escape sequences, with keepColors the SGR sequences are kept
+func stripANSI(value string, keepColors bool) string {
+	if !strings.ContainsAny(value, ansiIntroducers) {
+		return value
+	}
+
+	var b strings.Builder
+	b.Grow(len(value))
+	for i := 0; i < len(value); {
+		kind, n := ansiIntroducer(value, i)
+		if kind == ansiNone {
+			b.WriteByte(value[i])
+			i++
+			continue
+		}
+		end := ansiSequenceEnd(value, i+n, kind)
+		if keepColors && kind == ansiCSI && isSGR(value[i+n:end]) {
+			b.WriteString(value[i:end])
+		}
+		i = end
+	}
+	return b.String()
+}
+
+// ansiIntroducer returns the kind of escape sequence starting at i and the length of its
+// introducer (ESC [ or the C1 character U+009B for a CSI)
+func ansiIntroducer(value string, i int) (int, int) {
+	switch {
+	case value[i] == '\x1b' && i+1 < len(value):
+		switch value[i+1] {
+		case '[':
+			return ansiCSI, 2
+		case ']':
+			return ansiOSC, 2
+		case 'P', 'X', '^', '_':
+			return ansiString, 2
+		}
+		return ansiEscape, 1
+	case value[i] == '\x1b':
+		return ansiEscape, 1
+	case value[i] == 0xc2 && i+1 < len(value): // UTF-8 encoded C1 controls
+		switch value[i+1] {
+		case 0x9b:
+			return ansiCSI, 2
+		case 0x9d:
+			return ansiOSC, 2
+		case 0x90, 0x98, 0x9e, 0x9f:
+			return ansiString, 2
+		}
+	}
+	return ansiNone, 0
+}
+
+// ansiSequenceEnd returns the position after the escape sequence whose body starts at i
+func ansiSequenceEnd(value string, i, kind int) int {
+	switch kind {
+	case ansiCSI:
+		// Parameter bytes, intermediate bytes and a final byte, an invalid byte ends the sequence
+		for i < len(value) && value[i] >= 0x30 && value[i] <= 0x3f {
+			i++
+		}
+		for i < len(value) && value[i] >= 0x20 && value[i] <= 0x2f {
+			i++
+		}
+		if i < len(value) && value[i] >= 0x40 && value[i] <= 0x7e {
+			i++
+		}
+		return i
+	case ansiOSC, ansiString:
+		// Terminated by ST (ESC \ or U+009C), an OSC can also be terminated by BEL
+		for ; i < len(value); i++ {
+			switch {
+			case value[i] == '\a' && kind == ansiOSC:
+				return i + 1
+			case value[i] == '\x1b':
+				if i+1 < len(value) && value[i+1] == '\\' {
+					return i + 2
+				}
+				return i // A new escape sequence cancels the string
+			case value[i] == 0xc2 && i+1 < len(value) && value[i+1] == 0x9c:
+				return i + 2
+			}
+		}
+		return i
+	}
+
+	// Intermediate bytes and a final byte (ESC ( B), the final byte is only removed if valid
+	for i < len(value) && value[i] >= 0x20 && value[i] <= 0x2f {
+		i++
+	}
+	if i < len(value) && value[i] >= 0x30 && value[i] <= 0x7e {
+		i++
+	}
+	return i
+}
+
+// isSGR returns true if the body of a CSI is a select graphic rendition (colors and styles)
+func isSGR(body string) bool {
+	if len(body) == 0 || body[len(body)-1] != 'm' {
+		return false
+	}
+	for i := 0; i < len(body)-1; i++ {
+		if c := body[i]; (c < '0' || c > '9') && c != ';' && c != ':' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestANSI tests the ANSI method
+func TestANSI(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"plain text", "no escapes here", "no escapes here"},
+		{"colors", "\x1b[1;31merror\x1b[0m: failed", "error: failed"},
+		{"cursor movement", "a\x1b[2J\x1b[H\x1b[3Ab\x1b[?1049h", "ab"},
+		{"hyperlink st", "\x1b]8;;https://evil.com\x1b\\https://bank.com\x1b]8;;\x1b\\", "https://bank.com"},
+		{"hyperlink bel", "\x1b]8;id=1;https://evil.com\aclick\x1b]8;;\a", "click"},
+		{"window title", "\x1b]0;root@prod\x07ok", "ok"},
+		{"clipboard", "\x1b]52;c;ZWNobyBoaQ==\x07ok", "ok"},
+		{"device control string", "a\x1bPq#0;2;0;0;0\x1b\\b", "ab"},
+		{"application program command", "a\x1b_payload\x1b\\b", "ab"},
+		{"reset", "a\x1bcb", "ab"},
+		{"character set", "a\x1b(0lqk\x1b(Bb", "alqkb"},
+		{"save cursor", "a\x1b7b\x1b8", "ab"},
+		{"c1 csi", "a\u009b31mb", "ab"},
+		{"c1 osc", "a\u009d0;title\u009cb", "ab"},
+		{"unterminated osc", "a\x1b]0;title", "a"},
+		{"osc cancelled by escape", "a\x1b]0;title\x1b[31mb", "ab"},
+		{"lone escape", "a\x1b", "a"},
+		{"escape before new line", "a\x1b\nb", "a\nb"},
+		{"invalid csi", "a\x1b[31éb", "aéb"},
+		{"utf-8", "café \x1b[32m✓\x1b[0m", "café ✓"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := ANSI(test.input)
+			assert.Equal(t, test.expected, output)
+			assert.Equal(t, output, ANSI(output), "output should be stable")
+		})
+	}
+
+	t.Run("keep colors", func(t *testing.T) {
+		var tests = []struct {
+			input    string
+			expected string
+		}{
+			{"\x1b[1;31merror\x1b[0m", "\x1b[1;31merror\x1b[0m"},
+			{"\x1b[38:2:255:0:0mred\x1b[m", "\x1b[38:2:255:0:0mred\x1b[m"},
+			{"\x1b[2J\x1b[31mred\x1b]0;title\a", "\x1b[31mred"},
+			{"\x1b[?25m", ""},
+		}
+		for _, test := range tests {
+			assert.Equal(t, test.expected, ANSI(test.input, WithKeepANSIColors()))
+		}
+	})
+}
+
+// BenchmarkANSI benchmarks the ANSI method
+func BenchmarkANSI(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = ANSI("\x1b[31merror\x1b[0m: \x1b]8;;https://evil.com\x1b\\click\x1b]8;;\x1b\\")
+	}
+}
+
+// ExampleANSI example using ANSI()
+func ExampleANSI() {
+	fmt.Println(ANSI("\x1b]0;pwned\x07\x1b[31mlogin failed\x1b[0m \x1b]8;;https://evil.com\x1b\\help\x1b]8;;\x1b\\"))
+	// Output: login failed help
+}
@@ -0,0 +1,191 @@
+package sanitize
+
+import "unicode/utf8"
+
+// Character sets used to build the lookup tables (these match the regular expressions)
+const (
+	asciiDigits  = "0123456789"
+	asciiLetters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	asciiSpaces  = "\t\n\f\r " // Same as \s in RE2
+)
+
+// charTable is a lookup table of the allowed ASCII bytes
+type charTable [256]bool
+
+// newCharTable returns a lookup table that allows every byte in the sets
+func newCharTable(sets ...string) *charTable {
+	table := new(charTable)
+	for _, set := range sets {
+		for i := 0; i < len(set); i++ {
+			table[set[i]] = true
+		}
+	}
+	return table
+}
+
+// newCharTableExcept returns a lookup table that allows every byte except the bytes in the sets,
+// the bytes of multi-byte runes are allowed (kept) unless they are in the sets
+func newCharTableExcept(sets ...string) *charTable {
+	table := newCharTable(sets...)
+	for i := range table {
+		table[i] = !table[i]
+	}
+	return table
+}
+
+// nonASCIIBytes returns the bytes that are not ASCII (the bytes of multi-byte runes)
+func nonASCIIBytes() string {
+	set := make([]byte, 0, 256-utf8.RuneSelf)
+	for c := utf8.RuneSelf; c < 256; c++ {
+		set = append(set, byte(c))
+	}
+	return string(set)
+}
+
+// Lookup tables for the filter sanitizers and the Append functions
+var (
+	alphaNumericTable           = newCharTable(asciiLetters, asciiDigits)
+	alphaNumericWithSpacesTable = newCharTable(asciiLetters, asciiDigits, asciiSpaces)
+	alphaTable                  = newCharTable(asciiLetters)
+	alphaWithSpacesTable        = newCharTable(asciiLetters, asciiSpaces)
+	bitcoinCashTable            = newCharTable("acdefghjklmnpqrstuvwxyzACDEFGHJKLMNPQRSTUVWXYZ023456789")
+	bitcoinTable                = newCharTable("abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ123456789")
+	decimalTable                = newCharTable(asciiDigits, ".-")
+	domainTable                 = newCharTable(asciiLetters, asciiDigits, "-.")
+	emailTable                  = newCharTable(asciiLetters, asciiDigits, "-_.@+")
+	formalNameTable             = newCharTable(asciiLetters, asciiDigits, "-',.", asciiSpaces)
+	ipAddressTable              = newCharTable(asciiLetters, asciiDigits, ":.")
+	numericTable                = newCharTable(asciiDigits)
+	pathNameTable               = newCharTable(asciiLetters, asciiDigits, "-_")
+	punctuationTable            = newCharTable(asciiLetters, asciiDigits, `-'"#&!?,.`, asciiSpaces)
+	removeDigitsTable           = newCharTableExcept(asciiDigits)
+	removeLettersTable          = newCharTableExcept(asciiLetters)
+	removeNonASCIITable         = newCharTableExcept(nonASCIIBytes())
+	scientificNotationTable     = newCharTable(asciiDigits, ".eE+-")
+	timeTable                   = newCharTable(asciiDigits, ":")
+	uriTable                    = newCharTable(asciiLetters, asciiDigits, "-_/?&=#%")
+	urlTable                    = newCharTable(asciiLetters, asciiDigits, "-_/:.,?&@=#%")
+)
+
+// appendFiltered appends the bytes of src that are allowed by the table to dst. The tables
+// either allow or remove all the bytes of multi-byte runes, so runes are never split.
+func appendFiltered(dst []byte, src string, table *charTable) []byte {
+	for i := 0; i < len(src); i++ {
+		if table[src[i]] {
+			dst = append(dst, src[i])
+		}
+	}
+	return dst
+}
+
+// filterASCII returns the bytes of the string that are allowed by the table, this is the
+// byte-wise fast path of the filter sanitizers (no rune decoding). The original string is
+// returned as-is (without allocating) when there is nothing to remove.
+func filterASCII(original string, table *charTable) string {
+	for i := 0; i < len(original); i++ {
+		if !table[original[i]] {
+			buf := make([]byte, i, len(original))
+			copy(buf, original[:i])
+			return string(appendFiltered(buf, original[i+1:], table))
+		}
+	}
+	return original
+}
+
+// AppendAlpha appends the result of Alpha() to dst and returns the extended buffer.
+// Like strconv.AppendInt, this allows callers to reuse a buffer across calls.
+//
+//	View examples: append_test.go
+func AppendAlpha(dst []byte, src string, spaces bool) []byte {
+	if spaces {
+		return appendFiltered(dst, src, alphaWithSpacesTable)
+	}
+	return appendFiltered(dst, src, alphaTable)
+}
+
+// AppendAlphaNumeric appends the result of AlphaNumeric() to dst and returns the extended buffer.
+//
+//	View examples: append_test.go
+func AppendAlphaNumeric(dst []byte, src string, spaces bool) []byte {
+	if spaces {
+		return appendFiltered(dst, src, alphaNumericWithSpacesTable)
+	}
+	return appendFiltered(dst, src, alphaNumericTable)
+}
+
+// AppendBitcoinAddress appends the result of BitcoinAddress() to dst and returns the extended buffer.
+//
+//	View examples: append_test.go
+func AppendBitcoinAddress(dst []byte, src string) []byte {
+	return appendFiltered(dst, src, bitcoinTable)
+}
+
+// AppendBitcoinCashAddress appends the result of BitcoinCashAddress() to dst and returns the extended buffer.
+//
+//	View examples: append_test.go
+func AppendBitcoinCashAddress(dst []byte, src string) []byte {
+	return appendFiltered(dst, src, bitcoinCashTable)
+}
+
+// AppendDecimal appends the result of Decimal() to dst and returns the extended buffer.
+//
+//	View examples: append_test.go
+func AppendDecimal(dst []byte, src string) []byte {
+	return appendFiltered(dst, src, decimalTable)
+}
+
+// AppendFormalName appends the result of FormalName() to dst and returns the extended buffer.
+//
+//	View examples: append_test.go
+func AppendFormalName(dst []byte, src string) []byte {
+	return appendFiltered(dst, src, formalNameTable)
+}
+
+// AppendNumeric appends the result of Numeric() to dst and returns the extended buffer.
+//
+//	View examples: append_test.go
+func AppendNumeric(dst []byte, src string) []byte {
+	return appendFiltered(dst, src, numericTable)
+}
+
+// AppendPathName appends the result of PathName() to dst and returns the extended buffer.
+//
+//	View examples: append_test.go
+func AppendPathName(dst []byte, src string) []byte {
+	return appendFiltered(dst, src, pathNameTable)
+}
+
+// AppendPunctuation appends the result of Punctuation() to dst and returns the extended buffer.
+//
+//	View examples: append_test.go
+func AppendPunctuation(dst []byte, src string) []byte {
+	return appendFiltered(dst, src, punctuationTable)
+}
+
+// AppendScientificNotation appends the result of ScientificNotation() to dst and returns the extended buffer.
+//
+//	View examples: append_test.go
+func AppendScientificNotation(dst []byte, src string) []byte {
+	return appendFiltered(dst, src, scientificNotationTable)
+}
+
+// AppendTime appends the result of Time() to dst and returns the extended buffer.
+//
+//	View examples: append_test.go
+func AppendTime(dst []byte, src string) []byte {
+	return appendFiltered(dst, src, timeTable)
+}
+
+// AppendURI appends the result of URI() to dst and returns the extended buffer.
+//
+//	View examples: append_test.go
+func AppendURI(dst []byte, src string) []byte {
+	return appendFiltered(dst, src, uriTable)
+}
+
+// AppendURL appends the result of URL() to dst and returns the extended buffer.
+//
+//	View examples: append_test.go
+func AppendURL(dst []byte, src string) []byte {
+	return appendFiltered(dst, src, urlTable)
+}
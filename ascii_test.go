@@ -0,0 +1,86 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestASCII tests the ASCII sanitize method
+func TestASCII(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name          string
+		input         string
+		expected      string
+		transliterate bool
+	}{
+		{"already ascii", "Test This String-!123", "Test This String-!123", false},
+		{"drop accents", "Crème Brûlée", "Crme Brle", false},
+		{"drop symbols", "“Quote” ☺", "Quote ", false},
+		{"transliterate accents", "Crème Brûlée", "Creme Brulee", true},
+		{"transliterate quotes", "“It’s a quote” – he said…", `"It's a quote" - he said...`, true},
+		{"transliterate ligatures", "Æsir straße œuvre", "AEsir strasse oeuvre", true},
+		{"transliterate polish", "Łódź", "Lodz", true},
+		{"transliterate vietnamese", "Nguyễn", "Nguyen", true},
+		{"no equivalent", "snow ☃ man", "snow  man", true},
+		{"empty", "", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ASCII(test.input, test.transliterate))
+		})
+	}
+}
+
+// TestWithASCIIOnly tests the WithASCIIOnly option
+func TestWithASCIIOnly(t *testing.T) {
+	t.Parallel()
+
+	t.Run("alpha keeps transliterated letters", func(t *testing.T) {
+		assert.Equal(t, "Creme Brulee", Alpha("Crème Brûlée!", true, WithASCIIOnly()))
+	})
+
+	t.Run("formal name", func(t *testing.T) {
+		assert.Equal(t, "Francois O'Neil", FormalName("François O’Neil", WithASCIIOnly()))
+	})
+
+	t.Run("combined with casing", func(t *testing.T) {
+		assert.Equal(t, "SAO-PAULO", PathName("São-Paulo", WithASCIIOnly(), WithUppercase()))
+	})
+}
+
+// BenchmarkASCII benchmarks the ASCII method
+func BenchmarkASCII(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = ASCII("“This is the test string” – Crème Brûlée", false)
+	}
+}
+
+// BenchmarkASCII_Transliterate benchmarks the ASCII method
+func BenchmarkASCII_Transliterate(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = ASCII("“This is the test string” – Crème Brûlée", true)
+	}
+}
+
+// ExampleASCII example using ASCII() and removing non-ASCII characters
+func ExampleASCII() {
+	fmt.Println(ASCII("Crème Brûlée", false))
+	// Output: Crme Brle
+}
+
+// ExampleASCII_transliterate example using ASCII() and transliterating characters
+func ExampleASCII_transliterate() {
+	fmt.Println(ASCII("“Crème Brûlée”", true))
+	// Output: "Creme Brulee"
+}
+
+// ExampleWithASCIIOnly example using Alpha() and the WithASCIIOnly() option
+func ExampleWithASCIIOnly() {
+	fmt.Println(Alpha("Crème Brûlée!", true, WithASCIIOnly()))
+	// Output: Creme Brulee
+}
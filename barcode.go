@@ -0,0 +1,124 @@
+package sanitize
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// isbnPrefixRegExp matches the optional ISBN label before the number (ISBN-13: 978-...)
+var isbnPrefixRegExp = regexp.MustCompile(`(?i)^isbn(?:[- ]?1[03])?:?\s*`)
+
+// ISBN returns the ISBN-10 or ISBN-13 number without hyphens and spaces or an error if the
+// check digit is not valid (ISBN 0-306-40615-2 => 0306406152). The check digit X of an ISBN-10
+// is returned in uppercase, use WithISBN13 to convert ISBN-10 numbers to ISBN-13.
+//
+//	View examples: barcode_test.go
+func ISBN(original string, opts ...Option) (string, error) {
+	o := newOptions(opts)
+	if err := o.checkSize(original); err != nil {
+		return "", err
+	}
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %q %s", ErrInvalidISBN, original, reason)
+	}
+
+	number := strings.ToUpper(barcodeDigits(isbnPrefixRegExp.ReplaceAllString(strings.TrimSpace(original), "")))
+	switch len(number) {
+	case 10:
+		if !isDigitsASCII(number[:9]) || (number[9] != 'X' && !isDigitsASCII(number[9:])) {
+			return "", invalid("is not a number")
+		}
+		sum := 0
+		for i := 0; i < 10; i++ {
+			d := 10
+			if number[i] != 'X' {
+				d = int(number[i] - '0')
+			}
+			sum += (10 - i) * d
+		}
+		if sum%11 != 0 {
+			return "", invalid("has an invalid check digit")
+		}
+		if o != nil && o.isbn13 {
+			number = "978" + number[:9]
+			number += string(gtinCheckDigit(number))
+		}
+		return number, nil
+	case 13:
+		if !isDigitsASCII(number) {
+			return "", invalid("is not a number")
+		}
+		if !strings.HasPrefix(number, "978") && !strings.HasPrefix(number, "979") {
+			return "", invalid("does not start with 978 or 979")
+		}
+		if gtinCheckDigit(number[:12]) != number[12] {
+			return "", invalid("has an invalid check digit")
+		}
+		return number, nil
+	}
+	return "", invalid("is not 10 or 13 digits")
+}
+
+// EAN13 returns the 13 digit EAN (GTIN-13) barcode number without hyphens and spaces
+// or an error if the length or the check digit is not valid.
+//
+//	View examples: barcode_test.go
+func EAN13(original string) (string, error) {
+	return gtin(original, 13)
+}
+
+// UPC returns the 12 digit UPC-A (GTIN-12) barcode number without hyphens and spaces
+// or an error if the length or the check digit is not valid.
+//
+//	View examples: barcode_test.go
+func UPC(original string) (string, error) {
+	return gtin(original, 12)
+}
+
+// gtin validates a GTIN barcode number of the given length
+func gtin(original string, length int) (string, error) {
+	number := barcodeDigits(strings.TrimSpace(original))
+	if len(number) != length || !isDigitsASCII(number) {
+		return "", fmt.Errorf("%w: %q is not %d digits", ErrInvalidBarcode, original, length)
+	}
+	if gtinCheckDigit(number[:length-1]) != number[length-1] {
+		return "", fmt.Errorf("%w: %q has an invalid check digit", ErrInvalidBarcode, original)
+	}
+	return number, nil
+}
+
+// IMEI returns the 15 digit IMEI number or an error if the length or the Luhn check digit is not
+// valid. Everything but the digits is removed, including an IMEI label (IMEI: 49-015420-323751-8
+// => 490154203237518).
+//
+//	View examples: barcode_test.go
+func IMEI(original string) (string, error) {
+	number := filterASCII(original, numericTable)
+	if len(number) != 15 {
+		return "", fmt.Errorf("%w: %q is not 15 digits", ErrInvalidIMEI, original)
+	}
+	if !checksumLuhn(number) {
+		return "", fmt.Errorf("%w: %q has an invalid check digit", ErrInvalidIMEI, original)
+	}
+	return number, nil
+}
+
+// barcodeDigits removes the hyphens and spaces that separate the groups of digits
+func barcodeDigits(value string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(value)
+}
+
+// gtinCheckDigit returns the GS1 check digit of the digits (the weights are 3 and 1
+// starting from the rightmost digit)
+func gtinCheckDigit(digits string) byte {
+	sum := 0
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if (len(digits)-i)%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
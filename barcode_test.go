@@ -0,0 +1,277 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestISBN tests the ISBN method
+func TestISBN(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			opts     []Option
+			expected string
+		}{
+			{"isbn-10", "0306406152", nil, "0306406152"},
+			{"isbn-10 hyphens", "0-306-40615-2", nil, "0306406152"},
+			{"isbn-10 check digit x", "0-8044-2957-x", nil, "080442957X"},
+			{"isbn-13", "978-0-306-40615-7", nil, "9780306406157"},
+			{"isbn-13 spaces", " 978 0 306 40615 7 ", nil, "9780306406157"},
+			{"isbn-13 979", "979-10-90636-07-1", nil, "9791090636071"},
+			{"label", "ISBN 0-306-40615-2", nil, "0306406152"},
+			{"label with length", "ISBN-13: 978-0-306-40615-7", nil, "9780306406157"},
+			{"label lowercase", "isbn10:0306406152", nil, "0306406152"},
+			{"converted", "0-306-40615-2", []Option{WithISBN13()}, "9780306406157"},
+			{"converted check digit x", "080442957X", []Option{WithISBN13()}, "9780804429573"},
+			{"isbn-13 not converted", "9780306406157", []Option{WithISBN13()}, "9780306406157"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := ISBN(test.input, test.opts...)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"isbn-10 check digit", "0306406153"},
+			{"isbn-13 check digit", "9780306406158"},
+			{"isbn-13 prefix", "9770306406157"},
+			{"x not last", "03064X6152"},
+			{"letters", "03064o6152"},
+			{"too short", "030640615"},
+			{"too long", "97803064061570"},
+			{"other separators", "0.306.40615.2"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := ISBN(test.input)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidISBN)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+
+	t.Run("input too large", func(t *testing.T) {
+		_, err := ISBN("0-306-40615-2", WithMaxInputBytes(5))
+		assert.ErrorIs(t, err, ErrInputTooLarge)
+	})
+}
+
+// BenchmarkISBN benchmarks the ISBN method
+func BenchmarkISBN(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ISBN("ISBN 0-306-40615-2", WithISBN13())
+	}
+}
+
+// ExampleISBN example using ISBN()
+func ExampleISBN() {
+	fmt.Println(ISBN("ISBN 0-306-40615-2"))
+	fmt.Println(ISBN("ISBN 0-306-40615-2", WithISBN13()))
+	// Output:
+	// 0306406152 <nil>
+	// 9780306406157 <nil>
+}
+
+// TestEAN13 tests the EAN13 method
+func TestEAN13(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected string
+		}{
+			{"digits", "4006381333931", "4006381333931"},
+			{"spaces", " 4 006381 333931 ", "4006381333931"},
+			{"hyphens", "400-6381-33393-1", "4006381333931"},
+			{"check digit zero", "5901234123457", "5901234123457"},
+			{"isbn", "9780306406157", "9780306406157"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := EAN13(test.input)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"check digit", "4006381333932"},
+			{"upc", "036000291452"},
+			{"letters", "400638133393A"},
+			{"too long", "40063813339310"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := EAN13(test.input)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidBarcode)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkEAN13 benchmarks the EAN13 method
+func BenchmarkEAN13(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = EAN13("4 006381 333931")
+	}
+}
+
+// ExampleEAN13 example using EAN13()
+func ExampleEAN13() {
+	fmt.Println(EAN13("4 006381 333931"))
+	// Output: 4006381333931 <nil>
+}
+
+// TestUPC tests the UPC method
+func TestUPC(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected string
+		}{
+			{"digits", "036000291452", "036000291452"},
+			{"spaces", "0 36000 29145 2", "036000291452"},
+			{"hyphens", "0-36000-29145-2", "036000291452"},
+			{"check digit zero", "012345678905", "012345678905"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := UPC(test.input)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"check digit", "036000291453"},
+			{"ean-13", "4006381333931"},
+			{"letters", "03600029145X"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := UPC(test.input)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidBarcode)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkUPC benchmarks the UPC method
+func BenchmarkUPC(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = UPC("0 36000 29145 2")
+	}
+}
+
+// ExampleUPC example using UPC()
+func ExampleUPC() {
+	fmt.Println(UPC("0 36000 29145 2"))
+	// Output: 036000291452 <nil>
+}
+
+// TestIMEI tests the IMEI method
+func TestIMEI(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected string
+		}{
+			{"digits", "490154203237518", "490154203237518"},
+			{"hyphens", "49-015420-323751-8", "490154203237518"},
+			{"spaces", " 49 015420 323751 8 ", "490154203237518"},
+			{"slashes", "49/015420/323751/8", "490154203237518"},
+			{"label", "IMEI: 49-015420-323751-8", "490154203237518"},
+			{"scan noise", "\x02490154203237518\r\n", "490154203237518"},
+			{"check digit zero", "356938035643809", "356938035643809"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := IMEI(test.input)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"check digit", "490154203237519"},
+			{"too short", "49015420323751"},
+			{"imeisv", "4901542032375186"},
+			{"letters only", "IMEI"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := IMEI(test.input)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidIMEI)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkIMEI benchmarks the IMEI method
+func BenchmarkIMEI(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = IMEI("IMEI: 49-015420-323751-8")
+	}
+}
+
+// ExampleIMEI example using IMEI()
+func ExampleIMEI() {
+	fmt.Println(IMEI("IMEI: 49-015420-323751-8"))
+	// Output: 490154203237518 <nil>
+}
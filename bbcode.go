@@ -0,0 +1,190 @@
+package sanitize
+
+import (
+	"regexp"
+	"strings"
+)
+
+// bbcodeTags are the BBCode tags kept by BBCode, true if the tag has an end tag
+var bbcodeTags = map[string]bool{
+	"*":      false,
+	"b":      true,
+	"center": true,
+	"code":   true,
+	"color":  true,
+	"i":      true,
+	"img":    true,
+	"list":   true,
+	"quote":  true,
+	"s":      true,
+	"size":   true,
+	"u":      true,
+	"url":    true,
+}
+
+// bbcodeColorRegExp matches the allowed [color] values (#f00, #ff0000 or red)
+var bbcodeColorRegExp = regexp.MustCompile(`^(?:#[0-9a-fA-F]{3}|#[0-9a-fA-F]{6}|[a-zA-Z]{1,20})$`)
+
+// bbcodeSizeRegExp matches the allowed [size] values (1 to 3 digits and an optional unit)
+var bbcodeSizeRegExp = regexp.MustCompile(`^[0-9]{1,3}(?:px|pt|%)?$`)
+
+// bbcodeListTypes are the allowed [list] values (ordered list styles)
+var bbcodeListTypes = map[string]bool{"1": true, "a": true, "A": true, "i": true, "I": true}
+
+// bbcodeLinkSchemes are the schemes allowed in [img] urls
+var bbcodeLinkSchemes = []string{"http", "https"}
+
+// BBCode sanitizes BBCode markup (forum posts, legacy CMS content). The supported tags
+// (b, i, u, s, center, code, color, img, list, quote, size, url and the [*] list item) are
+// lowercased and balanced: end tags without a start tag are removed, elements that are not
+// closed are closed at the end and closing an outer element closes the inner ones. Links
+// with an unsafe scheme ([url=javascript:...]) lose their tags and keep their text, images
+// that are not http(s) urls ([img]data:...[/img]) are removed, invalid color, size and list
+// values are removed. The content of [code] is kept as-is and unknown tags are kept as text.
+//
+//	View examples: bbcode_test.go
+func BBCode(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(sanitizeBBCode(original))
+}
+
+// sanitizeBBCode sanitizes and balances the BBCode (see BBCode)
+func sanitizeBBCode(original string) string {
+	if !strings.Contains(original, "[") {
+		return original
+	}
+
+	var b strings.Builder
+	b.Grow(len(original))
+
+	var open []string
+	for i := 0; i < len(original); {
+		start := strings.IndexByte(original[i:], '[')
+		if start < 0 {
+			b.WriteString(original[i:])
+			break
+		}
+		b.WriteString(original[i : i+start])
+		i += start
+
+		end := strings.IndexByte(original[i:], ']')
+		if end < 0 {
+			b.WriteString(original[i:])
+			break
+		}
+		name, value, closing := parseBBCodeTag(original[i+1 : i+end])
+		hasEnd, known := bbcodeTags[name]
+		if !known || (closing && !hasEnd) {
+			b.WriteByte('[')
+			i++
+			continue
+		}
+		i += end + 1
+
+		switch {
+		case closing:
+			open = closeBBCode(&b, open, name)
+		case name == "code":
+			content, next := bbcodeContent(original, i, name)
+			b.WriteString("[code]" + content + "[/code]")
+			i = next
+		case name == "img":
+			content, next := bbcodeContent(original, i, name)
+			if link, err := SafeLink(strings.TrimSpace(content), bbcodeLinkSchemes...); err == nil {
+				b.WriteString("[img]" + link + "[/img]")
+			}
+			i = next
+		case name == "url" && len(value) == 0:
+			// The content is the link, an unsafe link is kept as text (without the tags)
+			content, next := bbcodeContent(original, i, name)
+			if link, err := SafeLink(strings.TrimSpace(content)); err == nil {
+				b.WriteString("[url]" + link + "[/url]")
+				i = next
+			}
+		case name == "url":
+			if link, err := SafeLink(value); err == nil {
+				b.WriteString("[url=" + link + "]")
+				open = append(open, name)
+			}
+		default:
+			b.WriteString("[" + name + bbcodeValue(name, value) + "]")
+			if hasEnd {
+				open = append(open, name)
+			}
+		}
+	}
+
+	// Close the elements that are still open
+	for j := len(open) - 1; j >= 0; j-- {
+		b.WriteString("[/" + open[j] + "]")
+	}
+	return b.String()
+}
+
+// parseBBCodeTag returns the lowercase name, the value (without quotes) and true for an
+// end tag from the content of a tag ([url="x"] => url, x, false)
+func parseBBCodeTag(tag string) (string, string, bool) {
+	closing := strings.HasPrefix(tag, "/")
+	if closing {
+		tag = tag[1:]
+	}
+	name, value, _ := strings.Cut(tag, "=")
+	value = strings.TrimSpace(value)
+	if len(value) > 1 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return strings.ToLower(strings.TrimSpace(name)), value, closing
+}
+
+// bbcodeValue returns the "=value" of a start tag, an invalid or unsupported value is removed
+func bbcodeValue(name, value string) string {
+	valid := false
+	switch name {
+	case "color":
+		valid = bbcodeColorRegExp.MatchString(value)
+	case "size":
+		valid = bbcodeSizeRegExp.MatchString(value)
+	case "list":
+		valid = bbcodeListTypes[value]
+	case "quote":
+		value = strings.Map(func(r rune) rune {
+			if r == '[' || r == ']' || r == '"' || r < ' ' {
+				return -1
+			}
+			return r
+		}, value)
+		valid = len(value) > 0
+	}
+	if !valid {
+		return ""
+	}
+	return "=" + value
+}
+
+// bbcodeContent returns the content of the element up to its end tag (ignoring case) and the
+// position after the end tag, an element that is not closed runs to the end of the input
+func bbcodeContent(original string, i int, name string) (string, int) {
+	endTag := "[/" + name + "]"
+	for j := i; j+len(endTag) <= len(original); j++ {
+		if original[j] == '[' && strings.EqualFold(original[j:j+len(endTag)], endTag) {
+			return original[i:j], j + len(endTag)
+		}
+	}
+	return original[i:], len(original)
+}
+
+// closeBBCode closes the open element with the name and the elements opened after it, the
+// remaining open elements are returned. An end tag without an open element is removed.
+func closeBBCode(b *strings.Builder, open []string, name string) []string {
+	for j := len(open) - 1; j >= 0; j-- {
+		if open[j] != name {
+			continue
+		}
+		for k := len(open) - 1; k >= j; k-- {
+			b.WriteString("[/" + open[k] + "]")
+		}
+		return open[:j]
+	}
+	return open
+}
@@ -0,0 +1,67 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBBCode tests the BBCode method
+func TestBBCode(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"text", "No tags here", "No tags here"},
+		{"basic tags", "[b]bold[/b] [I]italic[/I] [u]u[/u]", "[b]bold[/b] [i]italic[/i] [u]u[/u]"},
+		{"url", "[url=https://example.com]site[/url]", "[url=https://example.com]site[/url]"},
+		{"quoted url", `[url="https://example.com/a b"]site[/url]`, "[url=https://example.com/a%20b]site[/url]"},
+		{"url content", "[url]https://example.com[/url]", "[url]https://example.com[/url]"},
+		{"javascript url", "[url=javascript:alert(1)]click[/url]", "click"},
+		{"obfuscated url", "[url=JaVa&#115;cript:alert(1)]click[/url]", "click"},
+		{"javascript url content", "[url]javascript:alert(1)[/url]", "javascript:alert(1)"},
+		{"image", "[img]https://example.com/a.png[/img]", "[img]https://example.com/a.png[/img]"},
+		{"data image", "a[img]data:image/png;base64,AAAA[/img]b", "ab"},
+		{"javascript image", "[IMG]javascript:alert(1)[/IMG]", ""},
+		{"mailto image", "[img]mailto:a@example.com[/img]", ""},
+		{"color", "[color=#ff0000]red[/color] [color=Blue]blue[/color]",
+			"[color=#ff0000]red[/color] [color=Blue]blue[/color]"},
+		{"invalid color", "[color=red;background:url(x)]x[/color]", "[color]x[/color]"},
+		{"size", "[size=12px]x[/size] [size=expression(1)]y[/size]", "[size=12px]x[/size] [size]y[/size]"},
+		{"quote", `[quote="Bob"]hi[/quote] [quote=a"b]x[/quote]`, "[quote=Bob]hi[/quote] [quote=ab]x[/quote]"},
+		{"list", "[list=1][*]one[*]two[/list]", "[list=1][*]one[*]two[/list]"},
+		{"invalid list", "[list=disc]x[/list]", "[list]x[/list]"},
+		{"code is not parsed", "[code][url=javascript:x]a[/url][/CODE]", "[code][url=javascript:x]a[/url][/code]"},
+		{"unclosed code", "[code]x", "[code]x[/code]"},
+		{"unclosed tags", "[b][i]text", "[b][i]text[/i][/b]"},
+		{"stray end tag", "text[/b][/url]", "text"},
+		{"misnested tags", "[b][i]x[/b]y[/i]", "[b][i]x[/i][/b]y"},
+		{"unknown tags", "[spoiler]x[/spoiler] [1] a[b", "[spoiler]x[/spoiler] [1] a[b"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := BBCode(test.input)
+			assert.Equal(t, test.expected, output)
+			assert.Equal(t, output, BBCode(output), "output should be stable")
+		})
+	}
+}
+
+// BenchmarkBBCode benchmarks the BBCode method
+func BenchmarkBBCode(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = BBCode("[b]Hello[/b] [url=javascript:alert(1)]click[/url] [img]https://example.com/a.png[/img][i]")
+	}
+}
+
+// ExampleBBCode example using BBCode()
+func ExampleBBCode() {
+	fmt.Println(BBCode("[B]Hi[/B] [url=javascript:alert(1)]click[/url] [i]open"))
+	// Output: [b]Hi[/b] click [i]open[/i]
+}
@@ -0,0 +1,114 @@
+package sanitize
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// BidiPolicy is how BidiControls() handles bidirectional control characters
+type BidiPolicy int
+
+// Supported bidi policies
+const (
+	BidiStrip   BidiPolicy = iota // Remove the control characters
+	BidiEscape                    // Replace the control characters with a visible escape (\u202E)
+	BidiIsolate                   // Close any open embeddings and wrap the text in FSI ... PDI
+)
+
+// Bidirectional control characters
+const (
+	bidiALM = '\u061C' // Arabic letter mark
+	bidiLRM = '\u200E' // Left-to-right mark
+	bidiRLM = '\u200F' // Right-to-left mark
+	bidiLRE = '\u202A' // Left-to-right embedding
+	bidiRLE = '\u202B' // Right-to-left embedding
+	bidiPDF = '\u202C' // Pop directional formatting
+	bidiLRO = '\u202D' // Left-to-right override
+	bidiRLO = '\u202E' // Right-to-left override
+	bidiLRI = '\u2066' // Left-to-right isolate
+	bidiRLI = '\u2067' // Right-to-left isolate
+	bidiFSI = '\u2068' // First strong isolate
+	bidiPDI = '\u2069' // Pop directional isolate
+)
+
+// isBidiControl returns true for the bidirectional control characters
+func isBidiControl(r rune) bool {
+	return r == bidiALM || r == bidiLRM || r == bidiRLM ||
+		(r >= bidiLRE && r <= bidiRLO) || (r >= bidiLRI && r <= bidiPDI)
+}
+
+// BidiControls handles the bidirectional control characters (LRE, RLO, LRI, PDI, etc.) that
+// are used to spoof file names and source code (Trojan Source). BidiStrip removes them,
+// BidiEscape replaces them with a visible escape and BidiIsolate keeps them but closes any
+// embedding, override or isolate left open and wraps the text in an isolate (FSI ... PDI) so
+// that they can not change the text around it. Text without any control characters is
+// returned as-is.
+//
+//	View examples: bidi_test.go
+func BidiControls(original string, policy BidiPolicy, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	if strings.IndexFunc(original, isBidiControl) < 0 {
+		return o.after(original)
+	}
+
+	if policy == BidiIsolate {
+		return o.after(isolateBidi(original))
+	}
+
+	var b strings.Builder
+	b.Grow(len(original) + 8)
+	for _, r := range original {
+		switch {
+		case !isBidiControl(r):
+			b.WriteRune(r)
+		case policy == BidiEscape:
+			_, _ = fmt.Fprintf(&b, "\\u%04X", r)
+		}
+	}
+	return o.after(b.String())
+}
+
+// isolateBidi closes the open embeddings and isolates of the text and wraps it in FSI ... PDI,
+// text that is already wrapped (and balanced) is returned as-is
+func isolateBidi(original string) string {
+	var stack []rune // Open embeddings (PDF) and isolates (PDI)
+	wrapped := strings.HasPrefix(original, string(bidiFSI)) && strings.HasSuffix(original, string(bidiPDI))
+	for i, r := range original {
+		switch r {
+		case bidiLRE, bidiRLE, bidiLRO, bidiRLO:
+			stack = append(stack, bidiPDF)
+		case bidiLRI, bidiRLI, bidiFSI:
+			stack = append(stack, bidiPDI)
+		case bidiPDF:
+			if len(stack) > 0 && stack[len(stack)-1] == bidiPDF {
+				stack = stack[:len(stack)-1]
+			}
+		case bidiPDI:
+			// Closes the last isolate and the embeddings opened inside of it
+			for j := len(stack) - 1; j >= 0; j-- {
+				if stack[j] == bidiPDI {
+					stack = stack[:j]
+					break
+				}
+			}
+		}
+		if len(stack) == 0 && i+utf8.RuneLen(r) < len(original) {
+			wrapped = false // The first isolate does not wrap the whole text
+		}
+	}
+	if wrapped && len(stack) == 0 {
+		return original
+	}
+
+	var b strings.Builder
+	b.Grow(len(original) + (len(stack)+2)*3)
+	b.WriteRune(bidiFSI)
+	b.WriteString(original)
+	for i := len(stack) - 1; i >= 0; i-- {
+		b.WriteRune(stack[i])
+	}
+	b.WriteRune(bidiPDI)
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBidiControls tests the BidiControls method
+func TestBidiControls(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		policy   BidiPolicy
+		expected string
+	}{
+		{"no controls", "invoice.pdf", BidiStrip, "invoice.pdf"},
+		{"no controls isolate", "invoice.pdf", BidiIsolate, "invoice.pdf"},
+		{"strip override", "invoice\u202Efdp.exe", BidiStrip, "invoicefdp.exe"},
+		{"strip all", "\u061C\u200E\u200F\u202A\u202B\u202C\u202D\u202E\u2066\u2067\u2068\u2069x", BidiStrip, "x"},
+		{"escape override", "invoice\u202Efdp.exe", BidiEscape, `invoice\u202Efdp.exe`},
+		{"escape mark", "a\u200Fb", BidiEscape, `a\u200Fb`},
+		{"isolate override", "invoice\u202Efdp.exe", BidiIsolate, "\u2068invoice\u202Efdp.exe\u202C\u2069"},
+		{"isolate balanced", "a\u202Eb\u202C", BidiIsolate, "\u2068a\u202Eb\u202C\u2069"},
+		{"isolate open isolate", "a\u2067b", BidiIsolate, "\u2068a\u2067b\u2069\u2069"},
+		{"isolate nested", "\u2067a\u202Eb", BidiIsolate, "\u2068\u2067a\u202Eb\u202C\u2069\u2069"},
+		{"isolate pdi closes embeddings", "\u2067a\u202Eb\u2069c", BidiIsolate, "\u2068\u2067a\u202Eb\u2069c\u2069"},
+		{"isolate already wrapped", "\u2068a\u202Eb\u202C\u2069", BidiIsolate, "\u2068a\u202Eb\u202C\u2069"},
+		{"isolate two isolates", "\u2068a\u2069\u2068b\u2069", BidiIsolate, "\u2068\u2068a\u2069\u2068b\u2069\u2069"},
+		{"isolate marks only", "a\u200Fb", BidiIsolate, "\u2068a\u200Fb\u2069"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := BidiControls(test.input, test.policy)
+			assert.Equal(t, test.expected, output)
+			assert.Equal(t, output, BidiControls(output, test.policy))
+		})
+	}
+}
+
+// BenchmarkBidiControls benchmarks the BidiControls method
+func BenchmarkBidiControls(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = BidiControls("invoice\u202Efdp.exe", BidiStrip)
+	}
+}
+
+// ExampleBidiControls example using BidiControls()
+func ExampleBidiControls() {
+	fmt.Println(BidiControls("invoice\u202Efdp.exe", BidiStrip))
+	fmt.Println(BidiControls("invoice\u202Efdp.exe", BidiEscape))
+	// Output:
+	// invoicefdp.exe
+	// invoice\u202Efdp.exe
+}
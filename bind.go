@@ -0,0 +1,26 @@
+package sanitize
+
+// Sanitizable is implemented by models that sanitize their own fields (usually with the
+// sanitizers of this package), it is used by Bind to sanitize a model after binding
+type Sanitizable interface {
+	Sanitize()
+}
+
+// BindFunc decodes a request into obj, this matches the binding methods of the common web
+// frameworks: gin's c.ShouldBind (or ShouldBindJSON), echo's c.Bind and fiber's c.BodyParser
+type BindFunc func(obj any) error
+
+// Bind decodes the request into the model with the framework's binding function and then
+// sanitizes the model, so the fields are sanitized right after binding and before they are
+// validated. The model is not sanitized if the binding fails.
+//
+//	if err := sanitize.Bind(c.ShouldBind, &form); err != nil { ... }
+//
+//	View examples: bind_test.go
+func Bind[T Sanitizable](bind BindFunc, model T) error {
+	if err := bind(model); err != nil {
+		return err
+	}
+	model.Sanitize()
+	return nil
+}
@@ -0,0 +1,72 @@
+package sanitize
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signupForm is a model that sanitizes its own fields
+type signupForm struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// Sanitize sanitizes the fields of the form
+func (f *signupForm) Sanitize() {
+	f.Email = Email(f.Email, false)
+	f.Name = FormalName(f.Name, WithCollapseSpaces(), WithTrim())
+}
+
+// jsonBinder returns a BindFunc that decodes the body (like a framework's binding method)
+func jsonBinder(body string) BindFunc {
+	return func(obj any) error {
+		return json.Unmarshal([]byte(body), obj)
+	}
+}
+
+// TestBind tests the Bind method
+func TestBind(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bound and sanitized", func(t *testing.T) {
+		var form signupForm
+		err := Bind(jsonBinder(`{"email":" Bob@Example.COM ","name":"  Bob   Smith! "}`), &form)
+		require.NoError(t, err)
+		assert.Equal(t, "bob@example.com", form.Email)
+		assert.Equal(t, "Bob Smith", form.Name)
+	})
+
+	t.Run("binding error", func(t *testing.T) {
+		form := signupForm{Email: "Keep@Example.com"}
+		errBind := errors.New("bad request")
+		err := Bind(func(any) error { return errBind }, &form)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errBind)
+		assert.Equal(t, "Keep@Example.com", form.Email)
+	})
+}
+
+// BenchmarkBind benchmarks the Bind method
+func BenchmarkBind(b *testing.B) {
+	bind := jsonBinder(`{"email":" Bob@Example.COM ","name":"Bob Smith"}`)
+	for i := 0; i < b.N; i++ {
+		var form signupForm
+		_ = Bind(bind, &form)
+	}
+}
+
+// ExampleBind example using Bind()
+func ExampleBind() {
+	var form signupForm
+	bind := func(obj any) error { // c.ShouldBind, c.Bind or c.BodyParser
+		return json.Unmarshal([]byte(`{"email":" Bob@Example.COM ","name":" Bob Smith "}`), obj)
+	}
+	err := Bind(bind, &form)
+	fmt.Println(form.Email, form.Name, err)
+	// Output: bob@example.com Bob Smith <nil>
+}
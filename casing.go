@@ -0,0 +1,149 @@
+package sanitize
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CamelCase returns the words of the string joined in lower camel case
+// (exampleStringValue). Any character that is not a letter or number is
+// treated as a word separator, as is a change from lower to upper case.
+// A word that would merge with a capitalized single letter word before it
+// starts in lowercase instead (a b c => aBc) so that the result reads back
+// as the same words.
+//
+//	View examples: casing_test.go
+func CamelCase(original string) string {
+	words := splitWords(original)
+	var b strings.Builder
+	b.Grow(len(original))
+	upperLetter := false
+	for i, word := range words {
+		word = strings.ToLower(word)
+		first, size := utf8.DecodeRuneInString(word)
+		next, _ := utf8.DecodeRuneInString(word[size:])
+		merges := unicode.IsLetter(first) && !unicode.IsLower(next)
+		if i == 0 || (upperLetter && merges) {
+			b.WriteString(word)
+			upperLetter = false
+			continue
+		}
+		b.WriteString(FirstToUpper(word))
+		upperLetter = size == len(word) && unicode.IsUpper(unicode.ToUpper(first))
+	}
+	return b.String()
+}
+
+// goKeywords are the reserved words of the Go language
+var goKeywords = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true,
+	"defer": true, "else": true, "fallthrough": true, "for": true, "func": true, "go": true, "goto": true,
+	"if": true, "import": true, "interface": true, "map": true, "package": true, "range": true,
+	"return": true, "select": true, "struct": true, "switch": true, "type": true, "var": true,
+}
+
+// GoIdentifier returns a valid Go identifier from the string. Runes other than letters,
+// digits and underscores are removed, a leading digit is prefixed with an underscore and
+// keywords get a trailing underscore (type => type_). Set exported to true to upper case the
+// first letter, an X is added if the identifier does not start with a letter (1st => X1st).
+// An empty string is returned if there are no letters, digits or underscores.
+//
+//	View examples: casing_test.go
+func GoIdentifier(original string, exported bool) string {
+	var b strings.Builder
+	b.Grow(len(original) + 1)
+	for _, r := range original {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	identifier := b.String()
+	if len(identifier) == 0 {
+		return ""
+	}
+
+	first, _ := utf8.DecodeRuneInString(identifier)
+	switch {
+	case exported && unicode.IsUpper(unicode.ToUpper(first)):
+		return FirstToUpper(identifier)
+	case exported:
+		return "X" + identifier
+	case unicode.IsDigit(first):
+		return "_" + identifier
+	case goKeywords[identifier]:
+		return identifier + "_"
+	}
+	return identifier
+}
+
+// KebabCase returns the words of the string in lowercase joined
+// with hyphens (example-string-value).
+//
+//	View examples: casing_test.go
+func KebabCase(original string) string {
+	return strings.ToLower(strings.Join(splitWords(original), "-"))
+}
+
+// SnakeCase returns the words of the string in lowercase joined
+// with underscores (example_string_value).
+//
+//	View examples: casing_test.go
+func SnakeCase(original string) string {
+	return strings.ToLower(strings.Join(splitWords(original), "_"))
+}
+
+// TitleCase upper cases the first letter of every word and lower cases the
+// rest of the word. Words are separated by white space or hyphens, all other
+// characters are preserved.
+//
+//	View examples: casing_test.go
+func TitleCase(original string) string {
+	runes := []rune(original)
+	start := true
+	for i, r := range runes {
+		switch {
+		case unicode.IsSpace(r) || r == '-':
+			start = true
+		case start:
+			runes[i] = unicode.ToUpper(r)
+			start = false
+		default:
+			runes[i] = unicode.ToLower(r)
+		}
+	}
+	return string(runes)
+}
+
+// splitWords breaks a string into words on any non-alphanumeric character and on
+// case changes (fooBar => foo, Bar and HTTPServer => HTTP, Server)
+func splitWords(original string) []string {
+	var words []string
+	runes := []rune(original)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+			continue
+		}
+
+		// Split on a case boundary: fooBar or the last capital in HTTPServer
+		prev := runes[i-1]
+		if unicode.IsUpper(r) && (unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+			(unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
@@ -0,0 +1,210 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCamelCase tests the CamelCase method
+func TestCamelCase(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"spaces", "example string value", "exampleStringValue"},
+		{"snake case", "example_string_value", "exampleStringValue"},
+		{"kebab case", "example-string-value", "exampleStringValue"},
+		{"pascal case", "ExampleStringValue", "exampleStringValue"},
+		{"acronym", "HTTP server ID", "httpServerId"},
+		{"acronym prefix", "HTTPServer", "httpServer"},
+		{"symbols", "  !!example**string ", "exampleString"},
+		{"numbers", "version 2 release", "version2Release"},
+		{"single letters", "a b c d", "aBcD"},
+		{"single letter then word", "a b cd", "aBCd"},
+		{"single letter then number", "a b c1", "aBc1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, CamelCase(test.input))
+		})
+	}
+}
+
+// BenchmarkCamelCase benchmarks the CamelCase method
+func BenchmarkCamelCase(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = CamelCase("This is the test string.")
+	}
+}
+
+// ExampleCamelCase example using CamelCase()
+func ExampleCamelCase() {
+	fmt.Println(CamelCase("Example String value!"))
+	// Output: exampleStringValue
+}
+
+// TestKebabCase tests the KebabCase method
+func TestKebabCase(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"spaces", "Example String Value", "example-string-value"},
+		{"camel case", "exampleStringValue", "example-string-value"},
+		{"snake case", "example__string_value", "example-string-value"},
+		{"acronym", "HTTPServerID", "http-server-id"},
+		{"symbols", "--example!string--", "example-string"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, KebabCase(test.input))
+		})
+	}
+}
+
+// BenchmarkKebabCase benchmarks the KebabCase method
+func BenchmarkKebabCase(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = KebabCase("This is the test string.")
+	}
+}
+
+// ExampleKebabCase example using KebabCase()
+func ExampleKebabCase() {
+	fmt.Println(KebabCase("Example String value!"))
+	// Output: example-string-value
+}
+
+// TestSnakeCase tests the SnakeCase method
+func TestSnakeCase(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"spaces", "Example String Value", "example_string_value"},
+		{"camel case", "exampleStringValue", "example_string_value"},
+		{"kebab case", "example-string-value", "example_string_value"},
+		{"acronym", "userID", "user_id"},
+		{"numbers", "address line 2", "address_line_2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, SnakeCase(test.input))
+		})
+	}
+}
+
+// BenchmarkSnakeCase benchmarks the SnakeCase method
+func BenchmarkSnakeCase(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = SnakeCase("This is the test string.")
+	}
+}
+
+// ExampleSnakeCase example using SnakeCase()
+func ExampleSnakeCase() {
+	fmt.Println(SnakeCase("Example String value!"))
+	// Output: example_string_value
+}
+
+// TestTitleCase tests the TitleCase method
+func TestTitleCase(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"lowercase", "this is a title", "This Is A Title"},
+		{"uppercase", "THIS IS A TITLE", "This Is A Title"},
+		{"hyphen", "jean-luc picard", "Jean-Luc Picard"},
+		{"extra spaces", "  two  spaces ", "  Two  Spaces "},
+		{"unicode", "élan vital", "Élan Vital"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, TitleCase(test.input))
+		})
+	}
+}
+
+// BenchmarkTitleCase benchmarks the TitleCase method
+func BenchmarkTitleCase(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = TitleCase("This is the test string.")
+	}
+}
+
+// ExampleTitleCase example using TitleCase()
+func ExampleTitleCase() {
+	fmt.Println(TitleCase("example STRING value"))
+	// Output: Example String Value
+}
+
+// TestGoIdentifier tests the GoIdentifier method
+func TestGoIdentifier(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		exported bool
+		expected string
+	}{
+		{"empty", "", false, ""},
+		{"symbols only", "!@#", false, ""},
+		{"valid", "myVar", false, "myVar"},
+		{"spaces and symbols", "my-var name!", false, "myvarname"},
+		{"leading digit", "1st", false, "_1st"},
+		{"keyword", "type", false, "type_"},
+		{"keyword with symbols", "ra-nge", false, "range_"},
+		{"predeclared", "string", false, "string"},
+		{"underscore", "_private", false, "_private"},
+		{"unicode", "café", false, "café"},
+		{"exported", "myVar", true, "MyVar"},
+		{"exported keyword", "type", true, "Type"},
+		{"exported leading digit", "1st", true, "X1st"},
+		{"exported underscore", "_private", true, "X_private"},
+		{"exported unicode", "élan", true, "Élan"},
+		{"exported uncased letter", "日本", true, "X日本"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, GoIdentifier(test.input, test.exported))
+		})
+	}
+}
+
+// BenchmarkGoIdentifier benchmarks the GoIdentifier method
+func BenchmarkGoIdentifier(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = GoIdentifier("my-var name!", true)
+	}
+}
+
+// ExampleGoIdentifier example using GoIdentifier()
+func ExampleGoIdentifier() {
+	fmt.Println(GoIdentifier("type", false), GoIdentifier("1st-place", true))
+	// Output: type_ X1stplace
+}
@@ -0,0 +1,100 @@
+package sanitize
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Supported source charsets
+const (
+	charsetASCII       = "ascii"
+	charsetAuto        = "auto"
+	charsetLatin1      = "latin1"
+	charsetUTF8        = "utf8"
+	charsetWindows1252 = "windows1252"
+)
+
+// utf8BOM is the UTF-8 byte order mark
+const utf8BOM = "\xef\xbb\xbf"
+
+// charsetSeparators are removed from charset names before they are looked up
+var charsetSeparators = strings.NewReplacer("-", "", "_", "", " ", "")
+
+// charsetNames maps the charset names and aliases (lowercase, without separators) to a supported charset
+var charsetNames = map[string]string{
+	"":            charsetAuto,
+	"ascii":       charsetASCII,
+	"auto":        charsetAuto,
+	"cp1252":      charsetWindows1252,
+	"cp819":       charsetLatin1,
+	"iso88591":    charsetLatin1,
+	"l1":          charsetLatin1,
+	"latin1":      charsetLatin1,
+	"usascii":     charsetASCII,
+	"utf8":        charsetUTF8,
+	"win1252":     charsetWindows1252,
+	"windows1252": charsetWindows1252,
+}
+
+// windows1252 maps the bytes 0x80-0x9F of Windows-1252 to their runes (the five
+// undefined bytes map to the matching C1 control characters, like the WHATWG decoder)
+var windows1252 = [32]rune{
+	'€', '\u0081', '‚', 'ƒ', '„', '…', '†', '‡',
+	'ˆ', '‰', 'Š', '‹', 'Œ', '\u008d', 'Ž', '\u008f',
+	'\u0090', '‘', '’', '“', '”', '•', '–', '—',
+	'˜', '™', 'š', '›', 'œ', '\u009d', 'ž', 'Ÿ',
+}
+
+// ToUTF8 converts the input from the source charset to a UTF-8 string. The supported charsets
+// are UTF-8, US-ASCII, ISO-8859-1 (Latin-1) and Windows-1252 (and their common aliases). An
+// empty (or "auto") charset detects the encoding: valid UTF-8 is kept as-is and anything else
+// is decoded as Windows-1252, the usual encoding of legacy form posts and CSV files. A leading
+// UTF-8 byte order mark is removed.
+//
+//	View examples: charset_test.go
+func ToUTF8(input []byte, sourceCharset string) (string, error) {
+	name := charsetSeparators.Replace(strings.ToLower(strings.TrimSpace(sourceCharset)))
+	charset, ok := charsetNames[name]
+	if !ok {
+		return "", fmt.Errorf("%w: unsupported charset %q", ErrInvalidEncoding, sourceCharset)
+	}
+
+	switch charset {
+	case charsetAuto:
+		if utf8.Valid(input) {
+			return strings.TrimPrefix(string(input), utf8BOM), nil
+		}
+		return decodeSingleByte(input, true), nil
+	case charsetUTF8:
+		if !utf8.Valid(input) {
+			return "", fmt.Errorf("%w: invalid utf-8", ErrInvalidEncoding)
+		}
+		return strings.TrimPrefix(string(input), utf8BOM), nil
+	case charsetASCII:
+		for i, c := range input {
+			if c >= utf8.RuneSelf {
+				return "", fmt.Errorf("%w: non-ascii byte 0x%02x at %d", ErrInvalidEncoding, c, i)
+			}
+		}
+		return string(input), nil
+	}
+	return decodeSingleByte(input, charset == charsetWindows1252), nil
+}
+
+// decodeSingleByte decodes Latin-1 (each byte is its code point) or Windows-1252
+func decodeSingleByte(input []byte, windows bool) string {
+	var b strings.Builder
+	b.Grow(len(input) + len(input)/2)
+	for _, c := range input {
+		switch {
+		case c < utf8.RuneSelf:
+			b.WriteByte(c)
+		case windows && c < 0xa0:
+			b.WriteRune(windows1252[c-0x80])
+		default:
+			b.WriteRune(rune(c))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,79 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestToUTF8 tests the ToUTF8 method
+func TestToUTF8(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    []byte
+			charset  string
+			expected string
+		}{
+			{"empty", nil, "", ""},
+			{"ascii auto", []byte("plain text"), "", "plain text"},
+			{"utf-8 auto", []byte("café"), "", "café"},
+			{"utf-8 bom", []byte("\xef\xbb\xbfcafé"), "utf-8", "café"},
+			{"windows-1252 auto", []byte("caf\xe9 \x93quoted\x94 \x80 5"), "auto", "café “quoted” € 5"},
+			{"windows-1252", []byte("\x85 \x96 \x99"), "Windows-1252", "… – ™"},
+			{"windows-1252 alias", []byte("\x8a\x9a"), "cp1252", "Šš"},
+			{"windows-1252 undefined byte", []byte("\x81"), "windows-1252", "\u0081"},
+			{"latin-1", []byte("na\xefve \x80"), "ISO-8859-1", "naïve \u0080"},
+			{"latin-1 alias", []byte("\xdf"), "latin1", "ß"},
+			{"us-ascii", []byte("abc"), "US-ASCII", "abc"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := ToUTF8(test.input, test.charset)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name    string
+			input   []byte
+			charset string
+		}{
+			{"unsupported charset", []byte("abc"), "shift_jis"},
+			{"invalid utf-8", []byte("caf\xe9"), "utf-8"},
+			{"non-ascii", []byte("caf\xe9"), "ascii"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := ToUTF8(test.input, test.charset)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidEncoding)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkToUTF8 benchmarks the ToUTF8 method
+func BenchmarkToUTF8(b *testing.B) {
+	input := []byte("caf\xe9 \x93quoted\x94 \x80 5")
+	for i := 0; i < b.N; i++ {
+		_, _ = ToUTF8(input, "")
+	}
+}
+
+// ExampleToUTF8 example using ToUTF8()
+func ExampleToUTF8() {
+	output, err := ToUTF8([]byte("caf\xe9 \x93quoted\x94"), "windows-1252")
+	fmt.Println(output, err)
+	// Output: café “quoted” <nil>
+}
@@ -0,0 +1,69 @@
+//go:build js && wasm
+
+// Package main exposes the sanitizers to JavaScript when compiled to WebAssembly, so a
+// frontend can apply the same rules as the Go backend:
+//
+//	GOOS=js GOARCH=wasm go build -o go-sanitize.wasm ./cmd/go-sanitize-wasm
+//
+// After the module is started (with wasm_exec.js) the global goSanitize object has a function
+// for each of the built-in sanitizers and presets by their lowercase name, and a chain function:
+//
+//	goSanitize.email("Bob@Example.COM")              // "bob@example.com"
+//	goSanitize.forminput("  <b>John</b> ")           // "John"
+//	goSanitize.chain("trim|singleline|xss", value)   // runs the sanitizers in order
+//	goSanitize.names()                                // the names of the sanitizers
+//
+// The functions return null if the value is not a string (chain also for an unknown name).
+package main
+
+import (
+	"sort"
+	"strings"
+	"syscall/js"
+
+	"github.com/mrz1836/go-sanitize"
+)
+
+func main() {
+	fns := sanitize.FuncsByName()
+	exports := js.Global().Get("Object").New()
+
+	names := make([]any, 0, len(fns))
+	for name, fn := range fns {
+		exports.Set(name, jsFunc(fn))
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i].(string) < names[j].(string) })
+
+	exports.Set("names", js.FuncOf(func(js.Value, []js.Value) any {
+		return js.ValueOf(names)
+	}))
+	exports.Set("chain", js.FuncOf(func(_ js.Value, args []js.Value) any {
+		if len(args) < 2 || args[0].Type() != js.TypeString || args[1].Type() != js.TypeString {
+			return js.Null()
+		}
+		var steps []sanitize.SanitizeFunc
+		for _, name := range strings.Split(args[0].String(), "|") {
+			fn, ok := fns[strings.ToLower(strings.TrimSpace(name))]
+			if !ok {
+				return js.Null()
+			}
+			steps = append(steps, fn)
+		}
+		return sanitize.Chain(steps...)(args[1].String())
+	}))
+	js.Global().Set("goSanitize", exports)
+
+	// Keep the module running so the functions can be called
+	select {}
+}
+
+// jsFunc wraps a sanitizer as a JavaScript function of a single string
+func jsFunc(fn sanitize.SanitizeFunc) js.Func {
+	return js.FuncOf(func(_ js.Value, args []js.Value) any {
+		if len(args) == 0 || args[0].Type() != js.TypeString {
+			return js.Null()
+		}
+		return fn(args[0].String())
+	})
+}
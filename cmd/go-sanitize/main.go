@@ -0,0 +1,245 @@
+// Package main is the go-sanitize command, it applies the sanitizers of the package to the
+// lines of files (or stdin), to the values of JSON documents or to the columns of CSV files.
+//
+//	go-sanitize email < list.txt
+//	go-sanitize -chain 'trim|singleline|xss' file.txt
+//	go-sanitize -csv -header -columns email,name email users.csv
+//	go-sanitize -json -paths user.email email < events.ndjson
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mrz1836/go-sanitize"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// config holds the command line flags
+type config struct {
+	chain   string
+	columns string
+	csv     bool
+	header  bool
+	json    bool
+	list    bool
+	paths   string
+}
+
+// run runs the command and returns the exit code
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var cfg config
+	flags := flag.NewFlagSet("go-sanitize", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&cfg.chain, "chain", "", "sanitizers to run in order, separated by | (trim|singleline|xss)")
+	flags.StringVar(&cfg.columns, "columns", "", "CSV columns to sanitize (1-based indexes or header names)")
+	flags.BoolVar(&cfg.csv, "csv", false, "sanitize the fields of CSV input")
+	flags.BoolVar(&cfg.header, "header", false, "keep the first CSV record (the header) as-is")
+	flags.BoolVar(&cfg.json, "json", false, "sanitize the string values of JSON (or NDJSON) input")
+	flags.BoolVar(&cfg.list, "list", false, "list the sanitizer names")
+	flags.StringVar(&cfg.paths, "paths", "", "JSON paths to sanitize (user.email,items.*.name)")
+	flags.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "usage: go-sanitize [flags] [sanitizer] [file ...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	fns := sanitize.FuncsByName()
+	if cfg.list {
+		names := make([]string, 0, len(fns))
+		for name := range fns {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		_, _ = fmt.Fprintln(stdout, strings.Join(names, "\n"))
+		return 0
+	}
+
+	files := flags.Args()
+	if len(cfg.chain) == 0 {
+		if len(files) == 0 {
+			flags.Usage()
+			return 2
+		}
+		cfg.chain, files = files[0], files[1:]
+	}
+	fn, err := pipeline(fns, cfg.chain)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "go-sanitize:", err)
+		return 2
+	}
+
+	out := bufio.NewWriter(stdout)
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	for _, name := range files {
+		if err = process(cfg, fn, name, stdin, out); err != nil {
+			_, _ = fmt.Fprintln(stderr, "go-sanitize:", err)
+			_ = out.Flush()
+			return 1
+		}
+	}
+	if err = out.Flush(); err != nil {
+		_, _ = fmt.Fprintln(stderr, "go-sanitize:", err)
+		return 1
+	}
+	return 0
+}
+
+// pipeline returns the chain of the named sanitizers (separated by |)
+func pipeline(fns map[string]sanitize.SanitizeFunc, chain string) (sanitize.SanitizeFunc, error) {
+	var steps []sanitize.SanitizeFunc
+	for _, name := range strings.Split(chain, "|") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		fn, ok := fns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown sanitizer %q (use -list to see the names)", name)
+		}
+		steps = append(steps, fn)
+	}
+	return sanitize.Chain(steps...), nil
+}
+
+// process sanitizes the named file ("-" is stdin) to the output
+func process(cfg config, fn sanitize.SanitizeFunc, name string, stdin io.Reader, out io.Writer) error {
+	in := stdin
+	if name != "-" {
+		file, err := os.Open(name) //nolint:gosec // reading the files given on the command line
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+		in = file
+	}
+
+	switch {
+	case cfg.json:
+		return processJSON(cfg, fn, in, out)
+	case cfg.csv:
+		return processCSV(cfg, fn, in, out)
+	}
+	return processLines(fn, in, out)
+}
+
+// processLines sanitizes each line of the input
+func processLines(fn sanitize.SanitizeFunc, in io.Reader, out io.Writer) error {
+	reader := bufio.NewReader(in)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+			if _, werr := io.WriteString(out, fn(line)+"\n"); werr != nil {
+				return werr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// processJSON sanitizes the string values of each JSON document of the input
+func processJSON(cfg config, fn sanitize.SanitizeFunc, in io.Reader, out io.Writer) error {
+	rules := map[string]sanitize.SanitizeFunc{"": fn}
+	if len(cfg.paths) > 0 {
+		rules = make(map[string]sanitize.SanitizeFunc)
+		for _, path := range strings.Split(cfg.paths, ",") {
+			rules[strings.TrimSpace(path)] = fn
+		}
+	}
+
+	decoder := json.NewDecoder(in)
+	decoder.UseNumber()
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	for {
+		var document any
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := encoder.Encode(sanitize.DeepWithRules(document, rules)); err != nil {
+			return err
+		}
+	}
+}
+
+// processCSV sanitizes the selected columns (or every column) of the CSV input
+func processCSV(cfg config, fn sanitize.SanitizeFunc, in io.Reader, out io.Writer) error {
+	reader := csv.NewReader(in)
+	reader.FieldsPerRecord = -1
+	writer := csv.NewWriter(out)
+
+	var selected map[int]bool
+	for n := 0; ; n++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+		if n == 0 {
+			if selected, err = csvColumns(cfg.columns, record, cfg.header); err != nil {
+				return err
+			}
+		}
+		if n > 0 || !cfg.header {
+			for i := range record {
+				if selected == nil || selected[i] {
+					record[i] = fn(record[i])
+				}
+			}
+		}
+		if err = writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvColumns returns the indexes of the selected columns (nil for every column), the columns
+// are 1-based indexes or the names of the header record
+func csvColumns(columns string, header []string, hasHeader bool) (map[int]bool, error) {
+	if len(columns) == 0 {
+		return nil, nil
+	}
+	selected := make(map[int]bool)
+	for _, column := range strings.Split(columns, ",") {
+		column = strings.TrimSpace(column)
+		if index, err := strconv.Atoi(column); err == nil && index > 0 {
+			selected[index-1] = true
+			continue
+		}
+		found := false
+		for i, name := range header {
+			if hasHeader && strings.EqualFold(strings.TrimSpace(name), column) {
+				selected[i], found = true, true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column %q (names require -header)", column)
+		}
+	}
+	return selected, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runCommand runs the command with the input and returns the exit code and output
+func runCommand(input string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(input), &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+// TestRun tests the run method
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		args     []string
+		input    string
+		expected string
+	}{
+		{"lines", []string{"email"}, "Bob@Example.COM\r\nmailto:alice@example.com\n", "bob@example.com\nalice@example.com\n"},
+		{"no trailing new line", []string{"numeric"}, "(555) 123-4567", "5551234567\n"},
+		{"empty input", []string{"email"}, "", ""},
+		{"stdin file", []string{"alpha", "-"}, "a1b2\n", "ab\n"},
+		{"chain", []string{"-chain", "trim|singleline|XSS"}, "  eval(hi) \n", "hi)\n"},
+		{"chain argument", []string{"html|upper"}, "<b>bold</b>\n", "BOLD\n"},
+		{"preset", []string{"forminput"}, "  John \t <b>Smith</b> \n", "John Smith\n"},
+		{
+			"json", []string{"-json", "trim"}, `{"name":" Bob ","tags":[" a "],"age":30}`,
+			"{\"age\":30,\"name\":\"Bob\",\"tags\":[\"a\"]}\n",
+		},
+		{
+			"ndjson paths", []string{"-json", "-paths", "user.email", "email"},
+			"{\"user\":{\"email\":\"A@B.COM\",\"name\":\"A@B\"}}\n{\"user\":{\"email\":\"<C@D.com>\"}}\n",
+			"{\"user\":{\"email\":\"a@b.com\",\"name\":\"A@B\"}}\n{\"user\":{\"email\":\"c@d.com\"}}\n",
+		},
+		{"csv", []string{"-csv", "trim"}, " a , b \n c ,d\n", "a,b\nc,d\n"},
+		{
+			"csv columns by index", []string{"-csv", "-columns", "2", "numeric"},
+			"1a,2b,3c\n4d,5e\n", "1a,2,3c\n4d,5\n",
+		},
+		{
+			"csv columns by name", []string{"-csv", "-header", "-columns", "Email", "email"},
+			"name,email\nBob,Bob@Example.COM\n", "name,email\nBob,bob@example.com\n",
+		},
+		{"csv header", []string{"-csv", "-header", "upper"}, "name\nbob\n", "name\nBOB\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			code, stdout, stderr := runCommand(test.input, test.args...)
+			assert.Equal(t, 0, code, stderr)
+			assert.Equal(t, test.expected, stdout)
+		})
+	}
+
+	t.Run("files", func(t *testing.T) {
+		dir := t.TempDir()
+		first, second := filepath.Join(dir, "first.txt"), filepath.Join(dir, "second.txt")
+		require.NoError(t, os.WriteFile(first, []byte("A1\n"), 0o600))
+		require.NoError(t, os.WriteFile(second, []byte("B2\n"), 0o600))
+
+		code, stdout, _ := runCommand("", "alpha", first, second)
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "A\nB\n", stdout)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		code, stdout, _ := runCommand("", "-list")
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout, "email\n")
+		assert.Contains(t, stdout, "logsafe\n")
+		assert.Contains(t, stdout, "trim\n")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			args  []string
+			input string
+			code  int
+		}{
+			{"no sanitizer", nil, "", 2},
+			{"unknown flag", []string{"-nope"}, "", 2},
+			{"unknown sanitizer", []string{"nope"}, "", 2},
+			{"unknown in chain", []string{"-chain", "trim|nope"}, "", 2},
+			{"missing file", []string{"trim", filepath.Join(t.TempDir(), "missing.txt")}, "", 1},
+			{"invalid json", []string{"-json", "trim"}, "{", 1},
+			{"invalid csv", []string{"-csv", "trim"}, "\"a\n", 1},
+			{"unknown column", []string{"-csv", "-columns", "email", "trim"}, "email\n", 1},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				code, _, stderr := runCommand(test.input, test.args...)
+				assert.Equal(t, test.code, code)
+				assert.NotEmpty(t, stderr)
+			})
+		}
+	})
+}
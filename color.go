@@ -0,0 +1,176 @@
+package sanitize
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// cssColorNames are the CSS named colors and keywords accepted by CSSColor()
+var cssColorNames = map[string]bool{
+	"aliceblue": true, "antiquewhite": true, "aqua": true, "aquamarine": true, "azure": true, "beige": true,
+	"bisque": true, "black": true, "blanchedalmond": true, "blue": true, "blueviolet": true, "brown": true,
+	"burlywood": true, "cadetblue": true, "chartreuse": true, "chocolate": true, "coral": true,
+	"cornflowerblue": true, "cornsilk": true, "crimson": true, "currentcolor": true, "cyan": true,
+	"darkblue": true, "darkcyan": true, "darkgoldenrod": true, "darkgray": true, "darkgreen": true,
+	"darkgrey": true, "darkkhaki": true, "darkmagenta": true, "darkolivegreen": true, "darkorange": true,
+	"darkorchid": true, "darkred": true, "darksalmon": true, "darkseagreen": true, "darkslateblue": true,
+	"darkslategray": true, "darkslategrey": true, "darkturquoise": true, "darkviolet": true, "deeppink": true,
+	"deepskyblue": true, "dimgray": true, "dimgrey": true, "dodgerblue": true, "firebrick": true,
+	"floralwhite": true, "forestgreen": true, "fuchsia": true, "gainsboro": true, "ghostwhite": true,
+	"gold": true, "goldenrod": true, "gray": true, "green": true, "greenyellow": true, "grey": true,
+	"honeydew": true, "hotpink": true, "indianred": true, "indigo": true, "ivory": true, "khaki": true,
+	"lavender": true, "lavenderblush": true, "lawngreen": true, "lemonchiffon": true, "lightblue": true,
+	"lightcoral": true, "lightcyan": true, "lightgoldenrodyellow": true, "lightgray": true, "lightgreen": true,
+	"lightgrey": true, "lightpink": true, "lightsalmon": true, "lightseagreen": true, "lightskyblue": true,
+	"lightslategray": true, "lightslategrey": true, "lightsteelblue": true, "lightyellow": true, "lime": true,
+	"limegreen": true, "linen": true, "magenta": true, "maroon": true, "mediumaquamarine": true,
+	"mediumblue": true, "mediumorchid": true, "mediumpurple": true, "mediumseagreen": true,
+	"mediumslateblue": true, "mediumspringgreen": true, "mediumturquoise": true, "mediumvioletred": true,
+	"midnightblue": true, "mintcream": true, "mistyrose": true, "moccasin": true, "navajowhite": true,
+	"navy": true, "oldlace": true, "olive": true, "olivedrab": true, "orange": true, "orangered": true,
+	"orchid": true, "palegoldenrod": true, "palegreen": true, "paleturquoise": true, "palevioletred": true,
+	"papayawhip": true, "peachpuff": true, "peru": true, "pink": true, "plum": true, "powderblue": true,
+	"purple": true, "rebeccapurple": true, "red": true, "rosybrown": true, "royalblue": true,
+	"saddlebrown": true, "salmon": true, "sandybrown": true, "seagreen": true, "seashell": true, "sienna": true,
+	"silver": true, "skyblue": true, "slateblue": true, "slategray": true, "slategrey": true, "snow": true,
+	"springgreen": true, "steelblue": true, "tan": true, "teal": true, "thistle": true, "tomato": true,
+	"transparent": true, "turquoise": true, "violet": true, "wheat": true, "white": true, "whitesmoke": true,
+	"yellow": true, "yellowgreen": true,
+}
+
+// HexColor returns the hex color in its long, lowercase form (#FFF => #ffffff) or an error
+// if it is not a valid hex color. The leading # is optional and the 3, 4, 6 and 8 digit forms
+// are accepted (the 4 and 8 digit forms include the alpha channel).
+//
+//	View examples: color_test.go
+func HexColor(original string) (string, error) {
+	value := strings.TrimPrefix(strings.TrimSpace(original), "#")
+	if !isHexString(value) {
+		return "", fmt.Errorf("%w: %q is not a hex color", ErrInvalidColor, original)
+	}
+
+	switch len(value) {
+	case 3, 4:
+		long := make([]byte, 0, 9)
+		long = append(long, '#')
+		for i := 0; i < len(value); i++ {
+			long = append(long, value[i], value[i])
+		}
+		return strings.ToLower(string(long)), nil
+	case 6, 8:
+		return "#" + strings.ToLower(value), nil
+	}
+	return "", fmt.Errorf("%w: %q is not a hex color", ErrInvalidColor, original)
+}
+
+// CSSColor returns the CSS color in a normalized form or an error if it is not a valid color.
+// Hex colors (see HexColor), named colors (lowercased), rgb()/rgba() and hsl()/hsla() are
+// accepted, the functions are returned in the comma separated form (rgb(255 0 0 / 50%) =>
+// rgba(255, 0, 0, 50%)). Anything else (url(), expression(), var(), etc.) is rejected.
+//
+//	View examples: color_test.go
+func CSSColor(original string) (string, error) {
+	value := strings.ToLower(strings.TrimSpace(original))
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %q %s", ErrInvalidColor, original, reason)
+	}
+
+	switch {
+	case strings.HasPrefix(value, "#"):
+		return HexColor(value)
+	case cssColorNames[value]:
+		return value, nil
+	}
+
+	open := strings.IndexByte(value, '(')
+	if open < 0 || !strings.HasSuffix(value, ")") {
+		return "", invalid("is not a color")
+	}
+	name := strings.TrimSpace(value[:open])
+	if name != "rgb" && name != "rgba" && name != "hsl" && name != "hsla" {
+		return "", invalid("is not a color function")
+	}
+
+	args, err := splitColorArgs(value[open+1 : len(value)-1])
+	if err != nil {
+		return "", invalid(err.Error())
+	}
+	if len(args) != 3 && len(args) != 4 {
+		return "", invalid("has the wrong number of arguments")
+	}
+
+	// Validate the channels, rgb (0-255 or a percentage) or hsl (hue and two percentages)
+	for i, arg := range args[:3] {
+		var ok bool
+		switch {
+		case name[0] == 'r':
+			ok = isColorNumber(arg, 255, true)
+		case i == 0:
+			ok = isColorNumber(strings.TrimSuffix(arg, "deg"), 360, false)
+			args[0] = strings.TrimSuffix(arg, "deg")
+		default:
+			ok = strings.HasSuffix(arg, "%") && isColorNumber(arg, 100, true)
+		}
+		if !ok {
+			return "", invalid("has an invalid channel " + strconv.Quote(arg))
+		}
+	}
+	if len(args) == 4 {
+		if !isColorNumber(args[3], 1, true) {
+			return "", invalid("has an invalid alpha " + strconv.Quote(args[3]))
+		}
+		return name[:3] + "a(" + strings.Join(args, ", ") + ")", nil
+	}
+	return name[:3] + "(" + strings.Join(args, ", ") + ")", nil
+}
+
+// splitColorArgs splits the arguments of a color function, either comma separated
+// (255, 0, 0, 0.5) or space separated with an optional alpha (255 0 0 / 50%)
+func splitColorArgs(value string) ([]string, error) {
+	var args []string
+	if strings.Contains(value, ",") {
+		if strings.Contains(value, "/") {
+			return nil, errors.New("mixes commas and slashes")
+		}
+		for _, arg := range strings.Split(value, ",") {
+			args = append(args, strings.TrimSpace(arg))
+		}
+		return args, nil
+	}
+
+	channels, alpha := value, ""
+	if i := strings.IndexByte(value, '/'); i >= 0 {
+		channels, alpha = value[:i], strings.TrimSpace(value[i+1:])
+		if len(alpha) == 0 {
+			return nil, errors.New("has an empty alpha")
+		}
+	}
+	args = strings.Fields(channels)
+	if len(alpha) > 0 {
+		args = append(args, alpha)
+	}
+	return args, nil
+}
+
+// isColorNumber returns true if the value is a number between 0 and the limit, or a percentage
+// between 0% and 100% (if percentages are allowed)
+func isColorNumber(value string, limit float64, percent bool) bool {
+	if percent && strings.HasSuffix(value, "%") {
+		value, limit = value[:len(value)-1], 100
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	return err == nil && len(value) > 0 && (value[0] == '.' || (value[0] >= '0' && value[0] <= '9')) &&
+		n >= 0 && n <= limit
+}
+
+// isHexString returns true if the string only contains hex digits
+func isHexString(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if !isHex(value[i]) {
+			return false
+		}
+	}
+	return true
+}
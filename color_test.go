@@ -0,0 +1,160 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHexColor tests the HexColor method
+func TestHexColor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected string
+		}{
+			{"short", "#FFF", "#ffffff"},
+			{"short with alpha", "#F0F8", "#ff00ff88"},
+			{"long", "#1A2b3C", "#1a2b3c"},
+			{"long with alpha", "#1a2b3c4d", "#1a2b3c4d"},
+			{"no hash", "abc", "#aabbcc"},
+			{"spaces", "  #000000 ", "#000000"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := HexColor(test.input)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"hash only", "#"},
+			{"wrong length", "#12345"},
+			{"too long", "#123456789"},
+			{"not hex", "#ggg"},
+			{"double hash", "##fff"},
+			{"named color", "red"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := HexColor(test.input)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidColor)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkHexColor benchmarks the HexColor method
+func BenchmarkHexColor(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = HexColor("#FFF")
+	}
+}
+
+// ExampleHexColor example using HexColor()
+func ExampleHexColor() {
+	color, err := HexColor("#FFF")
+	fmt.Println(color, err)
+	// Output: #ffffff <nil>
+}
+
+// TestCSSColor tests the CSSColor method
+func TestCSSColor(t *testing.T) {
+	t.Parallel()
+
+	assert.Len(t, cssColorNames, 150)
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected string
+		}{
+			{"hex", "#ABC", "#aabbcc"},
+			{"named", "RebeccaPurple", "rebeccapurple"},
+			{"keyword", "transparent", "transparent"},
+			{"rgb", "rgb(255,0,0)", "rgb(255, 0, 0)"},
+			{"rgb spaces", "RGB( 255 , 128 , 0 )", "rgb(255, 128, 0)"},
+			{"rgb percentages", "rgb(100%, 50%, 0%)", "rgb(100%, 50%, 0%)"},
+			{"rgba", "rgba(0, 0, 0, 0.5)", "rgba(0, 0, 0, 0.5)"},
+			{"rgb with alpha", "rgb(0, 0, 0, .5)", "rgba(0, 0, 0, .5)"},
+			{"space syntax", "rgb(255 0 0 / 50%)", "rgba(255, 0, 0, 50%)"},
+			{"space syntax no alpha", "rgb(255 0 0)", "rgb(255, 0, 0)"},
+			{"hsl", "hsl(120, 100%, 50%)", "hsl(120, 100%, 50%)"},
+			{"hsl degrees", "hsl(120deg 100% 50%)", "hsl(120, 100%, 50%)"},
+			{"hsla", "hsla(120, 100%, 50%, 0.3)", "hsla(120, 100%, 50%, 0.3)"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := CSSColor(test.input)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"unknown name", "reddish"},
+			{"bad hex", "#12"},
+			{"channel out of range", "rgb(256, 0, 0)"},
+			{"negative channel", "rgb(-1, 0, 0)"},
+			{"alpha out of range", "rgba(0, 0, 0, 2)"},
+			{"too few arguments", "rgb(0, 0)"},
+			{"too many arguments", "rgb(0, 0, 0, 0, 0)"},
+			{"mixed separators", "rgb(0, 0, 0 / 1)"},
+			{"empty alpha", "rgb(0 0 0 /)"},
+			{"hsl without percentages", "hsl(120, 100, 50)"},
+			{"unknown function", "lab(50% 40 59)"},
+			{"url", "url(javascript:alert(1))"},
+			{"expression", "expression(alert(1))"},
+			{"var", "var(--color)"},
+			{"unclosed", "rgb(0, 0, 0"},
+			{"text channel", "rgb(red, 0, 0)"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := CSSColor(test.input)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidColor)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkCSSColor benchmarks the CSSColor method
+func BenchmarkCSSColor(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = CSSColor("rgb(255 0 0 / 50%)")
+	}
+}
+
+// ExampleCSSColor example using CSSColor()
+func ExampleCSSColor() {
+	color, err := CSSColor("rgb(255 0 0 / 50%)")
+	fmt.Println(color, err)
+	// Output: rgba(255, 0, 0, 50%) <nil>
+}
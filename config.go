@@ -0,0 +1,58 @@
+package sanitize
+
+import (
+	"strings"
+	"unicode"
+)
+
+// iniKeyReserved are the characters with a meaning in an INI key (separators, sections and comments)
+const iniKeyReserved = "=:[];#"
+
+// iniValueQuoted are the characters that need a quoted INI value (comment markers and quotes)
+const iniValueQuoted = ";#\"\\"
+
+// INIKey returns a value that is safe to use as a key in an INI file. The characters that
+// separate keys from values, start sections or comments (= : [ ] ; #), new lines and the
+// other control characters are removed, as is the white space around the key.
+//
+//	View examples: config_test.go
+func INIKey(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	key := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || strings.ContainsRune(iniKeyReserved, r) {
+			return -1
+		}
+		return r
+	}, strings.ToValidUTF8(original, ""))
+	return o.after(strings.TrimSpace(key))
+}
+
+// INIValue returns a value that is safe to use as a value in an INI file. New lines and tabs
+// are replaced with a space, terminal escape sequences and the other control characters are
+// removed, so the value can not add keys or sections. A value with comment markers (; #),
+// quotes, backslashes or white space at the start or end is double quoted (quotes and
+// backslashes are escaped), so that parsers do not read it as a comment or trim it.
+//
+//	View examples: config_test.go
+func INIValue(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	value := logLine(original, "")
+	if strings.ContainsAny(value, iniValueQuoted) || strings.TrimSpace(value) != value {
+		value = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+	}
+	return o.after(value)
+}
+
+// TOMLString escapes a value for use inside a TOML basic string ("..."), which can be a value
+// or a quoted key. Double quotes and backslashes are escaped, control characters are written
+// as \n, \t, \r, \b, \f or \uXXXX and invalid UTF-8 is removed, so the value can not end the
+// string or add keys and tables. The quotes around the string are not added.
+//
+//	View examples: config_test.go
+func TOMLString(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(escapeBasicString(original))
+}
@@ -0,0 +1,130 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestINIKey tests the INIKey method
+func TestINIKey(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"valid", "user.name", "user.name"},
+		{"separators", "name=admin:1", "nameadmin1"},
+		{"section", "[admin]", "admin"},
+		{"comments", "key ; comment # x", "key  comment  x"},
+		{"new lines", "key\n[admin]\nrole", "keyadminrole"},
+		{"white space", "  key name\t", "key name"},
+		{"invalid utf-8", "caf\xe9", "caf"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, INIKey(test.input))
+		})
+	}
+}
+
+// BenchmarkINIKey benchmarks the INIKey method
+func BenchmarkINIKey(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = INIKey("key\n[admin]\nrole=root")
+	}
+}
+
+// ExampleINIKey example using INIKey()
+func ExampleINIKey() {
+	fmt.Println(INIKey("name\n[admin]\nrole=root") + "=value")
+	// Output: nameadminroleroot=value
+}
+
+// TestINIValue tests the INIValue method
+func TestINIValue(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"plain", "hello world", "hello world"},
+		{"equals and brackets", "a=b [c]", "a=b [c]"},
+		{"new lines", "bob\n[admin]\nrole=root", "bob [admin] role=root"},
+		{"comment", "value ; comment", `"value ; comment"`},
+		{"hash", "#ff0000", `"#ff0000"`},
+		{"quotes", `say "hi"`, `"say \"hi\""`},
+		{"backslash", `C:\temp`, `"C:\\temp"`},
+		{"white space", " padded ", `" padded "`},
+		{"controls", "a\x00\x1b[31mb", "ab"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, INIValue(test.input))
+		})
+	}
+}
+
+// BenchmarkINIValue benchmarks the INIValue method
+func BenchmarkINIValue(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = INIValue("bob\n[admin]\nrole=root ; comment")
+	}
+}
+
+// ExampleINIValue example using INIValue()
+func ExampleINIValue() {
+	fmt.Println("name=" + INIValue("bob\n[admin]"))
+	fmt.Println("color=" + INIValue("#ff0000"))
+	// Output:
+	// name=bob [admin]
+	// color="#ff0000"
+}
+
+// TestTOMLString tests the TOMLString method
+func TestTOMLString(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"plain", "hello world", "hello world"},
+		{"quotes", `say "hi"`, `say \"hi\"`},
+		{"backslash", `C:\temp`, `C:\\temp`},
+		{"injection", "x\"\n[admin]\nrole = \"root", `x\"\n[admin]\nrole = \"root`},
+		{"control characters", "a\x00\x7f\tb", `a\u0000\u007f\tb`},
+		{"comment", "a # b", "a # b"},
+		{"unicode", "café", "café"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, TOMLString(test.input))
+		})
+	}
+}
+
+// BenchmarkTOMLString benchmarks the TOMLString method
+func BenchmarkTOMLString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = TOMLString("x\"\n[admin]\nrole = \"root")
+	}
+}
+
+// ExampleTOMLString example using TOMLString()
+func ExampleTOMLString() {
+	fmt.Printf("name = \"%s\"\n", TOMLString("bob\"\n[admin]"))
+	// Output: name = "bob\"\n[admin]"
+}
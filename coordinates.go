@@ -0,0 +1,136 @@
+package sanitize
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// coordinateUnits replaces the degree, minute and second symbols with spaces
+var coordinateUnits = strings.NewReplacer(
+	"°", " ", "º", " ", "˚", " ", "'", " ", "′", " ", "’", " ", "\"", " ", "″", " ", "”", " ",
+)
+
+// Coordinates parses a latitude and longitude pair and validates their ranges. Decimal degrees
+// (40.7128,-74.0060), degree symbols with cardinal letters (40.7128° N, 74.0060° W) and
+// degrees, minutes and seconds (40°42'46"N 74°0'22"W) are accepted. Southern and western
+// coordinates are returned as negative values, the latitude comes first unless the cardinal
+// letters say otherwise.
+//
+//	View examples: coordinates_test.go
+func Coordinates(original string) (lat, lon float64, err error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %q %s", ErrInvalidCoordinates, original, reason)
+	}
+
+	parts := splitCoordinates(strings.ToUpper(strings.TrimSpace(original)))
+	if len(parts) != 2 {
+		return 0, 0, invalid("is not a latitude and longitude pair")
+	}
+
+	var values [2]float64
+	var hemispheres [2]byte
+	for i, part := range parts {
+		if values[i], hemispheres[i], err = parseCoordinate(part); err != nil {
+			return 0, 0, invalid(err.Error())
+		}
+	}
+
+	// The cardinal letters decide the order (74° W 40° N)
+	if isLongitudeHemisphere(hemispheres[0]) || isLatitudeHemisphere(hemispheres[1]) {
+		values[0], values[1] = values[1], values[0]
+		hemispheres[0], hemispheres[1] = hemispheres[1], hemispheres[0]
+	}
+	if isLongitudeHemisphere(hemispheres[0]) || isLatitudeHemisphere(hemispheres[1]) {
+		return 0, 0, invalid("has conflicting cardinal directions")
+	}
+
+	lat, lon = values[0], values[1]
+	if lat < -90 || lat > 90 {
+		return 0, 0, invalid("has a latitude out of range")
+	}
+	if lon < -180 || lon > 180 {
+		return 0, 0, invalid("has a longitude out of range")
+	}
+	return lat, lon, nil
+}
+
+// splitCoordinates splits the (uppercased) input into the two coordinates
+func splitCoordinates(value string) []string {
+	if strings.ContainsAny(value, ",;") {
+		return strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' })
+	}
+
+	// Cardinal letters before (N 40 W 74) or after (40 N 74 W) each coordinate
+	if len(value) > 0 && isCardinal(value[0]) {
+		if i := strings.IndexAny(value[1:], "NSEW"); i >= 0 {
+			return []string{value[:i+1], value[i+1:]}
+		}
+	} else if i := strings.IndexAny(value, "NSEW"); i >= 0 && i < len(value)-1 {
+		return []string{value[:i+1], value[i+1:]}
+	}
+
+	return strings.Fields(value)
+}
+
+// parseCoordinate parses a single coordinate (decimal degrees or degrees, minutes and seconds)
+// and returns its signed value and cardinal letter (0 if there is none)
+func parseCoordinate(part string) (float64, byte, error) {
+	part = strings.TrimSpace(part)
+	var hemisphere byte
+	switch {
+	case len(part) > 0 && isCardinal(part[0]):
+		hemisphere, part = part[0], part[1:]
+	case len(part) > 0 && isCardinal(part[len(part)-1]):
+		hemisphere, part = part[len(part)-1], part[:len(part)-1]
+	}
+
+	fields := strings.Fields(coordinateUnits.Replace(part))
+	if len(fields) == 0 || len(fields) > 3 {
+		return 0, 0, errors.New("has an invalid coordinate")
+	}
+
+	// The sign applies to the whole coordinate (-40 30 => -40.5)
+	negative := strings.HasPrefix(fields[0], "-")
+	fields[0] = strings.TrimPrefix(fields[0], "-")
+
+	value, scale := 0.0, 1.0
+	for i, field := range fields {
+		n, err := strconv.ParseFloat(field, 64)
+		if err != nil || n < 0 || (i > 0 && n >= 60) {
+			return 0, 0, errors.New("has an invalid coordinate")
+		}
+		if i < len(fields)-1 && n != float64(int64(n)) {
+			return 0, 0, errors.New("has a fractional unit before the last one")
+		}
+		value += n / scale
+		scale *= 60
+	}
+
+	if hemisphere == 'S' || hemisphere == 'W' {
+		if negative {
+			return 0, 0, errors.New("has a sign and a cardinal direction")
+		}
+		negative = true
+	}
+	if negative {
+		value = -value
+	}
+	return value, hemisphere, nil
+}
+
+// isCardinal returns true for the cardinal direction letters
+func isCardinal(c byte) bool {
+	return isLatitudeHemisphere(c) || isLongitudeHemisphere(c)
+}
+
+// isLatitudeHemisphere returns true for N and S
+func isLatitudeHemisphere(c byte) bool {
+	return c == 'N' || c == 'S'
+}
+
+// isLongitudeHemisphere returns true for E and W
+func isLongitudeHemisphere(c byte) bool {
+	return c == 'E' || c == 'W'
+}
@@ -0,0 +1,91 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCoordinates tests the Coordinates method
+func TestCoordinates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+			lat   float64
+			lon   float64
+		}{
+			{"decimal", "40.7128,-74.0060", 40.7128, -74.0060},
+			{"decimal with spaces", " 40.7128 , -74.0060 ", 40.7128, -74.0060},
+			{"space separated", "40.7128 -74.0060", 40.7128, -74.0060},
+			{"semicolon", "-33.8688;151.2093", -33.8688, 151.2093},
+			{"degree symbols", " 40.7128° N, 74.0060° W ", 40.7128, -74.0060},
+			{"no comma", "40.7128° N 74.0060° W", 40.7128, -74.0060},
+			{"lowercase cardinal", "33.8688s 151.2093e", -33.8688, 151.2093},
+			{"cardinal prefix", "N 40.7128 W 74.0060", 40.7128, -74.0060},
+			{"longitude first", "74.0060° W, 40.7128° N", 40.7128, -74.0060},
+			{"degrees minutes seconds", `40°42'36"N 74°0'36"W`, 40.71, -74.01},
+			{"degrees decimal minutes", "40° 42.6' N, 74° 0.6' W", 40.71, -74.01},
+			{"prime symbols", "40°42′36″N, 74°0′36″W", 40.71, -74.01},
+			{"negative minutes", "-40 30, 20 15", -40.5, 20.25},
+			{"limits", "-90, 180", -90, 180},
+			{"zero", "0,0", 0, 0},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				lat, lon, err := Coordinates(test.input)
+				require.NoError(t, err)
+				assert.InDelta(t, test.lat, lat, 1e-9)
+				assert.InDelta(t, test.lon, lon, 1e-9)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"single value", "40.7128"},
+			{"three values", "40.7128, -74.0060, 10"},
+			{"latitude out of range", "91, 0"},
+			{"longitude out of range", "0, -180.5"},
+			{"text", "forty, seventy"},
+			{"minutes out of range", "40 60, 74 0"},
+			{"fractional degrees with minutes", "40.5 30, 74"},
+			{"sign and cardinal", "-40.7128 S, 74 W"},
+			{"two latitudes", "40 N, 74 S"},
+			{"two longitudes", "40 E, 74 W"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				lat, lon, err := Coordinates(test.input)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidCoordinates)
+				assert.Zero(t, lat)
+				assert.Zero(t, lon)
+			})
+		}
+	})
+}
+
+// BenchmarkCoordinates benchmarks the Coordinates method
+func BenchmarkCoordinates(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _ = Coordinates(" 40.7128° N, 74.0060° W ")
+	}
+}
+
+// ExampleCoordinates example using Coordinates()
+func ExampleCoordinates() {
+	lat, lon, err := Coordinates(" 40.7128° N, 74.0060° W ")
+	fmt.Println(lat, lon, err)
+	// Output: 40.7128 -74.006 <nil>
+}
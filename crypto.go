@@ -0,0 +1,559 @@
+package sanitize
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Address alphabets
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	bech32Alphabet = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+// bip21AmountRegExp is a bitcoin amount (up to 8 decimal places)
+var bip21AmountRegExp = regexp.MustCompile(`^[0-9]{1,8}(\.[0-9]{0,8})?$|^\.[0-9]{1,8}$`)
+
+// Network describes the address format of a cryptocurrency network for CryptoAddress().
+// Custom networks can be defined by setting the fields, only the Charset is required.
+type Network struct {
+	Name      string                              // Name of the network (used in errors)
+	Charset   string                              // Characters that are kept (everything else is removed)
+	Prefixes  []string                            // Accepted address prefixes (any prefix if empty)
+	MinLength int                                 // Minimum length of the address (0 for no minimum)
+	MaxLength int                                 // Maximum length of the address (0 for no maximum)
+	Validate  func(address string) (string, bool) // Optional checksum validation, returns the canonical address
+}
+
+// Built-in networks
+var (
+	NetworkBCH = Network{
+		Name:      "BCH",
+		Charset:   bech32Alphabet + strings.ToUpper(bech32Alphabet) + ":bitcoincashBITCOINCASH",
+		MinLength: 42,
+		MaxLength: 54,
+		Validate:  validateCashAddr,
+	}
+	NetworkBSV = Network{
+		Name:      "BSV",
+		Charset:   base58Alphabet,
+		Prefixes:  []string{"1"},
+		MinLength: 26,
+		MaxLength: 35,
+		Validate:  base58CheckValidator(0x00),
+	}
+	NetworkBTC = Network{
+		Name:      "BTC",
+		Charset:   base58Alphabet + "0" + "OIl",
+		Prefixes:  []string{"1", "3", "bc1", "BC1"},
+		MinLength: 26,
+		MaxLength: 90,
+		Validate:  anyValidator(base58CheckValidator(0x00, 0x05), segwitValidator("bc")),
+	}
+	NetworkDOGE = Network{
+		Name:      "DOGE",
+		Charset:   base58Alphabet,
+		Prefixes:  []string{"D", "9", "A"},
+		MinLength: 26,
+		MaxLength: 35,
+		Validate:  base58CheckValidator(0x1e, 0x16),
+	}
+	NetworkETH = Network{
+		Name:      "ETH",
+		Charset:   "0123456789abcdefABCDEFxX",
+		Prefixes:  []string{"0x", "0X"},
+		MinLength: 42,
+		MaxLength: 42,
+		Validate:  validateEthereumAddress,
+	}
+	NetworkLTC = Network{
+		Name:      "LTC",
+		Charset:   base58Alphabet + "0" + "OIl",
+		Prefixes:  []string{"L", "M", "3", "ltc1", "LTC1"},
+		MinLength: 26,
+		MaxLength: 90,
+		Validate:  anyValidator(base58CheckValidator(0x30, 0x32, 0x05), segwitValidator("ltc")),
+	}
+)
+
+// CryptoAddress returns the sanitized address for the network or an error if it is not a valid
+// address. Characters outside the network's charset (spaces, punctuation, etc.) are removed and
+// the prefix, length and checksum are validated. The built-in networks (NetworkBTC, NetworkBCH,
+// NetworkBSV, NetworkLTC, NetworkDOGE and NetworkETH) verify the base58check, bech32/bech32m,
+// cashaddr or EIP-55 checksums and return the canonical form of the address.
+//
+//	View examples: crypto_test.go
+func CryptoAddress(original string, network Network) (string, error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %q %s", ErrInvalidCryptoAddress, original, reason)
+	}
+	if len(network.Charset) == 0 {
+		return "", invalid("network has no charset")
+	}
+
+	address := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(network.Charset, r) {
+			return r
+		}
+		return -1
+	}, original)
+
+	switch {
+	case len(address) == 0:
+		return "", invalid("is empty")
+	case len(network.Prefixes) > 0 && !hasAnyPrefix(address, network.Prefixes):
+		return "", invalid("has an invalid prefix for " + network.Name)
+	case (network.MinLength > 0 && len(address) < network.MinLength) ||
+		(network.MaxLength > 0 && len(address) > network.MaxLength):
+		return "", invalid("has an invalid length for " + network.Name)
+	}
+
+	if network.Validate != nil {
+		canonical, ok := network.Validate(address)
+		if !ok {
+			return "", invalid("has an invalid checksum for " + network.Name)
+		}
+		address = canonical
+	}
+	return address, nil
+}
+
+// BIP21 parses and sanitizes a bitcoin: payment uri (BIP 21) and returns the address and
+// the parameters (amount, label, message and any other parameter). The address is sanitized
+// (see BitcoinAddress) and validated with CryptoAddress, the amount is validated (up to 8
+// decimal places), parameter values are decoded with control characters removed and the keys
+// are lowercased. Unknown required parameters (req-*) are rejected as required by BIP 21.
+//
+//	View examples: crypto_test.go
+func BIP21(original string) (address string, params map[string]string, err error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %q %s", ErrInvalidPaymentURI, original, reason)
+	}
+
+	uri := strings.TrimSpace(original)
+	if len(uri) < 8 || !strings.EqualFold(uri[:8], "bitcoin:") {
+		return "", nil, invalid("is not a bitcoin: uri")
+	}
+	uri = strings.TrimPrefix(uri[8:], "//")
+	rawAddress, rawQuery := uri, ""
+	if i := strings.IndexByte(uri, '?'); i >= 0 {
+		rawAddress, rawQuery = uri[:i], uri[i+1:]
+	}
+
+	// Bech32 addresses use characters that are not part of the base58 alphabet
+	if len(rawAddress) >= 3 && strings.EqualFold(rawAddress[:3], "bc1") {
+		address = strings.Map(func(r rune) rune {
+			if (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				return r
+			}
+			return -1
+		}, rawAddress)
+	} else {
+		address = BitcoinAddress(rawAddress)
+	}
+	if address, err = CryptoAddress(address, NetworkBTC); err != nil {
+		return "", nil, fmt.Errorf("%w: %s", ErrInvalidPaymentURI, err.Error())
+	}
+
+	params = make(map[string]string)
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if len(pair) == 0 {
+			continue
+		}
+		key, value := pair, ""
+		if i := strings.IndexByte(pair, '='); i >= 0 {
+			key, value = pair[:i], pair[i+1:]
+		}
+		if key, err = url.QueryUnescape(key); err != nil {
+			return "", nil, invalid("has an invalid parameter")
+		} else if value, err = url.QueryUnescape(value); err != nil {
+			return "", nil, invalid("has an invalid value for " + key)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(strings.Map(func(r rune) rune {
+			if r < ' ' || r == 0x7f {
+				return -1
+			}
+			return r
+		}, value))
+
+		switch {
+		case len(key) == 0:
+			continue
+		case strings.HasPrefix(key, "req-"):
+			return "", nil, invalid("has an unsupported required parameter " + key)
+		case key == "amount" && !bip21AmountRegExp.MatchString(value):
+			return "", nil, invalid("has an invalid amount")
+		}
+		if _, ok := params[key]; !ok {
+			params[key] = value
+		}
+	}
+	return address, params, nil
+}
+
+// Hash returns the hex encoded hash (digest) in lowercase or an error if it is not exactly
+// bits long (256 for SHA-256, 160 for SHA-1, 128 for MD5, etc.). White space, colon and
+// hyphen separators and a 0x prefix are removed, any other non-hex character is an error.
+//
+//	View examples: crypto_test.go
+func Hash(original string, bits int) (string, error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %q %s", ErrInvalidHash, original, reason)
+	}
+	if bits <= 0 || bits%4 != 0 {
+		return "", invalid(fmt.Sprintf("has an unsupported size of %d bits", bits))
+	}
+
+	hash := strings.TrimSpace(original)
+	if len(hash) >= 2 && hash[0] == '0' && (hash[1] == 'x' || hash[1] == 'X') {
+		hash = hash[2:]
+	}
+	hash = strings.Map(func(r rune) rune {
+		switch {
+		case r == ':' || r == '-' || unicode.IsSpace(r):
+			return -1
+		case r >= 'A' && r <= 'F':
+			return r + 'a' - 'A'
+		}
+		return r
+	}, hash)
+
+	for i := 0; i < len(hash); i++ {
+		if !isHex(hash[i]) {
+			return "", invalid("contains non-hex characters")
+		}
+	}
+	if len(hash) != bits/4 {
+		return "", invalid(fmt.Sprintf("is not %d bits (%d hex characters)", bits, bits/4))
+	}
+	return hash, nil
+}
+
+// TxID returns the transaction id (a 64 character SHA-256 hex hash) in lowercase or an error
+// if it is not valid, see Hash for the characters that are removed.
+//
+//	View examples: crypto_test.go
+func TxID(original string) (string, error) {
+	return Hash(original, 256)
+}
+
+// anyValidator returns a validator that accepts the address if any of the validators accepts it
+func anyValidator(validators ...func(string) (string, bool)) func(string) (string, bool) {
+	return func(address string) (string, bool) {
+		for _, validate := range validators {
+			if canonical, ok := validate(address); ok {
+				return canonical, true
+			}
+		}
+		return "", false
+	}
+}
+
+// base58CheckValidator returns a validator for base58check addresses (a version byte, a 20 byte
+// hash and a double SHA-256 checksum) with one of the version bytes
+func base58CheckValidator(versions ...byte) func(string) (string, bool) {
+	return func(address string) (string, bool) {
+		decoded, ok := decodeBase58(address)
+		if !ok || len(decoded) != 25 {
+			return "", false
+		}
+		first := sha256.Sum256(decoded[:21])
+		second := sha256.Sum256(first[:])
+		if string(second[:4]) != string(decoded[21:]) {
+			return "", false
+		}
+		for _, version := range versions {
+			if decoded[0] == version {
+				return address, true
+			}
+		}
+		return "", false
+	}
+}
+
+// decodeBase58 decodes a base58 string (leading 1s are leading zero bytes)
+func decodeBase58(value string) ([]byte, bool) {
+	decoded := make([]byte, 0, len(value))
+	for i := 0; i < len(value); i++ {
+		carry := strings.IndexByte(base58Alphabet, value[i])
+		if carry < 0 {
+			return nil, false
+		}
+		for j := range decoded {
+			carry += int(decoded[j]) * 58
+			decoded[j] = byte(carry)
+			carry >>= 8
+		}
+		for ; carry > 0; carry >>= 8 {
+			decoded = append(decoded, byte(carry))
+		}
+	}
+	for i := 0; i < len(value) && value[i] == '1'; i++ {
+		decoded = append(decoded, 0)
+	}
+
+	// Little endian => big endian
+	for i, j := 0, len(decoded)-1; i < j; i, j = i+1, j-1 {
+		decoded[i], decoded[j] = decoded[j], decoded[i]
+	}
+	return decoded, true
+}
+
+// segwitValidator returns a validator for segwit addresses (BIP 173 and BIP 350) with the
+// human readable part, the canonical form is lowercase
+func segwitValidator(hrp string) func(string) (string, bool) {
+	return func(address string) (string, bool) {
+		if strings.ToLower(address) != address && strings.ToUpper(address) != address {
+			return "", false // Mixed case
+		}
+		address = strings.ToLower(address)
+		if !strings.HasPrefix(address, hrp+"1") || len(address) > 90 {
+			return "", false
+		}
+
+		data, ok := decodeBech32Data(address[len(hrp)+1:])
+		if !ok || len(data) < 7 {
+			return "", false
+		}
+		program, ok := convertBits(data[1:len(data)-6], 5, 8)
+		version := data[0]
+		switch {
+		case !ok || version > 16 || len(program) < 2 || len(program) > 40:
+			return "", false
+		case version == 0 && len(program) != 20 && len(program) != 32:
+			return "", false
+		}
+
+		// Version 0 uses bech32, later versions use bech32m
+		checksum := bech32Polymod(append(bech32ExpandHRP(hrp), data...))
+		if (version == 0 && checksum != 1) || (version > 0 && checksum != 0x2bc830a3) {
+			return "", false
+		}
+		return address, true
+	}
+}
+
+// decodeBech32Data converts the bech32 characters to their 5 bit values
+func decodeBech32Data(value string) ([]byte, bool) {
+	data := make([]byte, len(value))
+	for i := 0; i < len(value); i++ {
+		index := strings.IndexByte(bech32Alphabet, value[i])
+		if index < 0 {
+			return nil, false
+		}
+		data[i] = byte(index)
+	}
+	return data, true
+}
+
+// bech32ExpandHRP expands the human readable part for the bech32 checksum
+func bech32ExpandHRP(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+// bech32Polymod is the bech32 checksum (BIP 173)
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	checksum := uint32(1)
+	for _, value := range values {
+		top := checksum >> 25
+		checksum = (checksum&0x1ffffff)<<5 ^ uint32(value)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				checksum ^= generator[i]
+			}
+		}
+	}
+	return checksum
+}
+
+// convertBits regroups the bits of the values (5 bit groups to bytes), the padding must be zero
+func convertBits(values []byte, from, to uint) ([]byte, bool) {
+	var acc, count uint
+	converted := make([]byte, 0, len(values)*int(from)/int(to)+1)
+	for _, value := range values {
+		acc = acc<<from | uint(value)
+		count += from
+		for count >= to {
+			count -= to
+			converted = append(converted, byte(acc>>count&(1<<to-1)))
+		}
+	}
+	if count >= from || acc&(1<<count-1) != 0 {
+		return nil, false
+	}
+	return converted, true
+}
+
+// validateCashAddr validates a Bitcoin Cash cashaddr address with or without the bitcoincash:
+// prefix, the canonical form is lowercase with the prefix
+func validateCashAddr(address string) (string, bool) {
+	if strings.ToLower(address) != address && strings.ToUpper(address) != address {
+		return "", false // Mixed case
+	}
+	address = strings.ToLower(address)
+	payload := strings.TrimPrefix(address, "bitcoincash:")
+	if len(payload) != 42 || (payload[0] != 'q' && payload[0] != 'p') {
+		return "", false
+	}
+
+	data, ok := decodeBech32Data(payload)
+	if !ok {
+		return "", false
+	}
+	values := make([]byte, 0, len("bitcoincash")+1+len(data))
+	for _, c := range []byte("bitcoincash") {
+		values = append(values, c&31)
+	}
+	values = append(append(values, 0), data...)
+	if cashAddrPolymod(values) != 0 {
+		return "", false
+	}
+	return "bitcoincash:" + payload, true
+}
+
+// cashAddrPolymod is the cashaddr checksum
+func cashAddrPolymod(values []byte) uint64 {
+	generator := [5]uint64{0x98f2bc8e61, 0x79b76d99e2, 0xf33e5fb3c4, 0xae2eabe2a8, 0x1e4f43e470}
+	checksum := uint64(1)
+	for _, value := range values {
+		top := byte(checksum >> 35)
+		checksum = (checksum&0x07ffffffff)<<5 ^ uint64(value)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				checksum ^= generator[i]
+			}
+		}
+	}
+	return checksum ^ 1
+}
+
+// validateEthereumAddress validates an Ethereum address, mixed case addresses must have a
+// valid EIP-55 checksum and the canonical form is the checksummed address
+func validateEthereumAddress(address string) (string, bool) {
+	digits := address[2:]
+	if _, err := hex.DecodeString(digits); err != nil {
+		return "", false
+	}
+	checksummed := ethereumChecksum(strings.ToLower(digits))
+	if strings.ToLower(digits) != digits && strings.ToUpper(digits) != digits && checksummed != digits {
+		return "", false
+	}
+	return "0x" + checksummed, true
+}
+
+// ethereumChecksum returns the EIP-55 mixed case form of the lowercase hex address
+func ethereumChecksum(digits string) string {
+	hash := keccak256([]byte(digits))
+	checksummed := []byte(digits)
+	for i, c := range checksummed {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			checksummed[i] = c - 'a' + 'A'
+		}
+	}
+	return string(checksummed)
+}
+
+// keccakRoundConstants are the round constants of the Keccak-f[1600] permutation
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808a, 0x8000000080008000,
+	0x000000000000808b, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008a, 0x0000000000000088, 0x0000000080008009, 0x000000008000000a,
+	0x000000008000808b, 0x800000000000008b, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800a, 0x800000008000000a,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// keccakRotations and keccakLanes are the rho offsets and pi lane order of Keccak-f[1600]
+var (
+	keccakRotations = [24]int{1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44}
+	keccakLanes     = [24]int{10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1}
+)
+
+// keccak256 returns the legacy Keccak-256 hash used by Ethereum (not the final SHA3-256)
+func keccak256(data []byte) [32]byte {
+	const rate = 136
+	var state [25]uint64
+
+	// Pad (Keccak padding 0x01 ... 0x80) and absorb
+	padded := make([]byte, len(data), len(data)+rate)
+	copy(padded, data)
+	padded = append(padded, 0x01)
+	for len(padded)%rate != 0 {
+		padded = append(padded, 0)
+	}
+	padded[len(padded)-1] |= 0x80
+	for block := 0; block < len(padded); block += rate {
+		for i := 0; i < rate/8; i++ {
+			var lane uint64
+			for j := 7; j >= 0; j-- {
+				lane = lane<<8 | uint64(padded[block+i*8+j])
+			}
+			state[i] ^= lane
+		}
+		keccakF1600(&state)
+	}
+
+	// Squeeze
+	var hash [32]byte
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			hash[i*8+j] = byte(state[i] >> (8 * j))
+		}
+	}
+	return hash
+}
+
+// keccakF1600 is the Keccak-f[1600] permutation
+func keccakF1600(a *[25]uint64) {
+	var c [5]uint64
+	for round := 0; round < 24; round++ {
+		// Theta
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d := c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+			for y := 0; y < 25; y += 5 {
+				a[y+x] ^= d
+			}
+		}
+
+		// Rho and pi
+		current := a[1]
+		for i := 0; i < 24; i++ {
+			lane := keccakLanes[i]
+			current, a[lane] = a[lane], bits.RotateLeft64(current, keccakRotations[i])
+		}
+
+		// Chi
+		for y := 0; y < 25; y += 5 {
+			copy(c[:], a[y:y+5])
+			for x := 0; x < 5; x++ {
+				a[y+x] = c[x] ^ (^c[(x+1)%5] & c[(x+2)%5])
+			}
+		}
+
+		// Iota
+		a[0] ^= keccakRoundConstants[round]
+	}
+}
@@ -0,0 +1,314 @@
+package sanitize
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCryptoAddress tests the CryptoAddress method
+func TestCryptoAddress(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			network  Network
+			expected string
+		}{
+			{"btc p2pkh", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", NetworkBTC, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+			{"btc p2sh", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", NetworkBTC, "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy"},
+			{"btc noise", " 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa!\n", NetworkBTC, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+			{"btc bech32", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", NetworkBTC,
+				"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+			{"btc bech32 uppercase", "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", NetworkBTC,
+				"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+			{"btc taproot", "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", NetworkBTC,
+				"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0"},
+			{"bch", "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", NetworkBCH,
+				"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a"},
+			{"bch without prefix", "qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a", NetworkBCH,
+				"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a"},
+			{"bch uppercase", "BITCOINCASH:QPM2QSZNHKS23Z7629MMS6S4CWEF74VCWVY22GDX6A", NetworkBCH,
+				"bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a"},
+			{"bsv", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", NetworkBSV, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+			{"ltc", "LM2WMpR1Rp6j3Sa59cMXMs1SPzj9eXpGc1", NetworkLTC, "LM2WMpR1Rp6j3Sa59cMXMs1SPzj9eXpGc1"},
+			{"ltc bech32", "ltc1qg42tkwuuxefutzxezdkdel39gfstuap288mfea", NetworkLTC,
+				"ltc1qg42tkwuuxefutzxezdkdel39gfstuap288mfea"},
+			{"doge", "DH5yaieqoZN36fDVciNyRueRGvGLR3mr7L", NetworkDOGE, "DH5yaieqoZN36fDVciNyRueRGvGLR3mr7L"},
+			{"eth checksummed", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", NetworkETH,
+				"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+			{"eth lowercase", "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", NetworkETH,
+				"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+			{"eth uppercase", "0X5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED", NetworkETH,
+				"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+			{"custom network", " r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59!", Network{
+				Name: "XRP", Charset: "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz",
+				Prefixes: []string{"r"}, MinLength: 25, MaxLength: 35,
+			}, "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := CryptoAddress(test.input, test.network)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name    string
+			input   string
+			network Network
+		}{
+			{"empty", "", NetworkBTC},
+			{"btc bad checksum", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb", NetworkBTC},
+			{"btc bech32 bad checksum", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5", NetworkBTC},
+			{"btc bech32 mixed case", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8F3T4", NetworkBTC},
+			{"btc too short", "1A1zP1eP", NetworkBTC},
+			{"btc litecoin address", "LM2WMpR1Rp6j3Sa59cMXMs1SPzj9eXpGc1", NetworkBTC},
+			{"btc litecoin bech32", "ltc1qg42tkwuuxefutzxezdkdel39gfstuap288mfea", NetworkBTC},
+			{"bsv p2sh", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", NetworkBSV},
+			{"bch bad checksum", "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6b", NetworkBCH},
+			{"bch legacy", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", NetworkBCH},
+			{"ltc bitcoin address", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", NetworkLTC},
+			{"doge bitcoin address", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", NetworkDOGE},
+			{"eth bad checksum", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", NetworkETH},
+			{"eth too short", "0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea", NetworkETH},
+			{"eth missing prefix", "5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", NetworkETH},
+			{"no charset", "abc", Network{Name: "empty"}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := CryptoAddress(test.input, test.network)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidCryptoAddress)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+
+	t.Run("keccak256", func(t *testing.T) {
+		hash := keccak256(nil)
+		assert.Equal(t, "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470", hex.EncodeToString(hash[:]))
+		hash = keccak256([]byte(strings.Repeat("a", 200))) // Multiple blocks
+		assert.Len(t, hex.EncodeToString(hash[:]), 64)
+	})
+}
+
+// BenchmarkCryptoAddress benchmarks the CryptoAddress method
+func BenchmarkCryptoAddress(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = CryptoAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", NetworkBTC)
+	}
+}
+
+// BenchmarkCryptoAddress_ETH benchmarks the CryptoAddress method with an Ethereum address
+func BenchmarkCryptoAddress_ETH(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = CryptoAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", NetworkETH)
+	}
+}
+
+// ExampleCryptoAddress example using CryptoAddress()
+func ExampleCryptoAddress() {
+	fmt.Println(CryptoAddress(" 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa ", NetworkBTC))
+	fmt.Println(CryptoAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", NetworkETH))
+	// Output:
+	// 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa <nil>
+	// 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed <nil>
+}
+
+// TestBIP21 tests the BIP21 method
+func TestBIP21(t *testing.T) {
+	t.Parallel()
+
+	const address = "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name            string
+			input           string
+			expectedAddress string
+			expectedParams  map[string]string
+		}{
+			{"address only", "bitcoin:" + address, address, map[string]string{}},
+			{"uppercase scheme", " BITCOIN:" + address + " ", address, map[string]string{}},
+			{"noise in address", "bitcoin:" + address + "!", address, map[string]string{}},
+			{"amount", "bitcoin:" + address + "?amount=0.01", address, map[string]string{"amount": "0.01"}},
+			{"all parameters", "bitcoin:" + address + "?amount=20.3&label=Luke-Jr&message=Donation%20for%20project%20xyz",
+				address, map[string]string{"amount": "20.3", "label": "Luke-Jr", "message": "Donation for project xyz"}},
+			{"control characters", "bitcoin:" + address + "?label=Bad%0A%00Label", address,
+				map[string]string{"label": "BadLabel"}},
+			{"uppercase keys", "bitcoin:" + address + "?Amount=1&LABEL=x", address,
+				map[string]string{"amount": "1", "label": "x"}},
+			{"duplicate keys", "bitcoin:" + address + "?label=first&label=second", address,
+				map[string]string{"label": "first"}},
+			{"other parameters", "bitcoin:" + address + "?lightning=lnbc1&&", address,
+				map[string]string{"lightning": "lnbc1"}},
+			{"bech32", "bitcoin:BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4?amount=.5",
+				"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", map[string]string{"amount": ".5"}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, params, err := BIP21(test.input)
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedAddress, output)
+				assert.Equal(t, test.expectedParams, params)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"address without scheme", address},
+			{"other scheme", "litecoin:LM2WMpR1Rp6j3Sa59cMXMs1SPzj9eXpGc1"},
+			{"missing address", "bitcoin:?amount=1"},
+			{"bad checksum", "bitcoin:1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb"},
+			{"negative amount", "bitcoin:" + address + "?amount=-1"},
+			{"amount with comma", "bitcoin:" + address + "?amount=1,000"},
+			{"amount too precise", "bitcoin:" + address + "?amount=0.000000001"},
+			{"amount text", "bitcoin:" + address + "?amount=one"},
+			{"required parameter", "bitcoin:" + address + "?req-somethingyoudontunderstand=50"},
+			{"invalid escape", "bitcoin:" + address + "?label=%zz"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, params, err := BIP21(test.input)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidPaymentURI)
+				assert.Equal(t, "", output)
+				assert.Nil(t, params)
+			})
+		}
+	})
+}
+
+// BenchmarkBIP21 benchmarks the BIP21 method
+func BenchmarkBIP21(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _, _ = BIP21("bitcoin:1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa?amount=0.01&label=Coffee")
+	}
+}
+
+// ExampleBIP21 example using BIP21()
+func ExampleBIP21() {
+	address, params, err := BIP21("bitcoin:1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa?amount=0.01&label=Coffee%20Shop")
+	fmt.Println(address, params["amount"], params["label"], err)
+	// Output: 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa 0.01 Coffee Shop <nil>
+}
+
+// TestHash tests the Hash method
+func TestHash(t *testing.T) {
+	t.Parallel()
+
+	const sha256Empty = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			bits     int
+			expected string
+		}{
+			{"sha-256", sha256Empty, 256, sha256Empty},
+			{"sha-256 uppercase", strings.ToUpper(sha256Empty), 256, sha256Empty},
+			{"sha-256 0x prefix", "0x" + sha256Empty, 256, sha256Empty},
+			{"sha-256 spaces", "  " + sha256Empty[:32] + "\n" + sha256Empty[32:] + " ", 256, sha256Empty},
+			{"sha-1", "da39a3ee5e6b4b0d3255bfef95601890afd80709", 160, "da39a3ee5e6b4b0d3255bfef95601890afd80709"},
+			{"md5 with colons", "D4:1D:8C:D9:8F:00:B2:04:E9:80:09:98:EC:F8:42:7E", 128, "d41d8cd98f00b204e9800998ecf8427e"},
+			{"uuid style", "d41d8cd9-8f00-b204-e980-0998ecf8427e", 128, "d41d8cd98f00b204e9800998ecf8427e"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := Hash(test.input, test.bits)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+			bits  int
+		}{
+			{"empty", "", 256},
+			{"too short", sha256Empty[:63], 256},
+			{"too long", sha256Empty + "0", 256},
+			{"sha-1 for sha-256", "da39a3ee5e6b4b0d3255bfef95601890afd80709", 256},
+			{"non-hex", "g" + sha256Empty[1:], 256},
+			{"punctuation", sha256Empty[:63] + "!", 256},
+			{"zero bits", sha256Empty, 0},
+			{"negative bits", sha256Empty, -256},
+			{"bits not a multiple of 4", "abc", 10},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := Hash(test.input, test.bits)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidHash)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkHash benchmarks the Hash method
+func BenchmarkHash(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = Hash("DA39A3EE5E6B4B0D3255BFEF95601890AFD80709", 160)
+	}
+}
+
+// ExampleHash example using Hash()
+func ExampleHash() {
+	fmt.Println(Hash("DA39A3EE 5E6B4B0D 3255BFEF 95601890 AFD80709", 160))
+	// Output: da39a3ee5e6b4b0d3255bfef95601890afd80709 <nil>
+}
+
+// TestTxID tests the TxID method
+func TestTxID(t *testing.T) {
+	t.Parallel()
+
+	const txID = "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
+
+	output, err := TxID(" " + strings.ToUpper(txID) + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, txID, output)
+
+	output, err = TxID(txID[:40])
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidHash)
+	assert.Equal(t, "", output)
+}
+
+// BenchmarkTxID benchmarks the TxID method
+func BenchmarkTxID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = TxID("4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b")
+	}
+}
+
+// ExampleTxID example using TxID()
+func ExampleTxID() {
+	fmt.Println(TxID("4A5E1E4BAAB89F3A32518A88C31BC87F618F76673E2CC77AB2127B7AFDEDA33B"))
+	// Output: 4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b <nil>
+}
@@ -0,0 +1,106 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCSS tests the CSS sanitize method
+func TestCSS(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"safe", "p { color: red; margin: 0 }", "p { color: red; margin: 0 }"},
+		{"expression", "p { color: red; width: expression(alert(1)); }", "p { color: red; }"},
+		{"expression last", "p { width: expression(alert(1)) }", "p {}"},
+		{"javascript url", "a { background: url(javascript:alert(1)); color: blue }", "a { color: blue }"},
+		{"javascript quoted url", `a { background: url("javascript:alert(1)") }`, "a {}"},
+		{"safe url", "a { background: url(/img/bg.png) }", "a { background: url(/img/bg.png) }"},
+		{
+			"data image url", "a { background: url(data:image/png;base64,AAA) }",
+			"a { background: url(data:image/png;base64,AAA) }",
+		},
+		{"data html url", "a { background: url(data:text/html;base64,AAA) }", "a {}"},
+		{"import", "@import url(evil.css); p { color: red }", " p { color: red }"},
+		{"import string", `@import "evil.css";p{}`, "p{}"},
+		{"behavior", "p { behavior: url(script.htc); color: red }", "p { color: red }"},
+		{"moz binding", "p { -moz-binding: url(x.xml#xss) }", "p {}"},
+		{"comment obfuscation", "p { width: expr/**/ession(alert(1)) }", "p {}"},
+		{"escape obfuscation", `p { width: \65 xpression(alert(1)) }`, "p {}"},
+		{"escaped character", `p { background: url(java\script:alert(1)) }`, "p {}"},
+		{"uppercase", "P { WIDTH: EXPRESSION(alert(1)) }", "P {}"},
+		{
+			"media query", "@media screen { p { color: red; width: expression(1) } }",
+			"@media screen { p { color: red;} }",
+		},
+		{"semicolon in string", `p { content: "a;b"; width: expression(1) }`, `p { content: "a;b";}`},
+		{"comments removed", "/* comment */p { color: red }", "p { color: red }"},
+		{"unterminated comment", "p { color: red } /* x", "p { color: red } "},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, CSS(test.input))
+		})
+	}
+}
+
+// BenchmarkCSS benchmarks the CSS method
+func BenchmarkCSS(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = CSS("p { color: red; width: expression(alert(1)); background: url(/bg.png) }")
+	}
+}
+
+// ExampleCSS example using CSS()
+func ExampleCSS() {
+	fmt.Println(CSS("@import url(evil.css);p { color: red; width: expression(alert(1)) }"))
+	// Output: p { color: red;}
+}
+
+// TestStyleAttr tests the StyleAttr sanitize method
+func TestStyleAttr(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"safe", "color: red; margin: 0", "color: red; margin: 0"},
+		{"expression", "color: red; width: expression(alert(1))", "color: red;"},
+		{"expression first", "width: expression(alert(1)); color: red", "color: red"},
+		{"javascript url", "background-image: url('javascript:alert(1)')", ""},
+		{"behavior", "behavior: url(x.htc)", ""},
+		{"escaped property", `beh\61vior: url(x.htc); color: red`, "color: red"},
+		{"import", "@import 'x.css'; color: red", "color: red"},
+		{"trailing backslash", `color: red\`, `color: red\`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, StyleAttr(test.input))
+		})
+	}
+}
+
+// BenchmarkStyleAttr benchmarks the StyleAttr method
+func BenchmarkStyleAttr(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = StyleAttr("color: red; width: expression(alert(1)); background: url(/bg.png)")
+	}
+}
+
+// ExampleStyleAttr example using StyleAttr()
+func ExampleStyleAttr() {
+	fmt.Println(StyleAttr("color: red; background: url(javascript:alert(1))"))
+	// Output: color: red;
+}
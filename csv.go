@@ -0,0 +1,202 @@
+package sanitize
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// CSV streams the CSV from r to w and sanitizes each field with the rule of its column. The
+// first record is the header and is written as-is, rules are keyed by the column name (from
+// the header) or by the column index ("0" is the first column) and an empty key is the
+// default for the columns without a rule (columns without any rule are kept as-is).
+//
+// Records are processed one at a time so large files are not loaded into memory. The quoting
+// of the input is preserved: quoted fields stay quoted and the other fields are only quoted
+// when they need to be (commas, quotes, new lines or leading white space). Records end with
+// \n and empty lines are skipped. An error (a *csv.ParseError) is returned if the CSV is
+// malformed, or ErrUnknownColumn if a rule does not match a column of the header.
+//
+//	View examples: csv_test.go
+func CSV(r io.Reader, w io.Writer, rules map[string]SanitizeFunc) error {
+	reader := &csvReader{r: bufio.NewReader(r)}
+	writer := bufio.NewWriter(w)
+
+	header, quoted, err := reader.read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	columns, err := csvColumnRules(header, rules)
+	if err != nil {
+		return err
+	}
+	if err = writeCSVRecord(writer, header, quoted); err != nil {
+		return err
+	}
+
+	for {
+		record, quoted, err := reader.read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+		for i := range record {
+			fn := rules[""]
+			if i < len(columns) && columns[i] != nil {
+				fn = columns[i]
+			}
+			if fn != nil {
+				record[i] = fn(record[i])
+			}
+		}
+		if err = writeCSVRecord(writer, record, quoted); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// csvColumnRules returns the rule of each column of the header (nil if it has none)
+func csvColumnRules(header []string, rules map[string]SanitizeFunc) ([]SanitizeFunc, error) {
+	columns := make([]SanitizeFunc, len(header))
+	for key, fn := range rules {
+		if len(key) == 0 {
+			continue
+		}
+		found := false
+		for i, name := range header {
+			if name == key {
+				columns[i], found = fn, true
+			}
+		}
+		if index, err := strconv.Atoi(key); !found && err == nil && index >= 0 && index < len(header) {
+			columns[index], found = fn, true
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownColumn, key)
+		}
+	}
+	return columns, nil
+}
+
+// csvReader reads RFC 4180 records (like encoding/csv) and also returns which fields were
+// quoted, the field and quoted slices are reused by the next record
+type csvReader struct {
+	fields []string      // Fields of the last record
+	line   int           // Number of the last line read
+	quoted []bool        // Quoted fields of the last record
+	r      *bufio.Reader // Input
+}
+
+// read returns the next record and its quoted fields, io.EOF is returned at the end
+func (c *csvReader) read() ([]string, []bool, error) {
+	line, err := c.readLine()
+	for err == nil && len(line) == 0 {
+		line, err = c.readLine()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.fields, c.quoted = c.fields[:0], c.quoted[:0]
+	start, length := c.line, len(line)
+	for {
+		if !strings.HasPrefix(line, `"`) {
+			field, rest, more := strings.Cut(line, ",")
+			if i := strings.IndexByte(field, '"'); i >= 0 {
+				return nil, nil, c.parseError(start, length-len(line)+i+1, csv.ErrBareQuote)
+			}
+			c.fields, c.quoted = append(c.fields, field), append(c.quoted, false)
+			if !more {
+				return c.fields, c.quoted, nil
+			}
+			line = rest
+			continue
+		}
+
+		// Quoted field, "" is a quote and the field can continue on the next lines
+		var b strings.Builder
+		line = line[1:]
+		for {
+			i := strings.IndexByte(line, '"')
+			if i < 0 {
+				b.WriteString(line + "\n")
+				if line, err = c.readLine(); errors.Is(err, io.EOF) {
+					return nil, nil, c.parseError(start, length+1, csv.ErrQuote)
+				} else if err != nil {
+					return nil, nil, err
+				}
+				length = len(line)
+				continue
+			}
+			b.WriteString(line[:i])
+			if line = line[i+1:]; !strings.HasPrefix(line, `"`) {
+				break
+			}
+			b.WriteByte('"')
+			line = line[1:]
+		}
+		c.fields, c.quoted = append(c.fields, b.String()), append(c.quoted, true)
+
+		switch {
+		case len(line) == 0:
+			return c.fields, c.quoted, nil
+		case line[0] != ',':
+			return nil, nil, c.parseError(start, length-len(line)+1, csv.ErrQuote)
+		}
+		line = line[1:]
+	}
+}
+
+// readLine returns the next line without its line ending (\n or \r\n)
+func (c *csvReader) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if len(line) == 0 && err != nil {
+		return "", err
+	} else if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	c.line++
+	line = strings.TrimSuffix(line, "\n")
+	return strings.TrimSuffix(line, "\r"), nil
+}
+
+// parseError returns the error for the record that starts on the line
+func (c *csvReader) parseError(start, column int, err error) error {
+	return &csv.ParseError{StartLine: start, Line: c.line, Column: column, Err: err}
+}
+
+// writeCSVRecord writes the record, the quoted fields and the fields that need quotes are quoted
+func writeCSVRecord(w *bufio.Writer, record []string, quoted []bool) error {
+	for i, field := range record {
+		if i > 0 {
+			_ = w.WriteByte(',')
+		}
+		if (i < len(quoted) && quoted[i]) || csvNeedsQuotes(field) {
+			_, _ = w.WriteString(`"` + strings.ReplaceAll(field, `"`, `""`) + `"`)
+		} else {
+			_, _ = w.WriteString(field)
+		}
+	}
+	return w.WriteByte('\n')
+}
+
+// csvNeedsQuotes returns true if the field must be quoted (like encoding/csv)
+func csvNeedsQuotes(field string) bool {
+	if len(field) == 0 {
+		return false
+	} else if field == `\.` || strings.ContainsAny(field, ",\"\r\n") {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(field)
+	return unicode.IsSpace(r)
+}
@@ -0,0 +1,145 @@
+package sanitize
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCSV tests the CSV method
+func TestCSV(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			rules    map[string]SanitizeFunc
+			expected string
+		}{
+			{"empty", "", map[string]SanitizeFunc{"email": Funcs.Email}, ""},
+			{"header only", "name,email\n", map[string]SanitizeFunc{"email": Funcs.Email}, "name,email\n"},
+			{
+				"by name", "name,email\n Bob ,Bob@Example.COM\n",
+				map[string]SanitizeFunc{"email": Funcs.Email}, "name,email\n\" Bob \",bob@example.com\n",
+			},
+			{
+				"by index", "name,phone\nBob,(555) 123-4567\n",
+				map[string]SanitizeFunc{"1": Funcs.Numeric}, "name,phone\nBob,5551234567\n",
+			},
+			{
+				"name before index", "1,0\na1,b2\n",
+				map[string]SanitizeFunc{"0": Funcs.Alpha}, "1,0\na1,b\n",
+			},
+			{
+				"default rule", "name,email,note\n Bob ,Bob@Example.COM, hi \n",
+				map[string]SanitizeFunc{"": strings.TrimSpace, "email": Funcs.Email},
+				"name,email,note\nBob,bob@example.com,hi\n",
+			},
+			{
+				"quoting kept", "name,note\n\"Smith, Bob\",\"say \"\"hi\"\"\nbye\"\n",
+				map[string]SanitizeFunc{"name": strings.ToUpper},
+				"name,note\n\"SMITH, BOB\",\"say \"\"hi\"\"\nbye\"\n",
+			},
+			{
+				"quoting preserved", "\"name\",note,\"id\"\r\n\"Bob\",\"a\r\nb\",7\r\n\"\",x,\"\"\"\"\n",
+				map[string]SanitizeFunc{"name": strings.ToUpper},
+				"\"name\",note,\"id\"\n\"BOB\",\"a\nb\",7\n\"\",x,\"\"\"\"\n",
+			},
+			{"empty lines", "a\n\n1\n\n", nil, "a\n1\n"},
+			{"trailing comma", "a,b\n1,\n", nil, "a,b\n1,\n"},
+			{
+				"quotes added", "name\nBob\n",
+				map[string]SanitizeFunc{"name": func(s string) string { return s + ", Jr." }},
+				"name\n\"Bob, Jr.\"\n",
+			},
+			{
+				"ragged records", "a,b\n1\n1,2,3\n",
+				map[string]SanitizeFunc{"b": strings.ToUpper, "": func(s string) string { return s + "!" }},
+				"a,b\n1!\n1!,2,3!\n",
+			},
+			{"no rules", "a,b\n1,2\n", nil, "a,b\n1,2\n"},
+			{"crlf", "a\r\nx\r\n", map[string]SanitizeFunc{"a": strings.ToUpper}, "a\nX\n"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				var output bytes.Buffer
+				err := CSV(strings.NewReader(test.input), &output, test.rules)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output.String())
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var output bytes.Buffer
+		err := CSV(strings.NewReader("name\nBob\n"), &output, map[string]SanitizeFunc{"email": Funcs.Email})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrUnknownColumn)
+
+		err = CSV(strings.NewReader("name\nBob\n"), &output, map[string]SanitizeFunc{"1": Funcs.Email})
+		assert.ErrorIs(t, err, ErrUnknownColumn)
+
+		err = CSV(strings.NewReader("name\n\"Bob\n"), &output, nil)
+		require.Error(t, err)
+
+		err = CSV(strings.NewReader("\"name\n"), &output, nil)
+		require.Error(t, err)
+
+		var parseErr *csv.ParseError
+		err = CSV(strings.NewReader("name\n\"Bob\"x\n"), &output, nil)
+		require.ErrorAs(t, err, &parseErr)
+		assert.ErrorIs(t, err, csv.ErrQuote)
+		assert.Equal(t, 2, parseErr.Line)
+
+		err = CSV(strings.NewReader("name\nB\"ob\n"), &output, nil)
+		assert.ErrorIs(t, err, csv.ErrBareQuote)
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		input := "name\n" + strings.Repeat("Bob\n", 2000)
+		err := CSV(strings.NewReader(input), failingWriter{}, nil)
+		require.Error(t, err)
+	})
+}
+
+// failingWriter is an io.Writer that always fails
+type failingWriter struct{}
+
+// Write returns an error
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+// BenchmarkCSV benchmarks the CSV method
+func BenchmarkCSV(b *testing.B) {
+	input := "name,email\n" + strings.Repeat(" Bob ,Bob@Example.COM\n", 100)
+	rules := map[string]SanitizeFunc{"": strings.TrimSpace, "email": Funcs.Email}
+	var output bytes.Buffer
+	for i := 0; i < b.N; i++ {
+		output.Reset()
+		_ = CSV(strings.NewReader(input), &output, rules)
+	}
+}
+
+// ExampleCSV example using CSV()
+func ExampleCSV() {
+	input := "name,email\n Bob ,Bob@Example.COM\n"
+	err := CSV(strings.NewReader(input), os.Stdout, map[string]SanitizeFunc{
+		"name":  strings.TrimSpace,
+		"email": Funcs.Email,
+	})
+	fmt.Println(err)
+	// Output:
+	// name,email
+	// Bob,bob@example.com
+	// <nil>
+}
@@ -0,0 +1,65 @@
+package sanitize
+
+import (
+	"strconv"
+)
+
+// Deep recursively walks a decoded payload (the result of json.Unmarshal into an interface{})
+// and returns a copy with every string value sanitized by fn. Maps (map[string]any and
+// map[string]string) and slices ([]any and []string) are copied, map keys and any other
+// types of values are returned as-is.
+//
+//	View examples: deep_test.go
+func Deep(v any, fn SanitizeFunc) any {
+	if fn == nil {
+		return v
+	}
+	return DeepWithRules(v, map[string]SanitizeFunc{"": fn})
+}
+
+// DeepWithRules recursively walks a decoded payload like Deep() and applies the sanitizer
+// of the most specific matching rule to each string value. Rules use the same path syntax
+// as JSON() (user.email, items.*.name) and an empty path matches every value, which makes
+// it the default for values without a more specific rule.
+//
+//	View examples: deep_test.go
+func DeepWithRules(v any, rules map[string]SanitizeFunc) any {
+	return sanitizeDeep(v, nil, compileJSONRules(rules))
+}
+
+// sanitizeDeep returns a sanitized copy of the value at the given path
+func sanitizeDeep(v any, path []string, rules []jsonRule) any {
+	switch value := v.(type) {
+	case string:
+		if fn := matchJSONRule(rules, path); fn != nil {
+			return fn(value)
+		}
+		return value
+	case map[string]any:
+		sanitized := make(map[string]any, len(value))
+		for key, item := range value {
+			sanitized[key] = sanitizeDeep(item, append(path[:len(path):len(path)], key), rules)
+		}
+		return sanitized
+	case map[string]string:
+		sanitized := make(map[string]string, len(value))
+		for key, item := range value {
+			sanitized[key] = sanitizeDeep(item, append(path[:len(path):len(path)], key), rules).(string)
+		}
+		return sanitized
+	case []any:
+		sanitized := make([]any, len(value))
+		for i, item := range value {
+			sanitized[i] = sanitizeDeep(item, append(path[:len(path):len(path)], strconv.Itoa(i)), rules)
+		}
+		return sanitized
+	case []string:
+		sanitized := make([]string, len(value))
+		for i, item := range value {
+			sanitized[i] = sanitizeDeep(item, append(path[:len(path):len(path)], strconv.Itoa(i)), rules).(string)
+		}
+		return sanitized
+	default:
+		return v
+	}
+}
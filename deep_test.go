@@ -0,0 +1,93 @@
+package sanitize
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDeep tests the Deep method
+func TestDeep(t *testing.T) {
+	t.Parallel()
+
+	upper := SanitizeFunc(strings.ToUpper)
+
+	var tests = []struct {
+		name     string
+		input    any
+		expected any
+	}{
+		{"nil", nil, nil},
+		{"string", "abc", "ABC"},
+		{"number", 1.5, 1.5},
+		{"map", map[string]any{"a": "x", "b": 2.0, "c": true}, map[string]any{"a": "X", "b": 2.0, "c": true}},
+		{
+			"nested", map[string]any{"a": []any{"x", map[string]any{"b": "y"}}},
+			map[string]any{"a": []any{"X", map[string]any{"b": "Y"}}},
+		},
+		{"string map", map[string]string{"k": "v"}, map[string]string{"k": "V"}},
+		{"string slice", []string{"a", "b"}, []string{"A", "B"}},
+		{"keys untouched", map[string]any{"key": "v"}, map[string]any{"key": "V"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Deep(test.input, upper))
+		})
+	}
+
+	t.Run("input not modified", func(t *testing.T) {
+		input := map[string]any{"a": "x", "b": []any{"y"}}
+		_ = Deep(input, upper)
+		assert.Equal(t, map[string]any{"a": "x", "b": []any{"y"}}, input)
+	})
+
+	t.Run("nil func", func(t *testing.T) {
+		input := map[string]any{"a": "x"}
+		assert.Equal(t, input, Deep(input, nil))
+	})
+}
+
+// TestDeepWithRules tests the DeepWithRules method
+func TestDeepWithRules(t *testing.T) {
+	t.Parallel()
+
+	var payload any
+	require.NoError(t, json.Unmarshal(
+		[]byte(`{"user":{"email":"A@B.COM","name":"<b>John</b>"},"tags":["X1","Y2"],"note":"Keep!"}`), &payload,
+	))
+
+	output := DeepWithRules(payload, map[string]SanitizeFunc{
+		"":           func(s string) string { return strings.TrimSpace(s) },
+		"user.email": func(s string) string { return Email(s, false) },
+		"user.name":  func(s string) string { return HTML(s) },
+		"tags.*":     func(s string) string { return Alpha(s, false) },
+	})
+
+	assert.Equal(t, map[string]any{
+		"user": map[string]any{"email": "a@b.com", "name": "John"},
+		"tags": []any{"X", "Y"},
+		"note": "Keep!",
+	}, output)
+}
+
+// BenchmarkDeep benchmarks the Deep method
+func BenchmarkDeep(b *testing.B) {
+	payload := map[string]any{"user": map[string]any{"name": "<b>John</b>", "tags": []any{"a", "b"}}}
+	for i := 0; i < b.N; i++ {
+		_ = Deep(payload, func(s string) string { return HTML(s) })
+	}
+}
+
+// ExampleDeep example using Deep()
+func ExampleDeep() {
+	var payload any
+	_ = json.Unmarshal([]byte(`{"name":"<b>John</b>","tags":["<i>new</i>"]}`), &payload)
+	output, _ := json.Marshal(Deep(payload, func(s string) string { return HTML(s) }))
+	fmt.Println(string(output))
+	// Output: {"name":"John","tags":["new"]}
+}
@@ -0,0 +1,191 @@
+package sanitize
+
+import "unicode/utf8"
+
+// maxDiffEdits is the largest number of edits computed by Diff before the remaining
+// difference is reported as a single change (this bounds the time and memory used)
+const maxDiffEdits = 512
+
+// Change is a single modification made by a sanitizer
+type Change struct {
+	Offset   int    // Byte offset of the change in the input
+	Removed  string // Text removed from the input
+	Inserted string // Text inserted in place of the removed text
+}
+
+// WouldModify returns true if the sanitizer would change the input, this can be used
+// to run a sanitizer in a report-only mode before enabling it
+//
+//	View examples: diff_test.go
+func WouldModify(input string, fn SanitizeFunc) bool {
+	return fn(input) != input
+}
+
+// Diff returns the changes the sanitizer would make to the input (nil if there are none),
+// changes are computed on runes and ordered by their offset in the input
+//
+//	View examples: diff_test.go
+func Diff(input string, fn SanitizeFunc) []Change {
+	output := fn(input)
+	if output == input {
+		return nil
+	}
+
+	// Trim the common prefix and suffix (on rune boundaries)
+	prefix := 0
+	for prefix < len(input) && prefix < len(output) {
+		_, size := utf8.DecodeRuneInString(input[prefix:])
+		if !hasPrefixAt(output, prefix, input[prefix:prefix+size]) {
+			break
+		}
+		prefix += size
+	}
+	suffix := 0
+	for suffix < len(input)-prefix && suffix < len(output)-prefix {
+		_, size := utf8.DecodeLastRuneInString(input[prefix : len(input)-suffix])
+		end := len(output) - suffix
+		if end-size < prefix || output[end-size:end] != input[len(input)-suffix-size:len(input)-suffix] {
+			break
+		}
+		suffix += size
+	}
+
+	a := []rune(input[prefix : len(input)-suffix])
+	b := []rune(output[prefix : len(output)-suffix])
+	ops, ok := diffRunes(a, b)
+	if !ok {
+		return []Change{{Offset: prefix, Removed: string(a), Inserted: string(b)}}
+	}
+
+	// Group the consecutive edits into changes
+	var changes []Change
+	offset := prefix
+	var current *Change
+	for _, op := range ops {
+		switch op.kind {
+		case diffEqual:
+			current = nil
+			offset += utf8.RuneLen(a[op.index])
+			continue
+		case diffDelete:
+			if current == nil {
+				changes = append(changes, Change{Offset: offset})
+				current = &changes[len(changes)-1]
+			}
+			current.Removed += string(a[op.index])
+			offset += utf8.RuneLen(a[op.index])
+		case diffInsert:
+			if current == nil {
+				changes = append(changes, Change{Offset: offset})
+				current = &changes[len(changes)-1]
+			}
+			current.Inserted += string(b[op.index])
+		}
+	}
+	return changes
+}
+
+// hasPrefixAt returns true if value contains prefix at the given byte offset
+func hasPrefixAt(value string, offset int, prefix string) bool {
+	return len(value)-offset >= len(prefix) && value[offset:offset+len(prefix)] == prefix
+}
+
+// diffKind is the type of edit in a rune diff
+type diffKind uint8
+
+// Supported diff edits
+const (
+	diffEqual diffKind = iota
+	diffDelete
+	diffInsert
+)
+
+// diffOp is a single edit, index is the position in a (equal, delete) or b (insert)
+type diffOp struct {
+	index int
+	kind  diffKind
+}
+
+// diffRunes computes the shortest edit script from a to b (Myers' algorithm),
+// false is returned if the script needs more than maxDiffEdits edits
+func diffRunes(a, b []rune) ([]diffOp, bool) {
+	n, m := len(a), len(b)
+	maxD := minInt(n+m, maxDiffEdits)
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	var trace [][]int
+
+	found := false
+	for d := 0; d <= maxD && !found; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	// Walk back through the trace to build the edit script
+	ops := make([]diffOp, 0, n+m)
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v = trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			ops = append(ops, diffOp{index: x, kind: diffEqual})
+		}
+		if d > 0 {
+			if x == prevX {
+				ops = append(ops, diffOp{index: prevY, kind: diffInsert})
+			} else {
+				ops = append(ops, diffOp{index: prevX, kind: diffDelete})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops, true
+}
+
+// IsIdempotent returns true if running the sanitizer twice gives the same result as running
+// it once (f(f(x)) == f(x)) for every sample. The sanitizers of Funcs (except Domain) and
+// Presets are tested with it, a sanitizer that is not idempotent can be exploited by input
+// that re-forms a removed token.
+//
+//	View examples: diff_test.go
+func IsIdempotent(fn SanitizeFunc, samples []string) bool {
+	for _, sample := range samples {
+		once := fn(sample)
+		if fn(once) != once {
+			return false
+		}
+	}
+	return true
+}
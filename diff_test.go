@@ -0,0 +1,191 @@
+package sanitize
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWouldModify tests the WouldModify method
+func TestWouldModify(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		fn       SanitizeFunc
+		expected bool
+	}{
+		{"clean input", "abc", Funcs.Alpha, false},
+		{"dirty input", "abc123", Funcs.Alpha, true},
+		{"empty input", "", Funcs.HTML, false},
+		{"html", "<b>bold</b>", Funcs.HTML, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, WouldModify(test.input, test.fn))
+		})
+	}
+}
+
+// TestDiff tests the Diff method
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		fn       SanitizeFunc
+		expected []Change
+	}{
+		{"no changes", "abc", Funcs.Alpha, nil},
+		{"single removal", "abc1", Funcs.Alpha, []Change{{Offset: 3, Removed: "1"}}},
+		{
+			"multiple removals",
+			"a1b22c",
+			Funcs.Alpha,
+			[]Change{{Offset: 1, Removed: "1"}, {Offset: 3, Removed: "22"}},
+		},
+		{
+			"html tags",
+			"<i>bold</i> text",
+			Funcs.HTML,
+			[]Change{{Offset: 0, Removed: "<i>"}, {Offset: 7, Removed: "</i>"}},
+		},
+		{
+			"replacement",
+			"Hello World",
+			strings.ToUpper,
+			[]Change{{Offset: 1, Removed: "ello", Inserted: "ELLO"}, {Offset: 7, Removed: "orld", Inserted: "ORLD"}},
+		},
+		{
+			"insertion",
+			"ab",
+			func(s string) string { return s + "!" },
+			[]Change{{Offset: 2, Inserted: "!"}},
+		},
+		{
+			"multibyte offsets",
+			"é1ü2",
+			Funcs.RemoveDigits,
+			[]Change{{Offset: 2, Removed: "1"}, {Offset: 5, Removed: "2"}},
+		},
+		{
+			"everything removed",
+			"123",
+			Funcs.Alpha,
+			[]Change{{Offset: 0, Removed: "123"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Diff(test.input, test.fn))
+		})
+	}
+
+	t.Run("too many edits", func(t *testing.T) {
+		input := strings.Repeat("a1", maxDiffEdits+1)
+		changes := Diff(input, Funcs.Alpha)
+		assert.Len(t, changes, 1)
+		assert.Equal(t, 1, changes[0].Offset)
+		assert.Equal(t, strings.Repeat("a", maxDiffEdits), changes[0].Inserted)
+	})
+
+	t.Run("changes rebuild the output", func(t *testing.T) {
+		input := "  <p>Some <i>text</i> with 123 numbers!</p>"
+		changes := Diff(input, Funcs.AlphaWithSpaces)
+		var b strings.Builder
+		last := 0
+		for _, change := range changes {
+			b.WriteString(input[last:change.Offset])
+			b.WriteString(change.Inserted)
+			last = change.Offset + len(change.Removed)
+		}
+		b.WriteString(input[last:])
+		assert.Equal(t, Alpha(input, true), b.String())
+	})
+}
+
+// idempotencySamples are the fragments combined to build the idempotency samples
+var idempotencySamples = []string{
+	"", "abc", "  Hello   World  ", "MiXeD CaSe wOrDs", "fooBar HTTPServer", "a b c", "a-b-c", "__init__",
+	"<b>bold</b> &amp; <i>x</i>", "<<b>b>x", "</", "<", ">", "<!--c-->", "<![CDATA[x]]>", "<style>a</style>",
+	"<scr<script>ipt>alert(1)</scr</script>ipt>", "<<script>>", "<a href=\"javascript:x\">y</a>",
+	"javascjavascript:ript:", "eval((x))", "&lt;script&gt;", "expression(alert(1))", "url(javascript:x)",
+	"user@Example.COM", "mailto:mailto:x@y.com", "https://www.example.com/path?q=1#x", "http://http://x",
+	"1.5e-10", "12:30:45", "$100,000.50", "192.168.0.1", "2001:db8::1", "/path/../to/file.txt",
+	"Crème brûlée", "Ünïcödé ÀÉÎ", "\ufb01", "\u01c5", "ß", "line1\nline2\r\n\tline3", "a\u00a0b\u200bc",
+	"o'Neil, Jr.", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "<\u200bimg src=x>", "<\u202eb>x", "<\x1b[0mb>",
+}
+
+// TestIsIdempotent tests the IsIdempotent method
+func TestIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("idempotent function", func(t *testing.T) {
+		assert.True(t, IsIdempotent(strings.TrimSpace, []string{" a ", "b", ""}))
+	})
+
+	t.Run("not idempotent", func(t *testing.T) {
+		removeOnce := func(s string) string { return strings.Replace(s, "ab", "", 1) }
+		assert.False(t, IsIdempotent(removeOnce, []string{"x", "aabb"}))
+	})
+
+	t.Run("no samples", func(t *testing.T) {
+		assert.True(t, IsIdempotent(strings.ToUpper, nil))
+	})
+
+	t.Run("built-in sanitizers", func(t *testing.T) {
+		// Every pair of fragments, so that tokens can be split across the fragments
+		samples := make([]string, 0, len(idempotencySamples)*len(idempotencySamples))
+		for _, first := range idempotencySamples {
+			for _, second := range idempotencySamples {
+				samples = append(samples, first+second)
+			}
+		}
+
+		for name, fn := range FuncsByName() {
+			if name == "domain" {
+				continue // Only adds http:// without "http" in the value, a host named http becomes empty
+			}
+			for _, sample := range samples {
+				once := fn(sample)
+				assert.Equal(t, once, fn(once), "%s(%q)", name, sample)
+			}
+			assert.True(t, IsIdempotent(fn, samples), name)
+		}
+	})
+}
+
+// BenchmarkDiff benchmarks the Diff method
+func BenchmarkDiff(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Diff("<b>This is the test string 123.</b>", Funcs.Alpha)
+	}
+}
+
+// ExampleWouldModify example using WouldModify()
+func ExampleWouldModify() {
+	fmt.Println(WouldModify("Example", Funcs.Alpha), WouldModify("Example 123", Funcs.Alpha))
+	// Output: false true
+}
+
+// ExampleIsIdempotent example using IsIdempotent()
+func ExampleIsIdempotent() {
+	fmt.Println(IsIdempotent(Funcs.HTML, []string{"<<b>b>x", "<p>Text</p>"}))
+	// Output: true
+}
+
+// ExampleDiff example using Diff()
+func ExampleDiff() {
+	for _, change := range Diff("Hello <b>World</b>!", Funcs.HTML) {
+		fmt.Printf("%d %q\n", change.Offset, change.Removed)
+	}
+	// Output:
+	// 6 "<b>"
+	// 14 "</b>"
+}
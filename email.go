@@ -0,0 +1,246 @@
+package sanitize
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Email address limits (RFC 5321)
+const (
+	maxEmailLength     = 254
+	maxLocalPartLength = 64
+)
+
+// emailDotInsensitiveDomains are the providers that ignore dots in the local part
+var emailDotInsensitiveDomains = map[string]bool{
+	"gmail.com":      true,
+	"googlemail.com": true,
+}
+
+// emailLocalPartRegExp is the dot-atom form of a local part (RFC 5322)
+var emailLocalPartRegExp = regexp.MustCompile("^[a-zA-Z0-9!#$%&'*+/=?^_`{|}~-]+(\\.[a-zA-Z0-9!#$%&'*+/=?^_`{|}~-]+)*$")
+
+// emailUnicodeLocalPartRegExp is the dot-atom form of an internationalized local part (RFC 6531)
+var emailUnicodeLocalPartRegExp = regexp.MustCompile(
+	"^[\\pL\\pM\\pNa-zA-Z0-9!#$%&'*+/=?^_`{|}~-]+(\\.[\\pL\\pM\\pNa-zA-Z0-9!#$%&'*+/=?^_`{|}~-]+)*$",
+)
+
+// EmailAction is what EmailWithPolicy does with an address that matches one of its checks
+type EmailAction int
+
+// Supported email actions
+const (
+	EmailAllow  EmailAction = iota // Accept the address (the check is skipped)
+	EmailFlag                      // Return the address along with the error
+	EmailReject                    // Return an empty address and the error
+)
+
+// EmailPolicy is the set of checks applied by EmailWithPolicy
+type EmailPolicy struct {
+	Disposable        EmailAction // Action for disposable (throw-away) email domains
+	DisposableDomains []string    // Additional disposable domains (sub-domains are matched as well)
+	PreserveCase      bool        // Keep the case of the local part
+	RoleAccount       EmailAction // Action for role accounts (admin@, noreply@, etc.)
+	RoleAccounts      []string    // Additional role account names
+}
+
+// disposableEmailDomains are well known disposable email providers (sub-domains are matched as well)
+var disposableEmailDomains = map[string]bool{
+	"10minutemail.com": true, "20minutemail.com": true, "33mail.com": true, "anonbox.net": true,
+	"burnermail.io": true, "discard.email": true, "dispostable.com": true, "dropmail.me": true,
+	"emailondeck.com": true, "fakeinbox.com": true, "fakemail.net": true, "getairmail.com": true,
+	"getnada.com": true, "guerrillamail.biz": true, "guerrillamail.com": true, "guerrillamail.de": true,
+	"guerrillamail.info": true, "guerrillamail.net": true, "guerrillamail.org": true,
+	"guerrillamailblock.com": true, "harakirimail.com": true, "inboxkitten.com": true,
+	"incognitomail.org": true, "jetable.org": true, "mail.tm": true, "mailcatch.com": true,
+	"maildrop.cc": true, "mailinator.com": true, "mailinator.net": true, "mailnesia.com": true,
+	"mailpoof.com": true, "mintemail.com": true, "moakt.com": true, "mohmal.com": true,
+	"mytemp.email": true, "mytrashmail.com": true, "nada.email": true, "sharklasers.com": true,
+	"spam4.me": true, "spamgourmet.com": true, "spambox.us": true, "tempail.com": true,
+	"tempinbox.com": true, "tempmail.com": true, "tempmail.net": true, "tempmailo.com": true,
+	"temp-mail.io": true, "temp-mail.org": true, "tempr.email": true, "throwawaymail.com": true,
+	"trashmail.com": true, "trashmail.de": true, "trashmail.net": true, "wegwerfmail.de": true,
+	"yopmail.com": true, "yopmail.fr": true, "yopmail.net": true,
+}
+
+// roleEmailAccounts are the local parts used by roles (or systems) rather than people
+var roleEmailAccounts = map[string]bool{
+	"abuse": true, "admin": true, "administrator": true, "billing": true, "contact": true,
+	"do-not-reply": true, "donotreply": true, "help": true, "hostmaster": true, "info": true,
+	"mailer-daemon": true, "marketing": true, "no-reply": true, "noc": true, "noreply": true,
+	"office": true, "postmaster": true, "root": true, "sales": true, "security": true,
+	"support": true, "sysadmin": true, "team": true, "webmaster": true,
+}
+
+// EmailWithPolicy returns the address in the canonical form of EmailStrict and applies the
+// policy checks for disposable domains and role accounts. A flagged address is returned along
+// with the error (ErrDisposableEmail or ErrRoleAccount) so that it can be accepted and marked,
+// a rejected address is returned as an empty string. Invalid addresses return ErrInvalidEmail.
+//
+//	View examples: email_test.go
+func EmailWithPolicy(original string, policy EmailPolicy) (string, error) {
+	email, err := EmailStrict(original, policy.PreserveCase)
+	if err != nil {
+		return "", err
+	}
+	at := strings.LastIndex(email, "@")
+	local, domain := strings.ToLower(email[:at]), email[at+1:]
+
+	if policy.Disposable != EmailAllow && isDisposableDomain(domain, policy.DisposableDomains) {
+		return applyEmailAction(email, policy.Disposable, ErrDisposableEmail)
+	}
+	if policy.RoleAccount != EmailAllow && isRoleAccount(local, policy.RoleAccounts) {
+		return applyEmailAction(email, policy.RoleAccount, ErrRoleAccount)
+	}
+	return email, nil
+}
+
+// applyEmailAction returns the result of a policy check that matched the address
+func applyEmailAction(email string, action EmailAction, sentinel error) (string, error) {
+	err := fmt.Errorf("%w: %q", sentinel, email)
+	if action == EmailReject {
+		return "", err
+	}
+	return email, err
+}
+
+// isDisposableDomain returns true if the domain (or a parent domain) is a disposable domain
+func isDisposableDomain(domain string, custom []string) bool {
+	for {
+		if disposableEmailDomains[domain] {
+			return true
+		}
+		for _, d := range custom {
+			if strings.EqualFold(strings.TrimSuffix(d, "."), domain) {
+				return true
+			}
+		}
+		dot := strings.IndexByte(domain, '.')
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+}
+
+// isRoleAccount returns true if the local part (without a +tag) is a role account
+func isRoleAccount(local string, custom []string) bool {
+	if plus := strings.IndexByte(local, '+'); plus > 0 {
+		local = local[:plus]
+	}
+	if roleEmailAccounts[local] {
+		return true
+	}
+	for _, account := range custom {
+		if strings.EqualFold(account, local) {
+			return true
+		}
+	}
+	return false
+}
+
+// EmailStrict parses the email address (RFC 5322) and returns it in a canonical form or
+// an error if the address is invalid. Display names and mail-to prefixes are removed
+// ("John" <john@example.com> => john@example.com), internationalized domains are converted
+// to punycode and each domain label is validated. The domain is always lowercased, use
+// preserveCase to keep the case of the local part. Use WithUnicodeEmail to accept
+// internationalized local parts (RFC 6531).
+//
+//	View examples: email_test.go
+func EmailStrict(original string, preserveCase bool, opts ...Option) (string, error) {
+	o := newOptions(opts)
+	if err := o.checkSize(original); err != nil {
+		return "", err
+	}
+	original = o.before(original)
+
+	// Remove the mail-to prefix and surrounding spaces
+	original = strings.TrimSpace(original)
+	if len(original) >= 7 && strings.EqualFold(original[:7], "mailto:") {
+		original = strings.TrimSpace(original[7:])
+	}
+	if len(original) == 0 {
+		return "", fmt.Errorf("%w: empty address", ErrInvalidEmail)
+	}
+
+	// Parse the address (removes any display name)
+	address, err := mail.ParseAddress(original)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidEmail, err.Error())
+	}
+	if strings.Count(address.Address, "@") != 1 {
+		return "", fmt.Errorf("%w: multiple @ symbols", ErrInvalidEmail)
+	}
+	at := strings.LastIndex(address.Address, "@")
+	local, domain := address.Address[:at], address.Address[at+1:]
+
+	// Validate the local part
+	if len(local) > maxLocalPartLength {
+		return "", fmt.Errorf("%w: local part is longer than %d characters", ErrInvalidEmail, maxLocalPartLength)
+	} else if !emailLocalPartRegExp.MatchString(local) &&
+		(o == nil || !o.unicodeEmail || !emailUnicodeLocalPartRegExp.MatchString(local)) {
+		return "", fmt.Errorf("%w: invalid local part %q", ErrInvalidEmail, local)
+	}
+	if !preserveCase {
+		local = strings.ToLower(local)
+	}
+
+	// Validate and normalize the domain
+	if domain, err = domainToASCII(domain); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidEmail, err.Error())
+	} else if !strings.Contains(domain, ".") {
+		return "", fmt.Errorf("%w: domain %q is not fully qualified", ErrInvalidEmail, domain)
+	}
+
+	email := local + "@" + domain
+	if len(email) > maxEmailLength {
+		return "", fmt.Errorf("%w: longer than %d characters", ErrInvalidEmail, maxEmailLength)
+	}
+	return o.after(o.email(email)), nil
+}
+
+// unicodeEmailRune keeps the email address characters and the unicode letters, marks and
+// numbers of internationalized addresses (used by Email with WithUnicodeEmail)
+func unicodeEmailRune(r rune) rune {
+	switch {
+	case r >= utf8.RuneSelf:
+		if unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsNumber(r) {
+			return r
+		}
+	case (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'),
+		r == '-', r == '_', r == '.', r == '@', r == '+':
+		return r
+	}
+	return -1
+}
+
+// email applies the email normalization options to the address
+func (o *options) email(address string) string {
+	if o == nil || (!o.stripEmailTag && !o.stripEmailDots) {
+		return address
+	}
+
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return address
+	}
+	local, domain := address[:at], address[at:]
+
+	// Remove the sub-addressing tag (user+tag)
+	if o.stripEmailTag {
+		if plus := strings.Index(local, "+"); plus > 0 {
+			local = local[:plus]
+		}
+	}
+
+	// Remove the dots for providers that ignore them
+	if o.stripEmailDots && emailDotInsensitiveDomains[strings.ToLower(domain[1:])] {
+		local = strings.Replace(local, ".", "", -1)
+	}
+
+	return local + domain
+}
@@ -0,0 +1,263 @@
+package sanitize
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEmailStrict tests the EmailStrict method
+func TestEmailStrict(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name         string
+			input        string
+			expected     string
+			preserveCase bool
+		}{
+			{"basic", "test@example.com", "test@example.com", false},
+			{"lowercase", "TestME@GmAil.com", "testme@gmail.com", false},
+			{"preserve case", "TestME@GmAil.com", "TestME@gmail.com", true},
+			{"mailto", "mailto:testME@GmAil.com", "testme@gmail.com", false},
+			{"mailto uppercase", "MAILTO:test@example.com", "test@example.com", false},
+			{"spaces", "  test@example.com  ", "test@example.com", false},
+			{"display name", `"John" <j@x.com>`, "j@x.com", false},
+			{"display name unquoted", "John Doe <John.Doe@Example.com>", "john.doe@example.com", false},
+			{"angle brackets", "<test@example.com>", "test@example.com", false},
+			{"plus tag", "test+tag@example.com", "test+tag@example.com", false},
+			{"idn domain", "test@Bücher.example", "test@xn--bcher-kva.example", false},
+			{"decomposed idn domain", "test@Bu\u0308cher.example", "test@xn--bcher-kva.example", false},
+			{"combining mark", "test@exa\u0301mple.com", "test@xn--exmple-qta.com", false},
+			{"subdomain", "test@mail.example.co.uk", "test@mail.example.co.uk", false},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := EmailStrict(test.input, test.preserveCase)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"symbols only", "!@#$%"},
+			{"at only", "@"},
+			{"double at", "test@@example.com"},
+			{"multiple at", "test@foo@example.com"},
+			{"quoted at", `"test@foo"@example.com`},
+			{"missing domain", "test@"},
+			{"missing local", "@example.com"},
+			{"no tld", "test@localhost"},
+			{"hyphen label", "test@-foo-.com"},
+			{"empty label", "test@foo..com"},
+			{"long label", "test@" + strings.Repeat("a", 64) + ".com"},
+			{"long local part", strings.Repeat("a", 65) + "@example.com"},
+			{"long address", strings.Repeat("a", 10) + "@" + strings.Repeat(strings.Repeat("a", 60)+".", 4) + "com"},
+			{"spaces in local", "te st@example.com"},
+			{"underscore domain", "test@exa_mple.com"},
+			{"two addresses", "a@example.com, b@example.com"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := EmailStrict(test.input, false)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidEmail)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+
+	t.Run("unicode email", func(t *testing.T) {
+		_, err := EmailStrict("t\u00e9st@example.com", false)
+		require.ErrorIs(t, err, ErrInvalidEmail)
+
+		output, err := EmailStrict("T\u00e9st@Ex\u00e1mple.com", false, WithUnicodeEmail())
+		require.NoError(t, err)
+		assert.Equal(t, "t\u00e9st@xn--exmple-qta.com", output)
+
+		output, err = EmailStrict("\u7528\u6237.\u540d@example.com", false, WithUnicodeEmail())
+		require.NoError(t, err)
+		assert.Equal(t, "\u7528\u6237.\u540d@example.com", output)
+
+		_, err = EmailStrict("t\u00e9st\u2026@example.com", false, WithUnicodeEmail())
+		require.ErrorIs(t, err, ErrInvalidEmail)
+	})
+}
+
+// BenchmarkEmailStrict benchmarks the EmailStrict method
+func BenchmarkEmailStrict(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = EmailStrict(`"Person" <Person@Example.COM>`, false)
+	}
+}
+
+// ExampleEmailStrict example using EmailStrict()
+func ExampleEmailStrict() {
+	fmt.Println(EmailStrict(`"John" <John@Example.COM>`, false))
+	// Output: john@example.com <nil>
+}
+
+// ExampleEmailStrict_invalid example using EmailStrict() with an invalid address
+func ExampleEmailStrict_invalid() {
+	_, err := EmailStrict("test@@example.com", false)
+	fmt.Println(errors.Is(err, ErrInvalidEmail))
+	// Output: true
+}
+
+// TestEmailNormalizationOptions tests the WithStripEmailTag and WithStripEmailDots options
+func TestEmailNormalizationOptions(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+		options  []Option
+	}{
+		{"no options", "First.Last+tag@Gmail.com", "first.last+tag@gmail.com", nil},
+		{"strip tag", "First.Last+tag@Gmail.com", "first.last@gmail.com", []Option{WithStripEmailTag()}},
+		{"strip multiple tags", "user+a+b@example.com", "user@example.com", []Option{WithStripEmailTag()}},
+		{"strip dots", "First.Last+tag@Gmail.com", "firstlast+tag@gmail.com", []Option{WithStripEmailDots()}},
+		{"strip dots googlemail", "first.last@googlemail.com", "firstlast@googlemail.com", []Option{WithStripEmailDots()}},
+		{"strip dots other provider", "first.last@example.com", "first.last@example.com", []Option{WithStripEmailDots()}},
+		{
+			"strip both", "First.Last+tag@Gmail.com", "firstlast@gmail.com",
+			[]Option{WithStripEmailTag(), WithStripEmailDots()},
+		},
+		{"leading plus kept", "+tag@example.com", "+tag@example.com", []Option{WithStripEmailTag()}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Email(test.input, false, test.options...))
+
+			output, err := EmailStrict(test.input, false, test.options...)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, output)
+		})
+	}
+
+	t.Run("preserve case", func(t *testing.T) {
+		output := Email("First.Last+tag@Gmail.com", true, WithStripEmailTag(), WithStripEmailDots())
+		assert.Equal(t, "FirstLast@Gmail.com", output)
+	})
+}
+
+// BenchmarkEmail_StripTagAndDots benchmarks the Email method with the normalization options
+func BenchmarkEmail_StripTagAndDots(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Email("mailto:First.Last+tag@Gmail.COM", false, WithStripEmailTag(), WithStripEmailDots())
+	}
+}
+
+// ExampleWithStripEmailTag example using Email() and the WithStripEmailTag() option
+func ExampleWithStripEmailTag() {
+	fmt.Println(Email("Person+Newsletter@Example.COM", false, WithStripEmailTag()))
+	// Output: person@example.com
+}
+
+// ExampleWithStripEmailDots example using Email() and the WithStripEmailDots() option
+func ExampleWithStripEmailDots() {
+	fmt.Println(Email("First.Last@Gmail.com", false, WithStripEmailDots()))
+	// Output: firstlast@gmail.com
+}
+
+// TestEmailWithPolicy tests the EmailWithPolicy method
+func TestEmailWithPolicy(t *testing.T) {
+	t.Parallel()
+
+	reject := EmailPolicy{Disposable: EmailReject, RoleAccount: EmailReject}
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			policy   EmailPolicy
+			expected string
+		}{
+			{"personal address", "John.Doe@Example.com", reject, "john.doe@example.com"},
+			{"preserve case", "John.Doe@Example.com", EmailPolicy{PreserveCase: true}, "John.Doe@example.com"},
+			{"disposable allowed", "test@mailinator.com", EmailPolicy{RoleAccount: EmailReject}, "test@mailinator.com"},
+			{"role allowed", "admin@example.com", EmailPolicy{Disposable: EmailReject}, "admin@example.com"},
+			{"role-like name", "administrator.jane@example.com", reject, "administrator.jane@example.com"},
+			{"similar domain", "test@notmailinator.com", reject, "test@notmailinator.com"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := EmailWithPolicy(test.input, test.policy)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			policy   EmailPolicy
+			expected string
+			err      error
+		}{
+			{"invalid address", "not an email", reject, "", ErrInvalidEmail},
+			{"disposable rejected", "test@Mailinator.com", reject, "", ErrDisposableEmail},
+			{"disposable sub-domain", "test@eu.mailinator.com", reject, "", ErrDisposableEmail},
+			{"disposable flagged", "test@yopmail.com", EmailPolicy{Disposable: EmailFlag}, "test@yopmail.com",
+				ErrDisposableEmail},
+			{"custom disposable", "test@throwaway.example", EmailPolicy{
+				Disposable: EmailReject, DisposableDomains: []string{"Throwaway.Example"},
+			}, "", ErrDisposableEmail},
+			{"role rejected", "Admin@example.com", reject, "", ErrRoleAccount},
+			{"role with tag", "noreply+alerts@example.com", reject, "", ErrRoleAccount},
+			{"role flagged", "support@example.com", EmailPolicy{RoleAccount: EmailFlag}, "support@example.com",
+				ErrRoleAccount},
+			{"custom role", "careers@example.com", EmailPolicy{
+				RoleAccount: EmailReject, RoleAccounts: []string{"Careers"},
+			}, "", ErrRoleAccount},
+			{"disposable checked first", "admin@mailinator.com", reject, "", ErrDisposableEmail},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := EmailWithPolicy(test.input, test.policy)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, test.err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+}
+
+// BenchmarkEmailWithPolicy benchmarks the EmailWithPolicy method
+func BenchmarkEmailWithPolicy(b *testing.B) {
+	policy := EmailPolicy{Disposable: EmailReject, RoleAccount: EmailFlag}
+	for i := 0; i < b.N; i++ {
+		_, _ = EmailWithPolicy("John.Doe@Example.com", policy)
+	}
+}
+
+// ExampleEmailWithPolicy example using EmailWithPolicy()
+func ExampleEmailWithPolicy() {
+	policy := EmailPolicy{Disposable: EmailReject, RoleAccount: EmailFlag}
+	fmt.Println(EmailWithPolicy("John.Doe@Example.com", policy))
+	fmt.Println(EmailWithPolicy("test@mailinator.com", policy))
+	fmt.Println(EmailWithPolicy("admin@example.com", policy))
+	// Output:
+	// john.doe@example.com <nil>
+	//  disposable email domain: "test@mailinator.com"
+	// admin@example.com role email account: "admin@example.com"
+}
@@ -0,0 +1,43 @@
+package sanitize
+
+import "errors"
+
+// Errors returned by the validating sanitizers, use errors.Is() to check for them
+var (
+	ErrDisposableEmail      = errors.New("disposable email domain")
+	ErrInputTooLarge        = errors.New("input is too large")
+	ErrInvalidAmount        = errors.New("invalid amount")
+	ErrInvalidBarcode       = errors.New("invalid barcode")
+	ErrInvalidBoolean       = errors.New("invalid boolean")
+	ErrInvalidColor         = errors.New("invalid color")
+	ErrInvalidCoordinates   = errors.New("invalid coordinates")
+	ErrInvalidCountryCode   = errors.New("invalid country code")
+	ErrInvalidCryptoAddress = errors.New("invalid crypto address")
+	ErrInvalidCurrency      = errors.New("invalid currency code")
+	ErrInvalidDSN           = errors.New("invalid dsn")
+	ErrInvalidDomain        = errors.New("invalid domain name")
+	ErrInvalidEnum          = errors.New("value is not allowed")
+	ErrInvalidEmail         = errors.New("invalid email address")
+	ErrInvalidEncoding      = errors.New("invalid encoding")
+	ErrInvalidHandle        = errors.New("invalid social handle")
+	ErrInvalidHash          = errors.New("invalid hash")
+	ErrInvalidIMEI          = errors.New("invalid imei")
+	ErrInvalidIPAddress     = errors.New("invalid ip address")
+	ErrInvalidISBN          = errors.New("invalid isbn")
+	ErrInvalidJSON          = errors.New("invalid json")
+	ErrInvalidKeyValue      = errors.New("invalid key value pair")
+	ErrInvalidLanguageTag   = errors.New("invalid language tag")
+	ErrInvalidNationalID    = errors.New("invalid national id")
+	ErrInvalidNumber        = errors.New("invalid number")
+	ErrInvalidPaymentURI    = errors.New("invalid payment uri")
+	ErrInvalidPattern       = errors.New("invalid regular expression")
+	ErrInvalidPercent       = errors.New("invalid percentage")
+	ErrInvalidRomanNumeral  = errors.New("invalid roman numeral")
+	ErrInvalidURL           = errors.New("invalid url")
+	ErrInvalidVATNumber     = errors.New("invalid vat number")
+	ErrPatternTimeout       = errors.New("pattern match timed out")
+	ErrPatternTooComplex    = errors.New("pattern is too complex")
+	ErrRoleAccount          = errors.New("role email account")
+	ErrUnknownColumn        = errors.New("unknown column")
+	ErrUnsafeURL            = errors.New("unsafe url")
+)
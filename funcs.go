@@ -0,0 +1,300 @@
+package sanitize
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SanitizeFunc is a function that sanitizes a string, any of the sanitizers in this
+// package can be wrapped in a SanitizeFunc (with their flags and options set) and
+// used with the JSON and structure sanitizers
+type SanitizeFunc func(string) string
+
+// FuncSet is the set of built-in sanitizers exposed as SanitizeFunc values
+type FuncSet struct {
+	ASCII                  SanitizeFunc // ASCII with transliteration
+	Alpha                  SanitizeFunc // Alpha without spaces
+	AlphaNumeric           SanitizeFunc // AlphaNumeric without spaces
+	AlphaNumericWithSpaces SanitizeFunc // AlphaNumeric with spaces
+	AlphaWithSpaces        SanitizeFunc // Alpha with spaces
+	BidiControls           SanitizeFunc // BidiControls with BidiStrip
+	BitcoinAddress         SanitizeFunc
+	BitcoinCashAddress     SanitizeFunc
+	CSS                    SanitizeFunc
+	CamelCase              SanitizeFunc
+	Decimal                SanitizeFunc
+	Domain                 SanitizeFunc // Domain lowercased (returns empty on error)
+	Email                  SanitizeFunc // Email lowercased
+	EmailPreserveCase      SanitizeFunc // Email preserving the case
+	ExtractDecimal         SanitizeFunc
+	ExtractInteger         SanitizeFunc
+	FirstToUpper           SanitizeFunc
+	FoldDigits             SanitizeFunc
+	FormalName             SanitizeFunc
+	HTML                   SanitizeFunc
+	Hashtag                SanitizeFunc
+	IPAddress              SanitizeFunc
+	Indentation            SanitizeFunc
+	KebabCase              SanitizeFunc
+	Mention                SanitizeFunc
+	Numeric                SanitizeFunc
+	Ordinals               SanitizeFunc
+	PathName               SanitizeFunc
+	Punctuation            SanitizeFunc
+	RemoveDigits           SanitizeFunc
+	RemoveLetters          SanitizeFunc
+	RemoveNonASCII         SanitizeFunc
+	RemovePunctuation      SanitizeFunc
+	RemoveWhitespace       SanitizeFunc
+	ScientificNotation     SanitizeFunc
+	Scripts                SanitizeFunc
+	Secrets                SanitizeFunc
+	SingleLine             SanitizeFunc
+	SnakeCase              SanitizeFunc
+	StyleAttr              SanitizeFunc
+	Time                   SanitizeFunc
+	Title                  SanitizeFunc
+	TitleCase              SanitizeFunc
+	URI                    SanitizeFunc
+	URL                    SanitizeFunc
+	XML                    SanitizeFunc
+	XSS                    SanitizeFunc
+}
+
+// Funcs are the built-in sanitizers as SanitizeFunc values (with their default flags),
+// these can be stored in tables, passed to pipelines or wrapped with decorators
+//
+//	View examples: funcs_test.go
+var Funcs = FuncSet{
+	ASCII:                  func(s string) string { return ASCII(s, true) },
+	Alpha:                  func(s string) string { return Alpha(s, false) },
+	AlphaNumeric:           func(s string) string { return AlphaNumeric(s, false) },
+	AlphaNumericWithSpaces: func(s string) string { return AlphaNumeric(s, true) },
+	AlphaWithSpaces:        func(s string) string { return Alpha(s, true) },
+	BidiControls:           func(s string) string { return BidiControls(s, BidiStrip) },
+	BitcoinAddress:         func(s string) string { return BitcoinAddress(s) },
+	BitcoinCashAddress:     func(s string) string { return BitcoinCashAddress(s) },
+	CSS:                    func(s string) string { return CSS(s) },
+	CamelCase:              CamelCase,
+	Decimal:                func(s string) string { return Decimal(s) },
+	Domain: func(s string) string {
+		domain, err := Domain(s, false, false)
+		if err != nil {
+			return ""
+		}
+		return domain
+	},
+	Email:              func(s string) string { return Email(s, false) },
+	EmailPreserveCase:  func(s string) string { return Email(s, true) },
+	ExtractDecimal:     func(s string) string { return ExtractDecimal(s) },
+	ExtractInteger:     func(s string) string { return ExtractInteger(s) },
+	FirstToUpper:       FirstToUpper,
+	FoldDigits:         func(s string) string { return FoldDigits(s) },
+	FormalName:         func(s string) string { return FormalName(s) },
+	HTML:               func(s string) string { return HTML(s) },
+	Hashtag:            Hashtag,
+	IPAddress:          func(s string) string { return IPAddress(s) },
+	Indentation:        func(s string) string { return Indentation(s) },
+	KebabCase:          KebabCase,
+	Mention:            Mention,
+	Numeric:            func(s string) string { return Numeric(s) },
+	Ordinals:           func(s string) string { return Ordinals(s) },
+	PathName:           func(s string) string { return PathName(s) },
+	Punctuation:        func(s string) string { return Punctuation(s) },
+	RemoveDigits:       func(s string) string { return RemoveDigits(s) },
+	RemoveLetters:      func(s string) string { return RemoveLetters(s) },
+	RemoveNonASCII:     func(s string) string { return RemoveNonASCII(s) },
+	RemovePunctuation:  func(s string) string { return RemovePunctuation(s) },
+	RemoveWhitespace:   func(s string) string { return RemoveWhitespace(s) },
+	ScientificNotation: func(s string) string { return ScientificNotation(s) },
+	Scripts:            func(s string) string { return Scripts(s) },
+	Secrets:            func(s string) string { return Secrets(s) },
+	SingleLine:         func(s string) string { return SingleLine(s) },
+	SnakeCase:          SnakeCase,
+	StyleAttr:          func(s string) string { return StyleAttr(s) },
+	Time:               func(s string) string { return Time(s) },
+	Title:              func(s string) string { return Title(s) },
+	TitleCase:          TitleCase,
+	URI:                func(s string) string { return URI(s) },
+	URL:                func(s string) string { return URL(s) },
+	XML:                func(s string) string { return XML(s) },
+	XSS:                func(s string) string { return XSS(s) },
+}
+
+// PresetSet is the set of composite sanitizers for the common destinations of user input
+type PresetSet struct {
+	DBSafe      SanitizeFunc // Valid UTF-8 without NUL and control characters (not SQL escaping)
+	DisplaySafe SanitizeFunc // Plain text without tags, escape sequences, control characters or bidi controls
+	FormInput   SanitizeFunc // Single line form field without tags, scripts, controls or extra spaces
+	LogSafe     SanitizeFunc // Single line log field with the secrets and terminal escapes removed
+}
+
+// Presets are composite sanitizers built from the primitives of this package, these are
+// sensible defaults for the usual destinations of user input:
+//
+//	DBSafe:      invalid UTF-8, NUL and control characters are removed (tabs and new lines are kept)
+//	DisplaySafe: tags, escape sequences, control characters (except tabs and new lines) and bidi controls are removed
+//	FormInput:   new lines, control characters, bidi controls, tags and scripts are removed, spaces are collapsed
+//	LogSafe:     secrets are redacted, escape sequences, new lines, control characters and bidi controls are removed
+//
+//	View examples: funcs_test.go
+var Presets = PresetSet{
+	DBSafe: Chain(
+		func(s string) string { return strings.ToValidUTF8(s, "") },
+		func(s string) string { return removeControls(s, "\t\n\r") },
+	),
+	DisplaySafe: Chain(
+		func(s string) string { return ANSI(s) },
+		func(s string) string { return removeControls(s, "\t\n") },
+		func(s string) string { return BidiControls(s, BidiStrip) },
+		func(s string) string { return HTML(s, WithTrim()) }, // Last, the removed characters can hide a tag
+	),
+	FormInput: Chain(
+		func(s string) string { return SingleLine(strings.ToValidUTF8(s, "")) },
+		func(s string) string { return removeControls(s, "") },
+		func(s string) string { return BidiControls(s, BidiStrip) },
+		func(s string) string { return HTML(s) },
+		func(s string) string { return XSS(s, WithCollapseSpaces(), WithTrim()) },
+	),
+	LogSafe: Chain(
+		func(s string) string { return ANSI(s) },
+		func(s string) string { return Secrets(s) },
+		func(s string) string { return SingleLine(strings.ToValidUTF8(s, "")) },
+		func(s string) string { return removeControls(s, "") },
+		func(s string) string { return BidiControls(s, BidiStrip, WithTrim()) },
+	),
+}
+
+// FuncsByName returns the built-in sanitizers (Funcs) and presets (Presets) by their lowercase
+// names ("email", "forminput") along with lower, trim and upper, to look up the sanitizers
+// named in configuration or on a command line. A new map is returned on every call.
+//
+//	View examples: funcs_test.go
+func FuncsByName() map[string]SanitizeFunc {
+	fns := map[string]SanitizeFunc{
+		"lower": strings.ToLower,
+		"trim":  strings.TrimSpace,
+		"upper": strings.ToUpper,
+	}
+	for _, set := range []any{Funcs, Presets} {
+		value := reflect.ValueOf(set)
+		for i := 0; i < value.NumField(); i++ {
+			fns[strings.ToLower(value.Type().Field(i).Name)] = value.Field(i).Interface().(SanitizeFunc)
+		}
+	}
+	return fns
+}
+
+// removeControls removes the control characters that are not in keep
+func removeControls(value, keep string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && !strings.ContainsRune(keep, r) {
+			return -1
+		}
+		return r
+	}, value)
+}
+
+// Chain returns a SanitizeFunc that runs each of the functions in order,
+// nil functions are skipped
+//
+//	View examples: funcs_test.go
+func Chain(fns ...SanitizeFunc) SanitizeFunc {
+	return func(s string) string {
+		for _, fn := range fns {
+			if fn != nil {
+				s = fn(s)
+			}
+		}
+		return s
+	}
+}
+
+// When returns a SanitizeFunc that only runs fn when the predicate is true for the value,
+// otherwise the value is returned as-is. Use this to skip expensive steps (HTML, XSS) for
+// input that can not need them. A nil predicate or fn returns the value as-is.
+//
+//	View examples: funcs_test.go
+func When(predicate func(string) bool, fn SanitizeFunc) SanitizeFunc {
+	return func(s string) string {
+		if predicate == nil || fn == nil || !predicate(s) {
+			return s
+		}
+		return fn(s)
+	}
+}
+
+// Unless returns a SanitizeFunc that only runs fn when the predicate is false for the value,
+// otherwise the value is returned as-is. A nil predicate always runs fn and a nil fn returns
+// the value as-is.
+//
+//	View examples: funcs_test.go
+func Unless(predicate func(string) bool, fn SanitizeFunc) SanitizeFunc {
+	return func(s string) string {
+		if fn == nil || (predicate != nil && predicate(s)) {
+			return s
+		}
+		return fn(s)
+	}
+}
+
+// defaultChunkSize is the chunk size used by Large() when the given size is zero or less
+const defaultChunkSize = 256 * 1024
+
+// Large sanitizes a very large string in parallel: the input is split into chunks of about
+// chunkSize bytes (on rune boundaries), the chunks are sanitized concurrently (using up to
+// GOMAXPROCS goroutines) and the results are joined in order. Input that fits in a single chunk
+// is sanitized directly. A chunkSize of zero or less uses a 256 KB chunk.
+//
+// This is only correct for sanitizers that filter or map each character on its own (Alpha,
+// Numeric, RemoveNonASCII, ...), sanitizers that look at tokens or markup (HTML, XSS, Email)
+// can give a different result when a token is split across chunks.
+//
+//	View examples: funcs_test.go
+func Large(input string, fn SanitizeFunc, chunkSize int) string {
+	if chunkSize <= 0 {
+		chunkSize = defaultChunkSize
+	}
+	if len(input) <= chunkSize {
+		return fn(input)
+	}
+
+	var chunks []string
+	for len(input) > 0 {
+		end := chunkSize
+		if end >= len(input) {
+			end = len(input)
+		} else {
+			for end > 0 && !utf8.RuneStart(input[end]) {
+				end--
+			}
+			if end == 0 { // A chunk smaller than a rune
+				for end = 1; end < len(input) && !utf8.RuneStart(input[end]); end++ {
+				}
+			}
+		}
+		chunks = append(chunks, input[:end])
+		input = input[end:]
+	}
+
+	results := make([]string, len(chunks))
+	limit := make(chan struct{}, runtime.GOMAXPROCS(0))
+	var wg sync.WaitGroup
+	for i := range chunks {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+			results[i] = fn(chunks[i])
+		}(i)
+	}
+	wg.Wait()
+	return strings.Join(results, "")
+}
@@ -0,0 +1,353 @@
+package sanitize
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFuncs tests the built-in SanitizeFunc values
+func TestFuncs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all set", func(t *testing.T) {
+		value := reflect.ValueOf(Funcs)
+		for i := 0; i < value.NumField(); i++ {
+			assert.False(t, value.Field(i).IsNil(), value.Type().Field(i).Name)
+		}
+	})
+
+	var tests = []struct {
+		name     string
+		fn       SanitizeFunc
+		input    string
+		expected string
+	}{
+		{"ASCII", Funcs.ASCII, "Crème", "Creme"},
+		{"Alpha", Funcs.Alpha, "Test This 123", "TestThis"},
+		{"AlphaWithSpaces", Funcs.AlphaWithSpaces, "Test This 123", "Test This "},
+		{"AlphaNumeric", Funcs.AlphaNumeric, "Test This 123!", "TestThis123"},
+		{"AlphaNumericWithSpaces", Funcs.AlphaNumericWithSpaces, "Test This 123!", "Test This 123"},
+		{"Domain", Funcs.Domain, "https://www.Example.COM/path", "www.example.com"},
+		{"Domain error", Funcs.Domain, "http://[::1", ""},
+		{"Email", Funcs.Email, "mailto:Person@Example.COM", "person@example.com"},
+		{"EmailPreserveCase", Funcs.EmailPreserveCase, "Person@Example.COM", "Person@Example.COM"},
+		{"HTML", Funcs.HTML, "<b>Bold</b>", "Bold"},
+		{"Numeric", Funcs.Numeric, "(555) 123-4567", "5551234567"},
+		{"SnakeCase", Funcs.SnakeCase, "Test This", "test_this"},
+		{"XSS", Funcs.XSS, "<script>alert(1)</script>", ">alert(1)</"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.fn(test.input))
+		})
+	}
+}
+
+// TestPresets tests the Presets sanitizers
+func TestPresets(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all set", func(t *testing.T) {
+		value := reflect.ValueOf(Presets)
+		for i := 0; i < value.NumField(); i++ {
+			assert.False(t, value.Field(i).IsNil(), value.Type().Field(i).Name)
+		}
+	})
+
+	var tests = []struct {
+		name     string
+		fn       SanitizeFunc
+		input    string
+		expected string
+	}{
+		{"DBSafe", Presets.DBSafe, "name\x00\x07\tvalue\r\n", "name\tvalue\r\n"},
+		{"DBSafe invalid utf-8", Presets.DBSafe, "caf\xe9 au lait", "caf au lait"},
+		{"DisplaySafe", Presets.DisplaySafe, " <b>Hello</b>\x1b\n\u202eworld ", "Hello\nworld"},
+		{"DisplaySafe hidden tag", Presets.DisplaySafe, "<\u202eimg src=x onerror=alert(1)>Hi", "Hi"},
+		{"DisplaySafe hidden escape", Presets.DisplaySafe, "<\x1b[0mscript>x", "x"},
+		{"FormInput", Presets.FormInput, "  John \r\n <script>Smith\x00 ", "John Smith"},
+		{"FormInput bidi", Presets.FormInput, "invoice\u202egpj.exe", "invoicegpj.exe"},
+		{"LogSafe", Presets.LogSafe, "login failed\nuser=bob password=hunter2", "login failed user=bob password=[REDACTED]"},
+		{"LogSafe escapes", Presets.LogSafe, "\x1b[31mred\x1b[0m\r", "red"},
+		{"LogSafe hyperlink", Presets.LogSafe, "\x1b]8;;https://evil.com\x1b\\click\x1b]8;;\x1b\\", "click"},
+		{"LogSafe title", Presets.LogSafe, "\x1b]0;pwned\atext\u009b2J", "text"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.fn(test.input))
+		})
+	}
+
+	t.Run("idempotent", func(t *testing.T) {
+		value := reflect.ValueOf(Presets)
+		for i := 0; i < value.NumField(); i++ {
+			fn := value.Field(i).Interface().(SanitizeFunc)
+			assert.True(t, IsIdempotent(fn, idempotencySamples), value.Type().Field(i).Name)
+		}
+	})
+}
+
+// BenchmarkPresets_FormInput benchmarks the FormInput preset
+func BenchmarkPresets_FormInput(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Presets.FormInput("  John \r\n <script>Smith ")
+	}
+}
+
+// ExamplePresets example using Presets
+func ExamplePresets() {
+	fmt.Println(Presets.FormInput("  John \r\n <script>Smith "))
+	fmt.Println(Presets.LogSafe("login failed\npassword=hunter2"))
+	// Output:
+	// John Smith
+	// login failed password=[REDACTED]
+}
+
+// TestFuncsByName tests the FuncsByName method
+func TestFuncsByName(t *testing.T) {
+	t.Parallel()
+
+	fns := FuncsByName()
+	assert.Len(t, fns, reflect.TypeOf(Funcs).NumField()+reflect.TypeOf(Presets).NumField()+3)
+	for name, fn := range fns {
+		assert.Equal(t, strings.ToLower(name), name)
+		assert.NotNil(t, fn, name)
+	}
+	assert.Equal(t, "bob@example.com", fns["email"]("Bob@Example.com"))
+	assert.Equal(t, "John Smith", fns["forminput"]("  John \r\n <script>Smith "))
+	assert.Equal(t, "ABC", fns["upper"]("abc"))
+
+	t.Run("new map", func(t *testing.T) {
+		delete(fns, "email")
+		assert.Contains(t, FuncsByName(), "email")
+	})
+}
+
+// ExampleFuncsByName example using FuncsByName()
+func ExampleFuncsByName() {
+	fns := FuncsByName()
+	fmt.Println(Chain(fns["trim"], fns["email"])("  Bob@Example.COM "))
+	// Output: bob@example.com
+}
+
+// TestChain tests the Chain method
+func TestChain(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs in order", func(t *testing.T) {
+		fn := Chain(Funcs.HTML, strings.TrimSpace, Funcs.TitleCase)
+		assert.Equal(t, "Hello World", fn("  <b>hello</b> world "))
+	})
+
+	t.Run("empty chain", func(t *testing.T) {
+		assert.Equal(t, "same", Chain()("same"))
+	})
+
+	t.Run("nil functions skipped", func(t *testing.T) {
+		assert.Equal(t, "abc", Chain(nil, Funcs.Alpha, nil)("a1b2c3"))
+	})
+
+	t.Run("decorator", func(t *testing.T) {
+		var calls int
+		counted := func(fn SanitizeFunc) SanitizeFunc {
+			return func(s string) string {
+				calls++
+				return fn(s)
+			}
+		}
+		fn := Chain(counted(Funcs.Numeric), counted(Funcs.Numeric))
+		assert.Equal(t, "123", fn("a1b2c3"))
+		assert.Equal(t, 2, calls)
+	})
+}
+
+// BenchmarkChain benchmarks the Chain method
+func BenchmarkChain(b *testing.B) {
+	fn := Chain(Funcs.HTML, Funcs.SingleLine, Funcs.XSS)
+	for i := 0; i < b.N; i++ {
+		_ = fn("<b>This is\nthe test string.</b>")
+	}
+}
+
+// ExampleFuncs example using a table of SanitizeFunc values
+func ExampleFuncs() {
+	fields := map[string]SanitizeFunc{
+		"email": Funcs.Email,
+		"phone": Funcs.Numeric,
+	}
+	fmt.Println(fields["email"]("Person@Example.COM"), fields["phone"]("(555) 123-4567"))
+	// Output: person@example.com 5551234567
+}
+
+// ExampleChain example using Chain()
+func ExampleChain() {
+	clean := Chain(Funcs.HTML, Funcs.SingleLine, strings.TrimSpace)
+	fmt.Println(clean(" <p>Line one\nLine two</p> "))
+	// Output: Line one Line two
+}
+
+// TestWhen tests the When method
+func TestWhen(t *testing.T) {
+	t.Parallel()
+
+	hasMarkup := func(s string) bool { return strings.ContainsAny(s, "<&") }
+
+	var tests = []struct {
+		name      string
+		predicate func(string) bool
+		fn        SanitizeFunc
+		input     string
+		expected  string
+	}{
+		{"predicate true", hasMarkup, Funcs.HTML, "<b>bold</b>", "bold"},
+		{"predicate false", hasMarkup, Funcs.Alpha, "plain text 123", "plain text 123"},
+		{"nil predicate", nil, Funcs.Alpha, "a1", "a1"},
+		{"nil fn", hasMarkup, nil, "<b>", "<b>"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, When(test.predicate, test.fn)(test.input))
+		})
+	}
+
+	t.Run("skips the call", func(t *testing.T) {
+		var calls int
+		fn := When(hasMarkup, func(s string) string {
+			calls++
+			return HTML(s)
+		})
+		_ = fn("no markup")
+		_ = fn("<i>markup</i>")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+// TestUnless tests the Unless method
+func TestUnless(t *testing.T) {
+	t.Parallel()
+
+	isASCII := func(s string) bool { return RemoveNonASCII(s) == s }
+
+	var tests = []struct {
+		name      string
+		predicate func(string) bool
+		fn        SanitizeFunc
+		input     string
+		expected  string
+	}{
+		{"predicate true", isASCII, Funcs.ASCII, "plain", "plain"},
+		{"predicate false", isASCII, Funcs.ASCII, "café", "cafe"},
+		{"nil predicate", nil, Funcs.Numeric, "a1", "1"},
+		{"nil fn", isASCII, nil, "café", "café"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Unless(test.predicate, test.fn)(test.input))
+		})
+	}
+}
+
+// BenchmarkWhen benchmarks the When method with input that skips the sanitizer
+func BenchmarkWhen(b *testing.B) {
+	fn := When(func(s string) bool { return strings.ContainsAny(s, "<&") }, Funcs.HTML)
+	for i := 0; i < b.N; i++ {
+		_ = fn("This is the test string without any markup.")
+	}
+}
+
+// ExampleWhen example using When() to only run HTML on input with markup
+func ExampleWhen() {
+	clean := Chain(When(func(s string) bool { return strings.Contains(s, "<") }, Funcs.HTML), strings.TrimSpace)
+	fmt.Println(clean(" <b>bold</b> "))
+	fmt.Println(clean(" 1 < 2 "))
+	// Output:
+	// bold
+	// 1 < 2
+}
+
+// ExampleUnless example using Unless() to skip numbers that are already clean
+func ExampleUnless() {
+	isDigits := func(s string) bool { return Numeric(s) == s }
+	fmt.Println(Unless(isDigits, Funcs.Numeric)("(555) 123-4567"))
+	// Output: 5551234567
+}
+
+// TestLarge tests the Large method
+func TestLarge(t *testing.T) {
+	t.Parallel()
+
+	text := strings.Repeat("Crème brûlée 123, ça va? \U0001F600 ", 5000)
+
+	var tests = []struct {
+		name      string
+		fn        SanitizeFunc
+		chunkSize int
+	}{
+		{"alpha", Funcs.Alpha, 1000},
+		{"numeric", Funcs.Numeric, 4096},
+		{"remove non-ascii", Funcs.RemoveNonASCII, 777},
+		{"upper", strings.ToUpper, 3},
+		{"tiny chunks", strings.ToUpper, 1},
+		{"default chunk", Funcs.AlphaWithSpaces, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.fn(text), Large(text, test.fn, test.chunkSize))
+		})
+	}
+
+	t.Run("single chunk", func(t *testing.T) {
+		calls := 0
+		fn := func(s string) string { calls++; return s }
+		assert.Equal(t, "abc", Large("abc", fn, 3))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Equal(t, "", Large("", Funcs.Alpha, 10))
+	})
+
+	t.Run("runes are not split", func(t *testing.T) {
+		var mu sync.Mutex
+		fn := func(s string) string {
+			mu.Lock()
+			defer mu.Unlock()
+			assert.True(t, utf8.ValidString(s), s)
+			return s
+		}
+		assert.Equal(t, text, Large(text, fn, 5))
+	})
+}
+
+// BenchmarkLarge benchmarks the Large method
+func BenchmarkLarge(b *testing.B) {
+	text := strings.Repeat("Crème brûlée 123, ça va? ", 200000)
+	for i := 0; i < b.N; i++ {
+		_ = Large(text, Funcs.RemoveNonASCII, 0)
+	}
+}
+
+// BenchmarkLarge_SingleCore benchmarks the sanitizer Large is compared to
+func BenchmarkLarge_SingleCore(b *testing.B) {
+	text := strings.Repeat("Crème brûlée 123, ça va? ", 200000)
+	for i := 0; i < b.N; i++ {
+		_ = Funcs.RemoveNonASCII(text)
+	}
+}
+
+// ExampleLarge example using Large()
+func ExampleLarge() {
+	fmt.Println(Large("Order #123 for 4 items", Funcs.Numeric, 4))
+	// Output: 1234
+}
@@ -2,7 +2,10 @@ module github.com/mrz1836/go-sanitize
 
 go 1.18
 
-require github.com/stretchr/testify v1.10.0
+require (
+	github.com/stretchr/testify v1.10.0
+	golang.org/x/text v0.16.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -0,0 +1,77 @@
+package sanitize
+
+import (
+	"strconv"
+	"strings"
+)
+
+// GraphQLString escapes a value for use inside a double quoted GraphQL string literal
+// ("..."), so user input can not end the string and change the document. Double quotes and
+// backslashes are escaped, control characters are written as \n, \t, \r, \b, \f or \uXXXX
+// and invalid UTF-8 is removed. The quotes around the string are not added. Use variables
+// instead of building documents from user input where possible.
+//
+//	View examples: graphql_test.go
+func GraphQLString(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+
+	return o.after(escapeBasicString(original))
+}
+
+// GraphQLName returns a valid GraphQL name ([_A-Za-z][_0-9A-Za-z]*) for a field, argument,
+// alias, type or operation name. Other characters are removed, a leading digit is prefixed
+// with an underscore and a leading double underscore (reserved for introspection) is reduced
+// to a single underscore. An empty string is returned if there are no valid characters.
+//
+//	View examples: graphql_test.go
+func GraphQLName(original string) string {
+	var b strings.Builder
+	b.Grow(len(original) + 1)
+	for i := 0; i < len(original); i++ {
+		if c := original[i]; isASCIILetter(c) || (c >= '0' && c <= '9') || c == '_' {
+			b.WriteByte(c)
+		}
+	}
+	name := b.String()
+	for strings.HasPrefix(name, "__") {
+		name = name[1:]
+	}
+	if len(name) > 0 && name[0] >= '0' && name[0] <= '9' {
+		return "_" + name
+	}
+	return name
+}
+
+// escapeBasicString escapes the value for a double quoted string in the JSON-like syntax of
+// GraphQL and TOML, invalid UTF-8 is removed
+func escapeBasicString(value string) string {
+	var b strings.Builder
+	b.Grow(len(value))
+	for _, r := range strings.ToValidUTF8(value, "") {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\b':
+			b.WriteString(`\b`)
+		case r == '\f':
+			b.WriteString(`\f`)
+		case r < ' ' || r == '\u007f':
+			b.WriteString(`\u00`)
+			if r < 0x10 {
+				b.WriteByte('0')
+			}
+			b.WriteString(strconv.FormatInt(int64(r), 16))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
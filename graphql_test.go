@@ -0,0 +1,88 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGraphQLString tests the GraphQLString method
+func TestGraphQLString(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"plain", "hello world", "hello world"},
+		{"quotes", `say "hi"`, `say \"hi\"`},
+		{"backslash", `a\b`, `a\\b`},
+		{"injection", `x") { admin { token } } #`, `x\") { admin { token } } #`},
+		{"new lines", "a\nb\r\n", `a\nb\r\n`},
+		{"tab", "a\tb", `a\tb`},
+		{"control characters", "a\x00b\x1b\x7f\b\f", `a\u0000b\u001b\u007f\b\f`},
+		{"unicode", "café ✓", "café ✓"},
+		{"invalid utf-8", "caf\xe9", "caf"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, GraphQLString(test.input))
+		})
+	}
+}
+
+// BenchmarkGraphQLString benchmarks the GraphQLString method
+func BenchmarkGraphQLString(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = GraphQLString(`x") { admin { token } } #`)
+	}
+}
+
+// ExampleGraphQLString example using GraphQLString()
+func ExampleGraphQLString() {
+	fmt.Printf("{ user(name: \"%s\") { id } }\n", GraphQLString(`bob") { id } admin(id: "1`))
+	// Output: { user(name: "bob\") { id } admin(id: \"1") { id } }
+}
+
+// TestGraphQLName tests the GraphQLName method
+func TestGraphQLName(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"valid", "userName", "userName"},
+		{"underscore", "_private", "_private"},
+		{"invalid characters", "user-name { admin }", "usernameadmin"},
+		{"leading digit", "1st", "_1st"},
+		{"introspection", "__schema", "_schema"},
+		{"unicode", "café", "caf"},
+		{"no valid characters", "{}!", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, GraphQLName(test.input))
+		})
+	}
+}
+
+// BenchmarkGraphQLName benchmarks the GraphQLName method
+func BenchmarkGraphQLName(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = GraphQLName("user-name { admin }")
+	}
+}
+
+// ExampleGraphQLName example using GraphQLName()
+func ExampleGraphQLName() {
+	fmt.Println(GraphQLName("user-name { __schema }"))
+	// Output: username__schema
+}
@@ -0,0 +1,20 @@
+module github.com/mrz1836/go-sanitize/grpcsanitize
+
+go 1.21
+
+require (
+	github.com/mrz1836/go-sanitize v0.0.0-20261016013506-a30fd47ce9dc
+	github.com/stretchr/testify v1.10.0
+	google.golang.org/grpc v1.66.3
+	google.golang.org/protobuf v1.34.2
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240604185151-ef581f913117 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,204 @@
+// Package grpcsanitize provides gRPC server interceptors that sanitize the string fields of
+// incoming protobuf messages with go-sanitize, the gRPC equivalent of an HTTP middleware.
+// The sanitizer of a field is found in a Registry keyed by the full name of the field
+// (package.Message.field) or read from a custom field option (see OptionResolver).
+package grpcsanitize
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mrz1836/go-sanitize"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Resolver returns the sanitizer for a string field or nil to leave the field unchanged
+type Resolver func(field protoreflect.FieldDescriptor) sanitize.SanitizeFunc
+
+// Registry maps string fields to their sanitizers, it is safe for concurrent use
+type Registry struct {
+	fields   map[protoreflect.FullName]sanitize.SanitizeFunc
+	mu       sync.RWMutex
+	resolver Resolver
+}
+
+// NewRegistry returns an empty registry, fields without a sanitizer are left unchanged
+func NewRegistry() *Registry {
+	return &Registry{fields: make(map[protoreflect.FullName]sanitize.SanitizeFunc)}
+}
+
+// Register sets the sanitizer of the field with the full name ("acme.v1.User.name"), the
+// field can be a string, a repeated string or a map with string values
+func (r *Registry) Register(field protoreflect.FullName, fn sanitize.SanitizeFunc) *Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.fields[field] = fn
+	return r
+}
+
+// SetResolver sets the resolver used for the fields that are not registered by name, such
+// as a resolver that reads a field option (see OptionResolver)
+func (r *Registry) SetResolver(resolver Resolver) *Registry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.resolver = resolver
+	return r
+}
+
+// lookup returns the sanitizer of the field, the registered name takes precedence over the resolver
+func (r *Registry) lookup(field protoreflect.FieldDescriptor) sanitize.SanitizeFunc {
+	r.mu.RLock()
+	fn, ok := r.fields[field.FullName()]
+	resolver := r.resolver
+	r.mu.RUnlock()
+	if !ok && resolver != nil {
+		fn = resolver(field)
+	}
+	return fn
+}
+
+// OptionResolver returns a resolver that reads the name of the sanitizer from a string field
+// option and looks it up in funcs (such as sanitize.FuncsByName()), for example with this
+// extension of google.protobuf.FieldOptions:
+//
+//	extend google.protobuf.FieldOptions { string sanitize = 50000; }
+//	message User { string bio = 1 [(sanitize) = "html"]; }
+//
+// Fields without the option or with an unknown name are left unchanged.
+func OptionResolver(option protoreflect.ExtensionType, funcs map[string]sanitize.SanitizeFunc) Resolver {
+	return func(field protoreflect.FieldDescriptor) sanitize.SanitizeFunc {
+		options := field.Options()
+		if options == nil || !proto.HasExtension(options, option) {
+			return nil
+		}
+		name, _ := proto.GetExtension(options, option).(string)
+		return funcs[name]
+	}
+}
+
+// Sanitize sanitizes the string fields of the message in place, including the fields of
+// nested messages, repeated fields and map values (map keys are left unchanged)
+func (r *Registry) Sanitize(msg proto.Message) {
+	if msg == nil {
+		return
+	}
+	r.sanitizeMessage(msg.ProtoReflect())
+}
+
+// sanitizeMessage walks the populated fields of the message
+func (r *Registry) sanitizeMessage(m protoreflect.Message) {
+	if !m.IsValid() {
+		return
+	}
+
+	// The fields are collected first, the message must not be changed while ranging over it
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(field protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, field)
+		return true
+	})
+
+	for _, field := range fields {
+		switch {
+		case field.IsMap():
+			r.sanitizeMap(m.Mutable(field).Map(), field)
+		case field.IsList():
+			r.sanitizeList(m.Mutable(field).List(), field)
+		case field.Kind() == protoreflect.StringKind:
+			if fn := r.lookup(field); fn != nil {
+				m.Set(field, protoreflect.ValueOfString(fn(m.Get(field).String())))
+			}
+		case isMessage(field):
+			r.sanitizeMessage(m.Mutable(field).Message())
+		}
+	}
+}
+
+// sanitizeList sanitizes the strings or messages of a repeated field
+func (r *Registry) sanitizeList(list protoreflect.List, field protoreflect.FieldDescriptor) {
+	if isMessage(field) {
+		for i := 0; i < list.Len(); i++ {
+			r.sanitizeMessage(list.Get(i).Message())
+		}
+		return
+	}
+	if field.Kind() != protoreflect.StringKind {
+		return
+	}
+	if fn := r.lookup(field); fn != nil {
+		for i := 0; i < list.Len(); i++ {
+			list.Set(i, protoreflect.ValueOfString(fn(list.Get(i).String())))
+		}
+	}
+}
+
+// sanitizeMap sanitizes the string or message values of a map field
+func (r *Registry) sanitizeMap(values protoreflect.Map, field protoreflect.FieldDescriptor) {
+	value := field.MapValue()
+	var fn sanitize.SanitizeFunc
+	switch {
+	case isMessage(value):
+	case value.Kind() == protoreflect.StringKind:
+		if fn = r.lookup(field); fn == nil {
+			return
+		}
+	default:
+		return
+	}
+
+	var keys []protoreflect.MapKey
+	values.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+		keys = append(keys, key)
+		return true
+	})
+	for _, key := range keys {
+		if fn == nil {
+			r.sanitizeMessage(values.Get(key).Message())
+		} else {
+			values.Set(key, protoreflect.ValueOfString(fn(values.Get(key).String())))
+		}
+	}
+}
+
+// isMessage returns true for message and group fields
+func isMessage(field protoreflect.FieldDescriptor) bool {
+	return field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind
+}
+
+// UnaryServerInterceptor returns an interceptor that sanitizes the request message before
+// it is passed to the handler
+func UnaryServerInterceptor(r *Registry) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if msg, ok := req.(proto.Message); ok {
+			r.Sanitize(msg)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns an interceptor that sanitizes every message received on
+// the stream (client and bidirectional streaming)
+func StreamServerInterceptor(r *Registry) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: stream, registry: r})
+	}
+}
+
+// serverStream sanitizes the messages received on the wrapped stream
+type serverStream struct {
+	grpc.ServerStream
+	registry *Registry
+}
+
+// RecvMsg receives the next message and sanitizes it
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		s.registry.Sanitize(msg)
+	}
+	return nil
+}
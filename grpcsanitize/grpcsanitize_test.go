@@ -0,0 +1,290 @@
+package grpcsanitize
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mrz1836/go-sanitize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// testDescriptors builds the test messages without generated code, this is the equivalent of:
+//
+//	extend google.protobuf.FieldOptions { string sanitize = 50000; }
+//	message Address { string city = 1; }
+//	message User {
+//	  string name = 1;
+//	  repeated string tags = 2;
+//	  map<string, string> labels = 3;
+//	  Address address = 4;
+//	  repeated Address previous = 5;
+//	  map<string, Address> places = 6;
+//	  string bio = 7 [(sanitize) = "html"];
+//	  int32 age = 8;
+//	  string note = 9;
+//	  string about = 10 [(sanitize) = "Unknown"];
+//	}
+func testDescriptors(t testing.TB) (protoreflect.MessageDescriptor, protoreflect.ExtensionType) {
+	optionFile, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:       proto.String("acme/v1/sanitize.proto"),
+		Package:    proto.String("acme.v1"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/descriptor.proto"},
+		Extension: []*descriptorpb.FieldDescriptorProto{{
+			Name:     proto.String("sanitize"),
+			Number:   proto.Int32(50000),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(),
+			JsonName: proto.String("sanitize"),
+			Extendee: proto.String(".google.protobuf.FieldOptions"),
+		}},
+	}, protoregistry.GlobalFiles)
+	require.NoError(t, err)
+	option := dynamicpb.NewExtensionType(optionFile.Extensions().Get(0))
+
+	withOption := func(name string) *descriptorpb.FieldOptions {
+		options := &descriptorpb.FieldOptions{}
+		proto.SetExtension(options, option, name)
+		return options
+	}
+	field := func(name string, number int32, kind descriptorpb.FieldDescriptorProto_Type,
+		repeated bool, typeName string, options *descriptorpb.FieldOptions,
+	) *descriptorpb.FieldDescriptorProto {
+		label := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+		if repeated {
+			label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+		}
+		f := &descriptorpb.FieldDescriptorProto{
+			Name: proto.String(name), Number: proto.Int32(number), Label: label.Enum(), Type: kind.Enum(),
+			JsonName: proto.String(name), Options: options,
+		}
+		if len(typeName) > 0 {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	entry := func(name string, value *descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{
+			Name: proto.String(name),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, false, "", nil), value,
+			},
+			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		}
+	}
+
+	const (
+		typeString  = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		typeMessage = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+		typeInt32   = descriptorpb.FieldDescriptorProto_TYPE_INT32
+	)
+	file, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("acme/v1/user.proto"),
+		Package: proto.String("acme.v1"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name:  proto.String("Address"),
+				Field: []*descriptorpb.FieldDescriptorProto{field("city", 1, typeString, false, "", nil)},
+			},
+			{
+				Name: proto.String("User"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("name", 1, typeString, false, "", nil),
+					field("tags", 2, typeString, true, "", nil),
+					field("labels", 3, typeMessage, true, ".acme.v1.User.LabelsEntry", nil),
+					field("address", 4, typeMessage, false, ".acme.v1.Address", nil),
+					field("previous", 5, typeMessage, true, ".acme.v1.Address", nil),
+					field("places", 6, typeMessage, true, ".acme.v1.User.PlacesEntry", nil),
+					field("bio", 7, typeString, false, "", withOption("html")),
+					field("age", 8, typeInt32, false, "", nil),
+					field("note", 9, typeString, false, "", nil),
+					field("about", 10, typeString, false, "", withOption("Unknown")),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{
+					entry("LabelsEntry", field("value", 2, typeString, false, "", nil)),
+					entry("PlacesEntry", field("value", 2, typeMessage, false, ".acme.v1.Address", nil)),
+				},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+	return file.Messages().ByName("User"), option
+}
+
+// testRegistry returns a registry with the test fields and the option resolver
+func testRegistry(option protoreflect.ExtensionType) *Registry {
+	upper := sanitize.SanitizeFunc(strings.ToUpper)
+	return NewRegistry().
+		Register("acme.v1.User.name", upper).
+		Register("acme.v1.User.tags", upper).
+		Register("acme.v1.User.labels", upper).
+		Register("acme.v1.Address.city", upper).
+		SetResolver(OptionResolver(option, sanitize.FuncsByName()))
+}
+
+// testUser returns a User message decoded from the JSON
+func testUser(t testing.TB, user protoreflect.MessageDescriptor, input string) proto.Message {
+	msg := dynamicpb.NewMessage(user)
+	require.NoError(t, protojson.Unmarshal([]byte(input), msg))
+	return msg
+}
+
+// TestRegistry_Sanitize tests the Sanitize method
+func TestRegistry_Sanitize(t *testing.T) {
+	t.Parallel()
+
+	user, option := testDescriptors(t)
+	registry := testRegistry(option)
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", `{}`, `{}`},
+		{"string", `{"name": "john"}`, `{"name": "JOHN"}`},
+		{"repeated string", `{"tags": ["a", "b"]}`, `{"tags": ["A", "B"]}`},
+		{"map values", `{"labels": {"key": "value"}}`, `{"labels": {"key": "VALUE"}}`},
+		{"nested message", `{"address": {"city": "paris"}}`, `{"address": {"city": "PARIS"}}`},
+		{"repeated message", `{"previous": [{"city": "rome"}, {}]}`, `{"previous": [{"city": "ROME"}, {}]}`},
+		{"map message values", `{"places": {"home": {"city": "oslo"}}}`, `{"places": {"home": {"city": "OSLO"}}}`},
+		{"field option", `{"bio": "<b>hi</b>"}`, `{"bio": "hi"}`},
+		{"unknown option", `{"about": "<b>hi</b>"}`, `{"about": "<b>hi</b>"}`},
+		{"not registered", `{"note": "keep", "age": 42}`, `{"note": "keep", "age": 42}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := testUser(t, user, test.input)
+			registry.Sanitize(msg)
+			expected := testUser(t, user, test.expected)
+			assert.True(t, proto.Equal(expected, msg), "got %v", msg)
+		})
+	}
+
+	t.Run("registered name takes precedence over the option", func(t *testing.T) {
+		msg := testUser(t, user, `{"bio": "<b>hi</b>"}`)
+		testRegistry(option).Register("acme.v1.User.bio", strings.ToUpper).Sanitize(msg)
+		assert.True(t, proto.Equal(testUser(t, user, `{"bio": "<B>HI</B>"}`), msg), "got %v", msg)
+	})
+
+	t.Run("generated message", func(t *testing.T) {
+		msg := wrapperspb.String("<i>x</i>")
+		NewRegistry().Register("google.protobuf.StringValue.value", sanitize.Funcs.HTML).Sanitize(msg)
+		assert.Equal(t, "x", msg.GetValue())
+	})
+
+	t.Run("nil message", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			registry.Sanitize(nil)
+			registry.Sanitize((*wrapperspb.StringValue)(nil))
+		})
+	})
+}
+
+// TestUnaryServerInterceptor tests the UnaryServerInterceptor method
+func TestUnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	interceptor := UnaryServerInterceptor(
+		NewRegistry().Register("google.protobuf.StringValue.value", strings.ToUpper),
+	)
+
+	t.Run("request is sanitized", func(t *testing.T) {
+		var received string
+		_, err := interceptor(context.Background(), wrapperspb.String("abc"), &grpc.UnaryServerInfo{},
+			func(_ context.Context, req any) (any, error) {
+				received = req.(*wrapperspb.StringValue).GetValue()
+				return req, nil
+			})
+		require.NoError(t, err)
+		assert.Equal(t, "ABC", received)
+	})
+
+	t.Run("not a proto message", func(t *testing.T) {
+		output, err := interceptor(context.Background(), "abc", &grpc.UnaryServerInfo{},
+			func(_ context.Context, req any) (any, error) { return req, nil })
+		require.NoError(t, err)
+		assert.Equal(t, "abc", output)
+	})
+}
+
+// testStream is a server stream that receives the messages
+type testStream struct {
+	grpc.ServerStream
+	messages []proto.Message
+}
+
+// RecvMsg receives the next message or io.EOF
+func (s *testStream) RecvMsg(m any) error {
+	if len(s.messages) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(proto.Message), s.messages[0])
+	s.messages = s.messages[1:]
+	return nil
+}
+
+// TestStreamServerInterceptor tests the StreamServerInterceptor method
+func TestStreamServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	interceptor := StreamServerInterceptor(
+		NewRegistry().Register("google.protobuf.StringValue.value", strings.ToUpper),
+	)
+	stream := &testStream{messages: []proto.Message{wrapperspb.String("a"), wrapperspb.String("b")}}
+
+	var received []string
+	err := interceptor(nil, stream, &grpc.StreamServerInfo{}, func(_ any, stream grpc.ServerStream) error {
+		for {
+			msg := &wrapperspb.StringValue{}
+			if err := stream.RecvMsg(msg); err != nil {
+				return err
+			}
+			received = append(received, msg.GetValue())
+		}
+	})
+	require.ErrorIs(t, err, io.EOF)
+	assert.Equal(t, []string{"A", "B"}, received)
+}
+
+// BenchmarkRegistry_Sanitize benchmarks the Sanitize method
+func BenchmarkRegistry_Sanitize(b *testing.B) {
+	user, option := testDescriptors(b)
+	registry := testRegistry(option)
+	msg := testUser(b, user, `{"name": "john", "tags": ["a"], "address": {"city": "paris"}, "bio": "<b>hi</b>"}`)
+	for i := 0; i < b.N; i++ {
+		registry.Sanitize(msg)
+	}
+}
+
+// ExampleUnaryServerInterceptor example using UnaryServerInterceptor()
+func ExampleUnaryServerInterceptor() {
+	registry := NewRegistry().Register("google.protobuf.StringValue.value", func(s string) string {
+		return sanitize.HTML(s)
+	})
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(registry)),
+		grpc.StreamInterceptor(StreamServerInterceptor(registry)),
+	)
+	defer server.Stop()
+
+	msg := wrapperspb.String("<b>Hello</b> World")
+	registry.Sanitize(msg)
+	fmt.Println(msg.GetValue())
+	// Output: Hello World
+}
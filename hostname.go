@@ -0,0 +1,15 @@
+package sanitize
+
+import "strings"
+
+// Hostname returns the hostname in lowercase (with internationalized labels converted to
+// punycode) or an error if it is not a valid hostname. Each label is validated against the
+// RFC 1123 rules: 1-63 letters, digits or hyphens that do not start or end with a hyphen,
+// and the hostname can not be longer than 253 characters. A trailing dot is removed.
+//
+// Unlike Domain(), the input is not a url and invalid characters are not filtered out.
+//
+//	View examples: hostname_test.go
+func Hostname(original string) (string, error) {
+	return domainToASCII(strings.TrimSpace(original))
+}
@@ -0,0 +1,85 @@
+package sanitize
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHostname tests the Hostname method
+func TestHostname(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected string
+		}{
+			{"single label", "localhost", "localhost"},
+			{"domain", "Example.COM", "example.com"},
+			{"subdomain", "api.v2.example.com", "api.v2.example.com"},
+			{"hyphen inside", "my-host.example.com", "my-host.example.com"},
+			{"leading digit", "1password.com", "1password.com"},
+			{"trailing dot", "example.com.", "example.com"},
+			{"spaces", "  example.com ", "example.com"},
+			{"idn", "Bücher.example", "xn--bcher-kva.example"},
+			{"max label", strings.Repeat("a", 63) + ".com", strings.Repeat("a", 63) + ".com"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := Hostname(test.input)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"leading hyphen", "-foo-.com"},
+			{"trailing hyphen", "foo-.com"},
+			{"empty label", "foo..com"},
+			{"leading dot", ".example.com"},
+			{"long label", strings.Repeat("a", 64) + ".com"},
+			{"long hostname", strings.Repeat(strings.Repeat("a", 60)+".", 5) + "com"},
+			{"underscore", "my_host.com"},
+			{"space", "my host.com"},
+			{"url", "https://example.com"},
+			{"port", "example.com:8080"},
+			{"unicode hyphen", "-bücher.example"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := Hostname(test.input)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidDomain)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkHostname benchmarks the Hostname method
+func BenchmarkHostname(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = Hostname("API.Example.COM")
+	}
+}
+
+// ExampleHostname example using Hostname()
+func ExampleHostname() {
+	_, err := Hostname("-foo-.com")
+	fmt.Println(errors.Is(err, ErrInvalidDomain))
+	// Output: true
+}
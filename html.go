@@ -0,0 +1,597 @@
+package sanitize
+
+import (
+	"html"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// scriptTags are the elements removed by Scripts() by default
+var scriptTags = []string{"embed", "iframe", "object", "script"}
+
+// EmailHTML sanitizes the html body of an email for display. Unlike HTML it keeps the
+// formatting, the tables and the presentational elements and attributes (font, center,
+// bgcolor, cellpadding) that email layouts depend on. Scripts, forms, frames, objects,
+// event handlers, unsafe urls and external stylesheets (link, @import) are removed, inline
+// styles and style elements are cleaned with CSS. Use WithStripTrackingPixels to also
+// remove hidden and 1x1 images.
+//
+//	View examples: html_test.go
+func EmailHTML(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+
+	policy := emailPolicy
+	if o != nil && o.stripTrackingPixels {
+		policy = emailPixelPolicy
+	}
+	return o.after(filterHTML(original, policy, o.report()))
+}
+
+// HTMLToText returns the text of an html document as it would be read. Block elements
+// (p, div, li, tr) start new lines, paragraphs and headings are separated by a blank line,
+// <br> is a line break and table cells are separated by tabs. White space is collapsed
+// like a browser does, except in pre elements. Scripts, styles and other non-text elements
+// are removed with their content and entities are decoded (&amp; => &), so the result is
+// plain text that must be escaped again before it is used as html. Comments and CDATA
+// sections are removed, use WithKeepCDATA to keep the text of CDATA sections as-is.
+//
+//	View examples: html_test.go
+func HTMLToText(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(htmlToText(original, o != nil && o.keepCDATA))
+}
+
+// StripTagsExcept removes all tags except the tags of the given elements (StripTagsExcept(s,
+// "b", "i", "a")), the text of all elements is kept. The attributes of the kept tags are
+// reduced to a safe set (href, src, alt, title, class, width, height and a few others),
+// urls with an unsafe scheme (javascript:) are removed and event handlers are always
+// removed. Elements that can run code or load resources (script, style, iframe, object, form)
+// can not be kept, the raw text elements (script, style) are removed with their content.
+//
+//	View examples: html_test.go
+func StripTagsExcept(original string, keep ...string) string {
+	policy := &htmlPolicy{
+		attrs:    stripSafeAttrs,
+		tags:     make(map[string]bool, len(keep)),
+		urlAttrs: stripURLAttrs,
+	}
+	for _, tag := range keep {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); len(tag) > 0 && !droppedTags[tag] {
+			policy.tags[tag] = true
+		}
+	}
+	return filterHTML(original, policy, nil)
+}
+
+// stripSafeAttrs are the attributes kept by StripTagsExcept
+var stripSafeAttrs = map[string]bool{
+	"align": true, "alt": true, "cite": true, "class": true, "colspan": true, "dir": true,
+	"height": true, "href": true, "lang": true, "rowspan": true, "src": true, "title": true,
+	"width": true,
+}
+
+// stripURLAttrs are the attributes of StripTagsExcept with a url value
+var stripURLAttrs = map[string]bool{"cite": true, "href": true, "src": true}
+
+// removeElements removes the elements (start tag, content and end tag) with the given
+// tag names. Unclosed elements are removed up to the end of the input and nested
+// elements of the same name are tracked so that the whole element is removed. The report
+// callback (optional) is called with each removed element and its attributes.
+//
+// The content of raw text elements (style, title) is markup inside svg and math, so the
+// elements are also removed from it (<svg><style><script>...</script></style></svg>).
+func removeElements(original string, tags []string, report func(Dropped)) string {
+	return removeElementsFrom(original, tags, false, report)
+}
+
+// removeElementsFrom removes the elements (see removeElements), with noRawText the content
+// of raw text elements is tokenized as markup
+func removeElementsFrom(original string, tags []string, noRawText bool, report func(Dropped)) string {
+	if !strings.Contains(original, "<") {
+		return original
+	}
+
+	var w htmlWriter
+	w.b.Grow(len(original))
+
+	z := newHTMLTokenizer(original)
+	z.keepAttrs = report != nil
+	z.noRawText = noRawText
+	skipTag, depth, rawTag := "", 0, false
+	for {
+		token, ok := z.next()
+		if !ok {
+			break
+		}
+		isTag := token.typ == htmlStartTag || token.typ == htmlEndTag
+
+		// Inside a removed element, only track the nesting of the same element
+		if depth > 0 {
+			if isTag && token.data == skipTag {
+				if token.typ == htmlStartTag {
+					depth++
+				} else {
+					depth--
+				}
+			}
+			continue
+		}
+
+		inRawText := rawTag && token.typ == htmlText
+		rawTag = false
+		switch {
+		case inRawText:
+			w.write(removeElementsFrom(token.raw, tags, true, report))
+		case isTag && containsFold(tags, token.data):
+			if token.typ == htmlStartTag && !htmlVoidTags[token.data] {
+				skipTag, depth = token.data, 1
+			}
+			if token.typ == htmlStartTag && report != nil {
+				report(Dropped{Kind: DroppedTag, Value: token.data})
+				reportAttrs(token, report)
+			}
+		default:
+			rawTag = token.typ == htmlStartTag && !noRawText && htmlRawTextTags[token.data]
+			w.write(token.raw)
+		}
+	}
+
+	return w.String()
+}
+
+// stripTags removes all tags, comments, CDATA sections and directives and keeps the text.
+// A "<" that does not start a tag (1 < 2) is kept and an unterminated tag is removed up
+// to the end of the input. The content of raw text elements (script, style) is stripped
+// like any other text so that the result never contains markup. With cdata the content
+// of CDATA sections is kept (without its markup). The report callback (optional) is called
+// with the dangerous elements, event handlers and script protocols.
+func stripTags(original string, cdata bool, report func(Dropped)) string {
+	if !strings.Contains(original, "<") {
+		return original
+	}
+
+	var w htmlWriter
+	w.b.Grow(len(original))
+
+	z := newHTMLTokenizer(original)
+	z.noRawText = true
+	z.keepAttrs = report != nil
+	for {
+		token, ok := z.next()
+		if !ok {
+			break
+		}
+		if token.typ == htmlText {
+			w.write(token.raw)
+		} else if token.typ == htmlCDATA && cdata {
+			w.write(stripTags(token.data, false, report))
+		} else if token.typ == htmlStartTag && report != nil {
+			if droppedTags[token.data] {
+				report(Dropped{Kind: DroppedTag, Value: token.data})
+			}
+			reportAttrs(token, report)
+		}
+	}
+
+	return w.String()
+}
+
+// htmlPolicy is an allow-list of the elements and attributes kept by filterHTML
+type htmlPolicy struct {
+	attrs    map[string]bool            // Attributes kept on the allowed elements
+	drop     func(token htmlToken) bool // Optional check that removes an allowed start tag
+	remove   map[string]bool            // Elements removed along with their content
+	tags     map[string]bool            // Elements kept, the tags of other elements are removed
+	urlAttrs map[string]bool            // Attributes with a url value (unsafe urls are removed)
+}
+
+// filterHTML keeps the elements and attributes allowed by the policy. The tags of other
+// elements are removed and their text is kept, except for the elements the policy removes
+// with their content (and raw text elements that are not allowed). Comments, CDATA sections
+// and directives are removed. The kept tags are rebuilt from their attributes, so the values
+// are always quoted and escaped. Urls are checked with SafeLink, style attributes with
+// StyleAttr and the content of style elements with CSS. The report callback (optional) is
+// called with the dangerous elements, event handlers and script protocols that are removed.
+func filterHTML(original string, policy *htmlPolicy, report func(Dropped)) string {
+	if !strings.Contains(original, "<") {
+		return original
+	}
+
+	var w htmlWriter
+	w.b.Grow(len(original))
+
+	z := newHTMLTokenizer(original)
+	z.keepAttrs = true
+	skipTag, depth, rawTag := "", 0, ""
+	for {
+		token, ok := z.next()
+		if !ok {
+			break
+		}
+		isTag := token.typ == htmlStartTag || token.typ == htmlEndTag
+
+		// Inside a removed element, only track the nesting of the same element
+		if depth > 0 {
+			if isTag && token.data == skipTag {
+				if token.typ == htmlStartTag {
+					depth++
+				} else {
+					depth--
+				}
+			}
+			continue
+		}
+
+		// The content of a kept raw text element can not contain markup
+		inRawText := len(rawTag) > 0 && token.typ == htmlText
+		tag := rawTag
+		rawTag = ""
+
+		switch {
+		case inRawText && tag == "style":
+			w.write(safeStyleText(sanitizeCSS(token.raw, true)))
+		case inRawText:
+			w.write(strings.ReplaceAll(token.raw, "<", "&lt;"))
+		case token.typ == htmlText:
+			w.write(token.raw)
+		case token.typ == htmlStartTag && policy.removes(token.data):
+			if !htmlVoidTags[token.data] {
+				skipTag, depth = token.data, 1
+			}
+			reportTag(token, report)
+		case token.typ == htmlStartTag && policy.tags[token.data] && (policy.drop == nil || !policy.drop(token)):
+			w.write(policy.startTag(token, report))
+			if htmlRawTextTags[token.data] {
+				rawTag = token.data
+			}
+		case token.typ == htmlStartTag:
+			reportTag(token, report)
+		case token.typ == htmlEndTag && policy.tags[token.data]:
+			w.write("</" + token.data + ">")
+		}
+		// Comments, CDATA sections, directives and the other end tags are removed
+	}
+
+	return w.String()
+}
+
+// removes returns true if the element is removed along with its content, raw text elements
+// (script, textarea) are removed unless the policy allows them
+func (p *htmlPolicy) removes(tag string) bool {
+	return p.remove[tag] || (htmlRawTextTags[tag] && !p.tags[tag])
+}
+
+// startTag rebuilds the start tag with the allowed attributes only
+func (p *htmlPolicy) startTag(token htmlToken, report func(Dropped)) string {
+	var b strings.Builder
+	b.WriteString("<" + token.data)
+	for _, attr := range token.attrs {
+		value, ok := p.attrValue(attr)
+		if !ok {
+			if report != nil {
+				reportAttr(attr, report)
+			}
+			continue
+		}
+		b.WriteString(" " + attr.key + `="` + html.EscapeString(value) + `"`)
+	}
+	if token.selfClosing {
+		b.WriteString(" /")
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// attrValue returns the (unescaped) value of an allowed attribute, false is returned if the
+// attribute is not allowed or its value is unsafe
+func (p *htmlPolicy) attrValue(attr htmlAttr) (string, bool) {
+	if !p.attrs[attr.key] {
+		return "", false
+	}
+	value := html.UnescapeString(attr.val)
+	switch {
+	case p.urlAttrs[attr.key]:
+		link, err := SafeLink(value)
+		return link, err == nil
+	case attr.key == "style":
+		value = strings.TrimSpace(sanitizeCSS(value, false))
+		return value, len(value) > 0
+	}
+	return value, true
+}
+
+// reportTag reports a removed start tag if it is a dangerous element and its event handlers
+// and script protocols
+func reportTag(token htmlToken, report func(Dropped)) {
+	if report == nil {
+		return
+	}
+	if droppedTags[token.data] {
+		report(Dropped{Kind: DroppedTag, Value: token.data})
+	}
+	reportAttrs(token, report)
+}
+
+// safeStyleText stops the css from closing the style element (a removed comment can turn
+// <//**/style> into </style>), "</" is escaped as "<\/" which css reads as "</"
+func safeStyleText(css string) string {
+	if !strings.Contains(css, "</") {
+		return css
+	}
+	return strings.ReplaceAll(css, "</", `<\/`)
+}
+
+// emailTags are the elements kept by EmailHTML, including the presentational elements and
+// attributes of the table based layouts used by email
+var emailTags = map[string]bool{
+	"a": true, "abbr": true, "address": true, "article": true, "b": true, "bdi": true, "bdo": true,
+	"big": true, "blockquote": true, "br": true, "caption": true, "center": true, "cite": true,
+	"code": true, "col": true, "colgroup": true, "dd": true, "del": true, "dfn": true, "div": true,
+	"dl": true, "dt": true, "em": true, "figcaption": true, "figure": true, "font": true,
+	"footer": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "i": true, "img": true, "ins": true, "kbd": true, "li": true,
+	"main": true, "mark": true, "ol": true, "p": true, "pre": true, "q": true, "s": true,
+	"samp": true, "section": true, "small": true, "span": true, "strike": true, "strong": true,
+	"style": true, "sub": true, "sup": true, "table": true, "tbody": true, "td": true,
+	"tfoot": true, "th": true, "thead": true, "tr": true, "tt": true, "u": true, "ul": true,
+	"wbr": true,
+}
+
+// emailAttrs are the attributes kept by EmailHTML
+var emailAttrs = map[string]bool{
+	"align": true, "alt": true, "background": true, "bgcolor": true, "border": true,
+	"cellpadding": true, "cellspacing": true, "class": true, "color": true, "cols": true,
+	"colspan": true, "dir": true, "face": true, "height": true, "href": true, "hspace": true,
+	"lang": true, "nowrap": true, "rowspan": true, "scope": true, "size": true, "span": true,
+	"src": true, "start": true, "style": true, "summary": true, "title": true, "type": true,
+	"valign": true, "vspace": true, "width": true,
+}
+
+// emailRemovedTags are the elements removed by EmailHTML along with their content
+var emailRemovedTags = map[string]bool{
+	"applet": true, "button": true, "embed": true, "frame": true, "frameset": true,
+	"iframe": true, "math": true, "object": true, "script": true, "select": true,
+	"svg": true, "template": true, "textarea": true, "title": true,
+}
+
+// emailPolicy is the policy applied by EmailHTML
+var emailPolicy = &htmlPolicy{
+	attrs:    emailAttrs,
+	remove:   emailRemovedTags,
+	tags:     emailTags,
+	urlAttrs: map[string]bool{"background": true, "href": true, "src": true},
+}
+
+// emailPixelPolicy is the policy applied by EmailHTML with WithStripTrackingPixels
+var emailPixelPolicy = &htmlPolicy{
+	attrs:    emailPolicy.attrs,
+	drop:     isTrackingPixel,
+	remove:   emailPolicy.remove,
+	tags:     emailPolicy.tags,
+	urlAttrs: emailPolicy.urlAttrs,
+}
+
+// isTrackingPixel returns true for an image that is hidden or at most 1x1 pixels
+func isTrackingPixel(token htmlToken) bool {
+	if token.data != "img" {
+		return false
+	}
+
+	var width, height string
+	for _, attr := range token.attrs {
+		switch attr.key {
+		case "width":
+			width = attr.val
+		case "height":
+			height = attr.val
+		case "style":
+			for _, declaration := range strings.Split(normalizeCSS(html.UnescapeString(attr.val)), ";") {
+				property, value, _ := strings.Cut(declaration, ":")
+				switch property {
+				case "display":
+					if value == "none" {
+						return true
+					}
+				case "visibility":
+					if value == "hidden" {
+						return true
+					}
+				case "width":
+					width = value
+				case "height":
+					height = value
+				}
+			}
+		}
+	}
+	return isPixelSize(width) && isPixelSize(height)
+}
+
+// isPixelSize returns true if the size is 0 or 1 pixel (1, 1px or 0)
+func isPixelSize(size string) bool {
+	size = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(size)), "px")
+	n, err := strconv.ParseFloat(size, 64)
+	return err == nil && n <= 1
+}
+
+// textBreaks are the number of line breaks around the block elements in HTMLToText
+var textBreaks = map[string]int{
+	"address": 1, "article": 1, "aside": 1, "blockquote": 2, "caption": 1, "dd": 1,
+	"details": 1, "div": 1, "dl": 1, "dt": 1, "fieldset": 1, "figcaption": 1, "figure": 1,
+	"footer": 1, "form": 1, "h1": 2, "h2": 2, "h3": 2, "h4": 2, "h5": 2, "h6": 2,
+	"header": 1, "hr": 1, "li": 1, "main": 1, "nav": 1, "ol": 1, "p": 2, "pre": 2,
+	"section": 1, "summary": 1, "table": 1, "tr": 1, "ul": 1,
+}
+
+// textRemovedTags are the elements removed by HTMLToText along with their content
+var textRemovedTags = map[string]bool{
+	"applet": true, "embed": true, "iframe": true, "noembed": true, "noframes": true,
+	"object": true, "script": true, "select": true, "style": true, "svg": true,
+	"template": true, "title": true,
+}
+
+// htmlToText converts the html to text (see HTMLToText), with cdata the content of CDATA
+// sections is kept as text
+func htmlToText(original string, cdata bool) string {
+	var w textWriter
+	w.b.Grow(len(original))
+
+	z := newHTMLTokenizer(original)
+	skipTag, depth, pre, rawTag := "", 0, 0, ""
+	for {
+		token, ok := z.next()
+		if !ok {
+			break
+		}
+		isTag := token.typ == htmlStartTag || token.typ == htmlEndTag
+
+		// Inside a removed element, only track the nesting of the same element
+		if depth > 0 {
+			if isTag && token.data == skipTag {
+				if token.typ == htmlStartTag {
+					depth++
+				} else {
+					depth--
+				}
+			}
+			continue
+		}
+
+		// The content of the raw text elements that are kept (textarea, xmp) keeps its white space
+		inRawText := len(rawTag) > 0 && token.typ == htmlText
+		rawTag = ""
+
+		switch {
+		case token.typ == htmlText:
+			w.text(token.raw, inRawText || pre > 0)
+		case token.typ == htmlCDATA && cdata:
+			w.write(token.data)
+		case token.typ == htmlStartTag && textRemovedTags[token.data]:
+			if !htmlVoidTags[token.data] {
+				skipTag, depth = token.data, 1
+			}
+		case token.typ == htmlStartTag && htmlRawTextTags[token.data]:
+			rawTag = token.data
+		case isTag && token.data == "br":
+			w.lineBreak()
+		case token.typ == htmlStartTag && (token.data == "td" || token.data == "th"):
+			w.cell()
+		case isTag && textBreaks[token.data] > 0:
+			w.breaks(textBreaks[token.data])
+			if token.data == "pre" && token.typ == htmlStartTag {
+				pre++
+			} else if token.data == "pre" && pre > 0 {
+				pre--
+			}
+		}
+		// Comments, CDATA sections, directives and the other tags are removed
+	}
+
+	// The indentation of the first line is kept (pre)
+	return strings.TrimRight(strings.TrimLeft(w.b.String(), "\n"), " \t\n")
+}
+
+// textWriter writes the text of a document with the white space of a rendered document
+type textWriter struct {
+	b        strings.Builder
+	newlines int  // Number of trailing line breaks
+	space    bool // A space is pending before the next word
+}
+
+// text writes the text, entities are decoded and white space is collapsed unless pre is set
+func (w *textWriter) text(raw string, pre bool) {
+	if pre {
+		w.write(html.UnescapeString(raw))
+		return
+	}
+
+	if len(raw) > 0 && isHTMLSpace(raw[0]) {
+		w.space = true
+	}
+	isSpace := func(r rune) bool { return r < utf8.RuneSelf && isHTMLSpace(byte(r)) }
+	for _, word := range strings.FieldsFunc(raw, isSpace) {
+		w.write(html.UnescapeString(word))
+		w.space = true
+	}
+	if len(raw) > 0 && !isHTMLSpace(raw[len(raw)-1]) {
+		w.space = false
+	}
+}
+
+// write writes the text after the pending space (if any)
+func (w *textWriter) write(text string) {
+	if len(text) == 0 {
+		return
+	}
+	if w.space && w.b.Len() > 0 && w.newlines == 0 {
+		w.b.WriteByte(' ')
+	}
+	w.space = false
+	w.b.WriteString(text)
+	w.newlines = len(text) - len(strings.TrimRight(text, "\n"))
+}
+
+// breaks ends the current line and adds blank lines until there are n line breaks
+func (w *textWriter) breaks(n int) {
+	w.space = false
+	if w.b.Len() == 0 {
+		return
+	}
+	for ; w.newlines < n; w.newlines++ {
+		w.b.WriteByte('\n')
+	}
+}
+
+// lineBreak adds a line break (<br>)
+func (w *textWriter) lineBreak() {
+	w.space = false
+	w.b.WriteByte('\n')
+	w.newlines++
+}
+
+// cell separates a table cell from the previous cell on the same line with a tab
+func (w *textWriter) cell() {
+	w.space = false
+	if w.b.Len() > 0 && w.newlines == 0 {
+		w.b.WriteByte('\t')
+	}
+}
+
+// htmlWriter writes the tokens that are kept from a document. A "<" that was followed by
+// removed markup is dropped if the next text would turn it into new markup (<<b>b> => b>),
+// this keeps the sanitizers idempotent and stops removed tags from re-forming new ones.
+type htmlWriter struct {
+	b       strings.Builder
+	pending int // Number of trailing "<" held back until the next token is known
+}
+
+// write writes the raw token
+func (w *htmlWriter) write(raw string) {
+	if len(raw) == 0 {
+		return
+	}
+	if w.pending > 0 {
+		if !isMarkupStartByte(raw[0]) {
+			w.b.WriteString(strings.Repeat("<", w.pending))
+		}
+		w.pending = 0
+	}
+	end := len(raw)
+	for end > 0 && raw[end-1] == '<' {
+		end--
+	}
+	w.b.WriteString(raw[:end])
+	w.pending = len(raw) - end
+}
+
+// String returns the written document
+func (w *htmlWriter) String() string {
+	if w.pending > 0 {
+		w.b.WriteString(strings.Repeat("<", w.pending))
+		w.pending = 0
+	}
+	return w.b.String()
+}
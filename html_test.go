@@ -0,0 +1,249 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEmailHTML tests the EmailHTML method
+func TestEmailHTML(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sanitize", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected string
+		}{
+			{"empty", "", ""},
+			{"text", "Hello & welcome", "Hello & welcome"},
+			{
+				"table layout",
+				`<table width=600 cellpadding="0" bgcolor='#fff'><tr><td align=center valign="top">` +
+					`<font face="Arial" color="#333">Hi</font></td></tr></table>`,
+				`<table width="600" cellpadding="0" bgcolor="#fff"><tr><td align="center" valign="top">` +
+					`<font face="Arial" color="#333">Hi</font></td></tr></table>`,
+			},
+			{"center", "<center><b>Sale</b><br/></center>", "<center><b>Sale</b><br /></center>"},
+			{"script", "<p>a</p><script>alert(1)</script><p>b</p>", "<p>a</p><p>b</p>"},
+			{"unclosed script", "<p>a</p><script>alert(1)", "<p>a</p>"},
+			{"iframe", `<iframe src="https://evil.com"></iframe>ok`, "ok"},
+			{"title", "<title>Newsletter</title><p>x</p>", "<p>x</p>"},
+			{"form", `<form action="/x">Name <input name="a"><button>Go</button></form>`, "Name "},
+			{"event handler", `<td onclick="steal()" ONMOUSEOVER=x>a</td>`, "<td>a</td>"},
+			{"javascript link", `<a href="javascript:alert(1)">x</a>`, "<a>x</a>"},
+			{"encoded javascript link", `<a href="&#106;avascript:alert(1)">x</a>`, "<a>x</a>"},
+			{"safe link", `<a href="https://example.com/?a=1&amp;b=2">x</a>`,
+				`<a href="https://example.com/?a=1&amp;b=2">x</a>`},
+			{"mailto link", `<a href="mailto:a@example.com">x</a>`, `<a href="mailto:a@example.com">x</a>`},
+			{"data image", `<img src="data:image/png;base64,AAAA">`, "<img>"},
+			{"background", `<td background="javascript:x">a</td>`, "<td>a</td>"},
+			{"unknown attribute", `<p id="main" data-x="1" class="intro">a</p>`, `<p class="intro">a</p>`},
+			{"quotes are escaped", `<p title='a"><script>'>a</p>`, `<p title="a&#34;&gt;&lt;script&gt;">a</p>`},
+			{"inline style", `<p style="color: red; width: expression(alert(1))">a</p>`, `<p style="color: red;">a</p>`},
+			{"unsafe inline style", `<p style="behavior: url(x.htc)">a</p>`, "<p>a</p>"},
+			{"style import", "<style>@import url(https://x/a.css); td { color: red }</style>",
+				"<style> td { color: red }</style>"},
+			{"style end tag", "<style>a{}<//**/style><b>x</b></style>", `<style>a{}<\/style><b>x<\/b></style>`},
+			{"stylesheet link", `<link rel="stylesheet" href="https://x/a.css"><p>a</p>`, "<p>a</p>"},
+			{"document", "<html><head><meta charset=utf-8></head><body><p>a</p></body></html>", "<p>a</p>"},
+			{"comments", "<!--[if mso]><table><![endif]--><p>a</p><!-- b -->", "<p>a</p>"},
+			{"cdata", "<![CDATA[<b>x</b>]]>a", "a"},
+			{"end tag attributes", "<b>a</b onclick=x>", "<b>a</b>"},
+			{"re-forming tag", "<<b>script>alert(1)<</b>/script>", "<<b>script>alert(1)<</b>/script>"},
+			{"svg", `<svg><script>alert(1)</script></svg>a`, "a"},
+			{"tracking pixel kept", `<img src="https://t.example.com/o.gif" width="1" height="1">`,
+				`<img src="https://t.example.com/o.gif" width="1" height="1">`},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output := EmailHTML(test.input)
+				assert.Equal(t, test.expected, output)
+				assert.Equal(t, output, EmailHTML(output), "output should be stable")
+			})
+		}
+	})
+
+	t.Run("tracking pixels", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected string
+		}{
+			{"1x1", `<img src="https://t.example.com/o.gif" width="1" height="1">a`, "a"},
+			{"0x0 px", `<img src="https://t.example.com/o.gif" width="0px" height="0">a`, "a"},
+			{"style size", `<img src="https://t.example.com/o.gif" style="width:1px;height:1px">a`, "a"},
+			{"hidden", `<img src="https://t.example.com/o.gif" style="display: none">a`, "a"},
+			{"invisible", `<img src="https://t.example.com/o.gif" style="visibility:hidden">a`, "a"},
+			{"image", `<img src="https://example.com/logo.png" width="120" height="1">`,
+				`<img src="https://example.com/logo.png" width="120" height="1">`},
+			{"no size", `<img src="https://example.com/logo.png">`, `<img src="https://example.com/logo.png">`},
+			{"other element", `<td width="1" height="1">a</td>`, `<td width="1" height="1">a</td>`},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				assert.Equal(t, test.expected, EmailHTML(test.input, WithStripTrackingPixels()))
+			})
+		}
+	})
+
+	t.Run("dropped", func(t *testing.T) {
+		var dropped []Dropped
+		input := `<script>x</script><form><a href="javascript:x" onclick="y" title="t">a</a></form>`
+		output := EmailHTML(input, WithDropped(func(d Dropped) { dropped = append(dropped, d) }))
+		assert.Equal(t, "<a title=\"t\">a</a>", output)
+		assert.Equal(t, []Dropped{
+			{Kind: DroppedTag, Value: "script"},
+			{Kind: DroppedTag, Value: "form"},
+			{Kind: DroppedProtocol, Value: "javascript"},
+			{Kind: DroppedHandler, Value: "onclick"},
+		}, dropped)
+	})
+}
+
+// BenchmarkEmailHTML benchmarks the EmailHTML method
+func BenchmarkEmailHTML(b *testing.B) {
+	input := `<table width="600"><tr><td align="center" onclick="x()"><font face="Arial">Hello</font>` +
+		`<img src="https://example.com/logo.png" width="120"></td></tr></table><script>alert(1)</script>`
+	for i := 0; i < b.N; i++ {
+		_ = EmailHTML(input)
+	}
+}
+
+// ExampleEmailHTML example using EmailHTML()
+func ExampleEmailHTML() {
+	body := `<table width=600><tr><td bgcolor="#eee" onclick="steal()">Hi!</td></tr></table>` +
+		`<script>alert(1)</script><img src="https://t.example.com/open.gif" width="1" height="1">`
+	fmt.Println(EmailHTML(body, WithStripTrackingPixels()))
+	// Output: <table width="600"><tr><td bgcolor="#eee">Hi!</td></tr></table>
+}
+
+// TestHTMLToText tests the HTMLToText method
+func TestHTMLToText(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"text", "Hello world", "Hello world"},
+		{"collapse white space", "  Hello \n\t world  ", "Hello world"},
+		{"inline elements", "<b>Hello</b> <i>world</i>!", "Hello world!"},
+		{"paragraphs", "<p>One</p><p>Two</p>", "One\n\nTwo"},
+		{"divs", "<div>One</div><div>Two</div>", "One\nTwo"},
+		{"headings", "<h1>Title</h1>Text", "Title\n\nText"},
+		{"line breaks", "One<br>Two<br/><br>Three", "One\nTwo\n\nThree"},
+		{"list", "<ul><li>One</li><li>Two</li></ul>After", "One\nTwo\nAfter"},
+		{"table", "<table><tr><th>A</th><th>B</th></tr><tr><td>1</td><td>2</td></tr></table>",
+			"A\tB\n1\t2"},
+		{"pre", "<p>Code:</p><pre>  if x {\n    y()\n  }</pre>", "Code:\n\n  if x {\n    y()\n  }"},
+		{"leading pre", "<pre>  indented</pre>", "  indented"},
+		{"textarea", "<textarea>  a\n b</textarea>", "  a\n b"},
+		{"entities", "Fish &amp; chips &lt;b&gt; &#169;", "Fish & chips <b> ©"},
+		{"script", "a<script>alert('x')</script>b", "ab"},
+		{"style", "<style>p { color: red }</style>text", "text"},
+		{"head", "<html><head><title>T</title><meta charset=utf-8></head><body>Body</body></html>", "Body"},
+		{"comments", "a<!-- b -->c<![CDATA[d]]>", "ac"},
+		{"less than", "1 < 2", "1 < 2"},
+		{"unterminated tag", "a<b", "a"},
+		{"word split by tags", "Hel<b>lo</b>", "Hello"},
+		{"conditional comment", "<!--[if mso]><p>Outlook</p><![endif]--><p>All</p>", "All"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, HTMLToText(test.input))
+		})
+	}
+
+	t.Run("keep cdata", func(t *testing.T) {
+		assert.Equal(t, "a <b> & c", HTMLToText("a <![CDATA[<b> &]]> c", WithKeepCDATA()))
+	})
+}
+
+// BenchmarkHTMLToText benchmarks the HTMLToText method
+func BenchmarkHTMLToText(b *testing.B) {
+	input := "<h1>Title</h1><p>Hello <b>world</b> &amp; more</p><script>alert('x')</script>" +
+		"<ul><li>One</li><li>Two</li></ul>"
+	for i := 0; i < b.N; i++ {
+		_ = HTMLToText(input)
+	}
+}
+
+// ExampleHTMLToText example using HTMLToText()
+func ExampleHTMLToText() {
+	fmt.Println(HTMLToText("<h1>News</h1><p>Fish &amp; chips<br>today</p><script>alert('x')</script>"))
+	// Output:
+	// News
+	//
+	// Fish & chips
+	// today
+}
+
+// TestStripTagsExcept tests the StripTagsExcept method
+func TestStripTagsExcept(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		keep     []string
+		expected string
+	}{
+		{"empty", "", []string{"b"}, ""},
+		{"no tags kept", "<p>Hello <b>world</b></p>", nil, "Hello world"},
+		{"keep tags", "<p>Hello <b>bold</b> <i>italic</i></p>", []string{"b", "i"},
+			"Hello <b>bold</b> <i>italic</i>"},
+		{"case and space", "<B>x</B>", []string{" B "}, "<b>x</b>"},
+		{"safe attributes", `<a href="https://example.com" title=Home class='c' target="_blank">x</a>`,
+			[]string{"a"}, `<a href="https://example.com" title="Home" class="c">x</a>`},
+		{"event handlers", `<b onclick="x()" onmouseover=y>x</b>`, []string{"b"}, "<b>x</b>"},
+		{"style attribute", `<b style="color:red">x</b>`, []string{"b"}, "<b>x</b>"},
+		{"unsafe url", `<a href="javascript:alert(1)">x</a><img src="data:text/html,x">`,
+			[]string{"a", "img"}, "<a>x</a><img>"},
+		{"entity encoded url", `<a href="jav&#x61;script:alert(1)">x</a>`, []string{"a"}, "<a>x</a>"},
+		{"attribute escaping", `<b title='"><script>'>x</b>`, []string{"b"},
+			`<b title="&#34;&gt;&lt;script&gt;">x</b>`},
+		{"script can not be kept", "<script>alert(1)</script>a", []string{"script"}, "a"},
+		{"iframe can not be kept", `<iframe src="https://x"></iframe>a`, []string{"iframe"}, "a"},
+		{"style content removed", "<style>b{}</style>a", []string{"b"}, "a"},
+		{"comments removed", "a<!-- <b>x</b> -->b", []string{"b"}, "ab"},
+		{"self closing", "a<br/>b<br>", []string{"br"}, "a<br />b<br>"},
+		{"kept raw text element", "<textarea><b>x</b></textarea>", []string{"textarea"},
+			"<textarea>&lt;b>x&lt;/b></textarea>"},
+		{"re-forming tag", "<<i>b>x", []string{"b"}, "b>x"},
+		{"svg style", "<svg><style><script>alert(1)</script></style></svg>", []string{"svg"}, "<svg></svg>"},
+		{"svg title", "<svg><title><script>alert(1)</script></title></svg>", []string{"svg", "title"},
+			"<svg><title>&lt;script>alert(1)&lt;/script></title></svg>"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := StripTagsExcept(test.input, test.keep...)
+			assert.Equal(t, test.expected, output)
+			assert.Equal(t, output, StripTagsExcept(output, test.keep...), "output should be stable")
+		})
+	}
+}
+
+// BenchmarkStripTagsExcept benchmarks the StripTagsExcept method
+func BenchmarkStripTagsExcept(b *testing.B) {
+	input := `<div class="post"><p>Hello <b onclick="x()">world</b>, ` +
+		`<a href="https://example.com">link</a></p></div>`
+	for i := 0; i < b.N; i++ {
+		_ = StripTagsExcept(input, "a", "b", "i", "p")
+	}
+}
+
+// ExampleStripTagsExcept example using StripTagsExcept()
+func ExampleStripTagsExcept() {
+	input := `<div><b onclick="x()">Hi</b> <a href="javascript:x">there</a></div>`
+	fmt.Println(StripTagsExcept(input, "a", "b"))
+	// Output: <b>Hi</b> <a>there</a>
+}
@@ -0,0 +1,314 @@
+package sanitize
+
+import (
+	"strings"
+)
+
+// htmlTokenType is the type of token returned by the html tokenizer
+type htmlTokenType int
+
+// Supported html token types
+const (
+	htmlText      htmlTokenType = iota // Text (including the content of raw text elements)
+	htmlStartTag                       // <tag attr="value"> or <tag />
+	htmlEndTag                         // </tag>
+	htmlComment                        // <!-- comment --> (including conditional comments)
+	htmlCDATA                          // <![CDATA[ data ]]>
+	htmlDirective                      // <!DOCTYPE html>, <?xml ?> and other bogus comments
+)
+
+// htmlAttr is an attribute of a start tag
+type htmlAttr struct {
+	key string // Lowercase attribute name
+	val string // Raw (not unescaped) attribute value
+}
+
+// htmlToken is a single token returned by the html tokenizer
+type htmlToken struct {
+	attrs       []htmlAttr    // Attributes (start tags only)
+	data        string        // Lowercase tag name, text, comment or CDATA content
+	raw         string        // The exact source of the token
+	selfClosing bool          // Start tag ends with />
+	typ         htmlTokenType // Type of token
+}
+
+// htmlRawTextTags are the elements whose content is not parsed as html
+var htmlRawTextTags = map[string]bool{
+	"iframe":    true,
+	"noembed":   true,
+	"noframes":  true,
+	"plaintext": true,
+	"script":    true,
+	"style":     true,
+	"textarea":  true,
+	"title":     true,
+	"xmp":       true,
+}
+
+// htmlVoidTags are the elements that never have content or an end tag
+var htmlVoidTags = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"link":   true,
+	"meta":   true,
+	"param":  true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
+
+// htmlTokenizer is a minimal, forgiving html tokenizer (loosely following the WHATWG
+// tokenization rules). Unterminated tags, comments and raw text elements run to the
+// end of the input so that nothing dangerous can be hidden in a broken document.
+type htmlTokenizer struct {
+	input     string // Input being tokenized
+	keepAttrs bool   // Collect the attributes of start tags (skipped by default to save allocations)
+	noRawText bool   // Tokenize the content of raw text elements as markup
+	pos       int    // Current position in the input
+	rawTag    string // Name of the open raw text element (if any)
+}
+
+// newHTMLTokenizer returns a tokenizer for the input
+func newHTMLTokenizer(input string) *htmlTokenizer {
+	return &htmlTokenizer{input: input}
+}
+
+// next returns the next token, false is returned at the end of the input
+func (z *htmlTokenizer) next() (htmlToken, bool) {
+	if z.pos >= len(z.input) {
+		return htmlToken{}, false
+	}
+
+	// Content of a raw text element (script, style, etc.)
+	if len(z.rawTag) > 0 {
+		if token, ok := z.readRawText(); ok {
+			return token, true
+		}
+	}
+
+	if z.input[z.pos] == '<' {
+		if token, ok := z.readMarkup(); ok {
+			return token, true
+		}
+	}
+	return z.readText(), true
+}
+
+// readText reads text until the next markup
+func (z *htmlTokenizer) readText() htmlToken {
+	start := z.pos
+	i := z.pos + 1
+	for {
+		next := strings.IndexByte(z.input[i:], '<')
+		if next < 0 {
+			i = len(z.input)
+			break
+		}
+		i += next
+		if isMarkupStart(z.input, i) {
+			break
+		}
+		i++
+	}
+	z.pos = i
+	return htmlToken{typ: htmlText, data: z.input[start:i], raw: z.input[start:i]}
+}
+
+// readRawText reads the content of a raw text element up to its end tag
+func (z *htmlTokenizer) readRawText() (htmlToken, bool) {
+	start, tag := z.pos, z.rawTag
+	z.rawTag = ""
+
+	end := len(z.input)
+	if tag != "plaintext" {
+		end = indexEndTag(z.input, start, tag)
+	}
+	if end == start {
+		return htmlToken{}, false
+	}
+	z.pos = end
+	return htmlToken{typ: htmlText, data: z.input[start:end], raw: z.input[start:end]}, true
+}
+
+// readMarkup reads a tag, comment, CDATA section or directive starting at "<"
+func (z *htmlTokenizer) readMarkup() (htmlToken, bool) {
+	start, rest := z.pos, z.input[z.pos:]
+
+	switch {
+	case strings.HasPrefix(rest, "<!--"):
+		return z.readUntil(start, 4, "-->", htmlComment), true
+	case len(rest) >= 9 && strings.EqualFold(rest[:9], "<![CDATA["):
+		return z.readUntil(start, 9, "]]>", htmlCDATA), true
+	case strings.HasPrefix(rest, "<!") || strings.HasPrefix(rest, "<?"):
+		return z.readUntil(start, 2, ">", htmlDirective), true
+	case strings.HasPrefix(rest, "</"):
+		if len(rest) > 2 && isASCIILetter(rest[2]) {
+			return z.readTag(start, true), true
+		}
+		return z.readUntil(start, 2, ">", htmlDirective), true
+	case len(rest) > 1 && isASCIILetter(rest[1]):
+		return z.readTag(start, false), true
+	}
+	return htmlToken{}, false
+}
+
+// readUntil reads a token that ends with the terminator (or the end of the input)
+func (z *htmlTokenizer) readUntil(start, prefix int, terminator string, typ htmlTokenType) htmlToken {
+	contentStart := start + prefix
+
+	// Special case: <!--> and <!---> are empty comments
+	if typ == htmlComment {
+		if strings.HasPrefix(z.input[contentStart:], ">") {
+			z.pos = contentStart + 1
+			return htmlToken{typ: typ, raw: z.input[start:z.pos]}
+		} else if strings.HasPrefix(z.input[contentStart:], "->") {
+			z.pos = contentStart + 2
+			return htmlToken{typ: typ, raw: z.input[start:z.pos]}
+		}
+	}
+
+	end := strings.Index(z.input[contentStart:], terminator)
+
+	// A comment also ends with --!> (the comment body is always removed as a whole)
+	if typ == htmlComment {
+		if bang := strings.Index(z.input[contentStart:], "--!>"); bang >= 0 && (end < 0 || bang < end) {
+			end, terminator = bang, "--!>"
+		}
+	}
+	if end < 0 {
+		z.pos = len(z.input)
+		return htmlToken{typ: typ, data: z.input[contentStart:], raw: z.input[start:]}
+	}
+	z.pos = contentStart + end + len(terminator)
+	return htmlToken{typ: typ, data: z.input[contentStart : contentStart+end], raw: z.input[start:z.pos]}
+}
+
+// readTag reads a start or end tag including its attributes
+func (z *htmlTokenizer) readTag(start int, endTag bool) htmlToken {
+	i := start + 1
+	if endTag {
+		i++
+	}
+
+	// Tag name
+	nameStart := i
+	for i < len(z.input) && !isHTMLSpace(z.input[i]) && z.input[i] != '/' && z.input[i] != '>' {
+		i++
+	}
+	token := htmlToken{typ: htmlStartTag, data: strings.ToLower(z.input[nameStart:i])}
+	if endTag {
+		token.typ = htmlEndTag
+	}
+
+	// Attributes
+	for i < len(z.input) && z.input[i] != '>' {
+		c := z.input[i]
+		if isHTMLSpace(c) || c == '/' {
+			token.selfClosing = c == '/'
+			i++
+			continue
+		}
+		token.selfClosing = false
+
+		keyStart := i
+		for i < len(z.input) && !isHTMLSpace(z.input[i]) && z.input[i] != '/' && z.input[i] != '>' &&
+			(z.input[i] != '=' || i == keyStart) {
+			i++
+		}
+		attr := htmlAttr{key: strings.ToLower(z.input[keyStart:i])}
+
+		// Optional value
+		j := skipHTMLSpace(z.input, i)
+		if j < len(z.input) && z.input[j] == '=' {
+			i = skipHTMLSpace(z.input, j+1)
+			attr.val, i = readAttrValue(z.input, i)
+		}
+		if !endTag && z.keepAttrs {
+			token.attrs = append(token.attrs, attr)
+		}
+	}
+
+	if i < len(z.input) {
+		i++ // Closing >
+	}
+	z.pos = i
+	token.raw = z.input[start:i]
+
+	if token.typ == htmlStartTag && !z.noRawText && htmlRawTextTags[token.data] {
+		z.rawTag = token.data
+	}
+	return token
+}
+
+// readAttrValue reads a quoted or unquoted attribute value starting at i
+func readAttrValue(input string, i int) (string, int) {
+	if i >= len(input) {
+		return "", i
+	}
+
+	if quote := input[i]; quote == '"' || quote == '\'' {
+		end := strings.IndexByte(input[i+1:], quote)
+		if end < 0 {
+			return input[i+1:], len(input)
+		}
+		return input[i+1 : i+1+end], i + end + 2
+	}
+
+	start := i
+	for i < len(input) && !isHTMLSpace(input[i]) && input[i] != '>' {
+		i++
+	}
+	return input[start:i], i
+}
+
+// indexEndTag returns the position of the end tag for the raw text element (or the end of the input)
+func indexEndTag(input string, from int, tag string) int {
+	for i := from; i < len(input); i++ {
+		if input[i] != '<' || i+2+len(tag) > len(input) || input[i+1] != '/' ||
+			!strings.EqualFold(input[i+2:i+2+len(tag)], tag) {
+			continue
+		}
+		if after := i + 2 + len(tag); after == len(input) || isHTMLSpace(input[after]) ||
+			input[after] == '/' || input[after] == '>' {
+			return i
+		}
+	}
+	return len(input)
+}
+
+// isMarkupStart returns true if the "<" at position i starts a tag, comment or directive
+func isMarkupStart(input string, i int) bool {
+	if i+1 >= len(input) {
+		return false
+	}
+	return isMarkupStartByte(input[i+1])
+}
+
+// isMarkupStartByte returns true if a "<" followed by the byte starts a tag, comment or directive
+func isMarkupStartByte(c byte) bool {
+	return isASCIILetter(c) || c == '/' || c == '!' || c == '?'
+}
+
+// isASCIILetter returns true for a-z and A-Z
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isHTMLSpace returns true for the html white space characters
+func isHTMLSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// skipHTMLSpace returns the position of the next non white space character
+func skipHTMLSpace(input string, i int) int {
+	for i < len(input) && isHTMLSpace(input[i]) {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,86 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHTMLTokenizer tests the internal html tokenizer
+func TestHTMLTokenizer(t *testing.T) {
+	t.Parallel()
+
+	tokenize := func(input string) (tokens []htmlToken) {
+		z := newHTMLTokenizer(input)
+		z.keepAttrs = true
+		for {
+			token, ok := z.next()
+			if !ok {
+				return tokens
+			}
+			tokens = append(tokens, token)
+		}
+	}
+
+	t.Run("raw output matches input", func(t *testing.T) {
+		inputs := []string{
+			"",
+			"plain text",
+			`<p class="a" id=b data-x='c'>Text</p>`,
+			"<!-- comment --><![CDATA[ data ]]><!DOCTYPE html><?xml version='1.0'?>",
+			"<script>if (a < b) { x = '</p>' }</script>",
+			"1 < 2 > 0 <",
+			"<div unclosed='attr>",
+			"<!-- unterminated",
+			"<!-- bang --!> <!-->",
+			"</ not a tag>",
+		}
+		for _, input := range inputs {
+			var raw string
+			for _, token := range tokenize(input) {
+				raw += token.raw
+			}
+			assert.Equal(t, input, raw)
+		}
+	})
+
+	t.Run("token types", func(t *testing.T) {
+		tokens := tokenize(`<A HREF="x" title='y' checked>Link</a><!--c--><![CDATA[d]]><!doctype html>`)
+		assert.Len(t, tokens, 6)
+		assert.Equal(t, htmlStartTag, tokens[0].typ)
+		assert.Equal(t, "a", tokens[0].data)
+		assert.Equal(t, []htmlAttr{{"href", "x"}, {"title", "y"}, {"checked", ""}}, tokens[0].attrs)
+		assert.Equal(t, htmlText, tokens[1].typ)
+		assert.Equal(t, htmlEndTag, tokens[2].typ)
+		assert.Equal(t, htmlComment, tokens[3].typ)
+		assert.Equal(t, "c", tokens[3].data)
+		assert.Equal(t, htmlCDATA, tokens[4].typ)
+		assert.Equal(t, "d", tokens[4].data)
+		assert.Equal(t, htmlDirective, tokens[5].typ)
+	})
+
+	t.Run("raw text elements", func(t *testing.T) {
+		tokens := tokenize("<script><b>not a tag</b></SCRIPT >after")
+		assert.Len(t, tokens, 4)
+		assert.Equal(t, htmlText, tokens[1].typ)
+		assert.Equal(t, "<b>not a tag</b>", tokens[1].data)
+		assert.Equal(t, htmlEndTag, tokens[2].typ)
+		assert.Equal(t, "script", tokens[2].data)
+	})
+
+	t.Run("comments", func(t *testing.T) {
+		tokens := tokenize("<!--a--!>b<!---->c<!-- d -- > -->")
+		assert.Len(t, tokens, 5)
+		assert.Equal(t, "a", tokens[0].data)
+		assert.Equal(t, "<!--a--!>", tokens[0].raw)
+		assert.Equal(t, "", tokens[2].data)
+		assert.Equal(t, " d -- > ", tokens[4].data)
+	})
+
+	t.Run("self closing", func(t *testing.T) {
+		tokens := tokenize("<br/><img src=x />")
+		assert.True(t, tokens[0].selfClosing)
+		assert.True(t, tokens[1].selfClosing)
+		assert.Equal(t, []htmlAttr{{"src", "x"}}, tokens[1].attrs)
+	})
+}
@@ -0,0 +1,163 @@
+package sanitize
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Domain name limits (RFC 1035 & RFC 1123)
+const (
+	maxDomainLength = 253
+	maxLabelLength  = 63
+)
+
+// Punycode parameters (RFC 3492)
+const (
+	punyBase        = 36
+	punyDamp        = 700
+	punyInitialBias = 72
+	punyInitialN    = 128
+	punySkew        = 38
+	punyTMax        = 26
+	punyTMin        = 1
+	punyPrefix      = "xn--"
+)
+
+// domainToASCII lowercases the domain, converts any internationalized labels to
+// punycode (IDNA) and validates each label against the hostname rules. The labels are
+// normalized to NFC first, so the composed and decomposed forms (é and e + U+0301) of
+// a domain give the same A-label.
+func domainToASCII(domain string) (string, error) {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	if len(domain) == 0 {
+		return "", fmt.Errorf("%w: empty domain", ErrInvalidDomain)
+	}
+
+	labels := strings.Split(domain, ".")
+	for i, label := range labels {
+		if !isASCII(label) {
+			if !utf8.ValidString(label) {
+				return "", fmt.Errorf("%w: invalid utf-8 in label", ErrInvalidDomain)
+			} else if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+				return "", fmt.Errorf("%w: label %q starts or ends with a hyphen", ErrInvalidDomain, label)
+			}
+			labels[i] = punyPrefix + punycodeEncode(norm.NFC.String(label))
+		}
+		if err := validateLabel(labels[i]); err != nil {
+			return "", err
+		}
+	}
+
+	domain = strings.Join(labels, ".")
+	if len(domain) > maxDomainLength {
+		return "", fmt.Errorf("%w: longer than %d characters", ErrInvalidDomain, maxDomainLength)
+	}
+	return domain, nil
+}
+
+// validateLabel checks a single (ASCII) DNS label: 1-63 letters, digits or hyphens
+// which does not start or end with a hyphen
+func validateLabel(label string) error {
+	if len(label) == 0 {
+		return fmt.Errorf("%w: empty label", ErrInvalidDomain)
+	} else if len(label) > maxLabelLength {
+		return fmt.Errorf("%w: label %q is longer than %d characters", ErrInvalidDomain, label, maxLabelLength)
+	} else if label[0] == '-' || label[len(label)-1] == '-' {
+		return fmt.Errorf("%w: label %q starts or ends with a hyphen", ErrInvalidDomain, label)
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '-' {
+			return fmt.Errorf("%w: invalid character %q in label %q", ErrInvalidDomain, c, label)
+		}
+	}
+	return nil
+}
+
+// punycodeEncode encodes a unicode label using punycode (without the xn-- prefix)
+func punycodeEncode(label string) string {
+	runes := []rune(label)
+	out := make([]byte, 0, len(label)+8)
+
+	// Copy the basic (ASCII) code points first
+	for _, r := range runes {
+		if r < utf8.RuneSelf {
+			out = append(out, byte(r))
+		}
+	}
+	basic := len(out)
+	handled := basic
+	if basic > 0 {
+		out = append(out, '-')
+	}
+
+	// Encode the remaining code points as variable length integers
+	n, delta, bias := punyInitialN, 0, punyInitialBias
+	for handled < len(runes) {
+		m := int(utf8.MaxRune) + 1
+		for _, r := range runes {
+			if int(r) >= n && int(r) < m {
+				m = int(r)
+			}
+		}
+		delta += (m - n) * (handled + 1)
+		n = m
+
+		for _, r := range runes {
+			if int(r) < n {
+				delta++
+			} else if int(r) == n {
+				q := delta
+				for k := punyBase; ; k += punyBase {
+					t := k - bias
+					if t < punyTMin {
+						t = punyTMin
+					} else if t > punyTMax {
+						t = punyTMax
+					}
+					if q < t {
+						break
+					}
+					out = append(out, punyDigit(t+(q-t)%(punyBase-t)))
+					q = (q - t) / (punyBase - t)
+				}
+				out = append(out, punyDigit(q))
+				bias = punyAdapt(delta, handled+1, handled == basic)
+				delta = 0
+				handled++
+			}
+		}
+		delta++
+		n++
+	}
+
+	return string(out)
+}
+
+// punyAdapt is the bias adaptation function (RFC 3492 section 6.1)
+func punyAdapt(delta, numPoints int, firstTime bool) int {
+	if firstTime {
+		delta /= punyDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+
+	k := 0
+	for delta > ((punyBase-punyTMin)*punyTMax)/2 {
+		delta /= punyBase - punyTMin
+		k += punyBase
+	}
+	return k + (punyBase-punyTMin+1)*delta/(delta+punySkew)
+}
+
+// punyDigit returns the basic code point for a punycode digit (a-z, 0-9)
+func punyDigit(digit int) byte {
+	if digit < 26 {
+		return byte('a' + digit)
+	}
+	return byte('0' + digit - 26)
+}
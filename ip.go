@@ -0,0 +1,162 @@
+package sanitize
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// reservedNetworks are the ip ranges that are not publicly routable (private, loopback,
+// link-local, carrier-grade NAT, documentation, multicast, etc.)
+var reservedNetworks = parseCIDRs(
+	"0.0.0.0/8", "10.0.0.0/8", "100.64.0.0/10", "127.0.0.0/8", "169.254.0.0/16", "172.16.0.0/12",
+	"192.0.0.0/24", "192.0.2.0/24", "192.88.99.0/24", "192.168.0.0/16", "198.18.0.0/15",
+	"198.51.100.0/24", "203.0.113.0/24", "224.0.0.0/4", "240.0.0.0/4",
+	"::/96", "100::/64", "2001:db8::/32", "fc00::/7", "fe80::/10", "fec0::/10", "ff00::/8",
+)
+
+// embeddedIPv4Networks are the ipv6 ranges that embed an ipv4 address (NAT64 and 6to4)
+var embeddedIPv4Networks = parseCIDRs("64:ff9b::/96", "64:ff9b:1::/48", "2002::/16")
+
+// parseCIDRs parses the list of ip ranges (panics on an invalid range)
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// isPublicIP returns true if the ip is publicly routable, ipv4 addresses embedded in an
+// ipv6 address (mapped, NAT64 and 6to4) are checked as well
+func isPublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	} else {
+		for _, network := range embeddedIPv4Networks {
+			if !network.Contains(ip) {
+				continue
+			}
+			embedded := ip[12:16]
+			if network.IP[0] == 0x20 { // 6to4 (2002:AABB:CCDD::)
+				embedded = ip[2:6]
+			}
+			if !isPublicIP(net.IP(embedded)) {
+				return false
+			}
+		}
+	}
+	for _, network := range reservedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// allowIP applies the ip address options (version and range) to the ip
+func (o *options) allowIP(ip net.IP) bool {
+	if o == nil {
+		return true
+	}
+
+	isIPv4 := ip.To4() != nil
+	switch {
+	case o.ipVersion == 4 && !isIPv4, o.ipVersion == 6 && isIPv4:
+		return false
+	case o.rejectLoopback && ip.IsLoopback():
+		return false
+	case o.rejectPrivate && !ip.IsLoopback() && !isPublicIP(ip):
+		return false
+	}
+	return true
+}
+
+// parseIPv4Host parses a host name that is an ipv4 address in any of the forms accepted by
+// browsers and the C library (2130706433, 0x7f.1, 0177.0.0.1, etc.). The host is only an ip
+// address if its last label is a number (nil is returned otherwise), an error is returned if
+// that address is invalid.
+func parseIPv4Host(host string) (net.IP, error) {
+	parts := strings.Split(host, ".")
+	if len(parts) > 1 && len(parts[len(parts)-1]) == 0 {
+		parts = parts[:len(parts)-1]
+	}
+	if _, ok := parseIPv4Part(parts[len(parts)-1]); !ok {
+		return nil, nil
+	}
+	if len(parts) > 4 {
+		return nil, fmt.Errorf("%w: invalid ipv4 address %q", ErrInvalidURL, host)
+	}
+
+	var value uint64
+	for i, part := range parts {
+		n, ok := parseIPv4Part(part)
+		limit := uint64(255)
+		if i == len(parts)-1 {
+			limit = 1<<(8*(5-len(parts))) - 1
+		}
+		if !ok || n > limit {
+			return nil, fmt.Errorf("%w: invalid ipv4 address %q", ErrInvalidURL, host)
+		}
+		if i < len(parts)-1 {
+			value |= n << (8 * (3 - i))
+		} else {
+			value |= n
+		}
+	}
+	return net.IPv4(byte(value>>24), byte(value>>16), byte(value>>8), byte(value)).To4(), nil
+}
+
+// parseIPv4Part parses a decimal, octal (leading zero) or hexadecimal (0x) number
+func parseIPv4Part(part string) (uint64, bool) {
+	base := 10
+	if len(part) >= 2 && part[0] == '0' && (part[1] == 'x' || part[1] == 'X') {
+		part, base = part[2:], 16
+		if len(part) == 0 {
+			return 0, true
+		}
+	} else if len(part) > 1 && part[0] == '0' {
+		part, base = part[1:], 8
+	}
+	if len(part) == 0 || part[0] == '+' || part[0] == '-' {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(part, base, 64)
+	if err != nil {
+		// Out of range numbers are still numbers (the address is invalid)
+		if errors.Is(err, strconv.ErrRange) {
+			return 1 << 32, true
+		}
+		return 0, false
+	}
+	return n, true
+}
+
+// ReverseDNS returns the reverse DNS (PTR) name of the ip address (192.0.2.1 => 1.2.0.192.in-addr.arpa.),
+// ipv6 addresses are expanded into their reversed nibbles under ip6.arpa. The address is
+// sanitized with IPAddress first, an error is returned if it is not a valid ip address.
+//
+//	View examples: ip_test.go
+func ReverseDNS(ip string) (string, error) {
+	address := net.ParseIP(IPAddress(ip))
+	if address == nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidIPAddress, ip)
+	}
+
+	if ip4 := address.To4(); ip4 != nil {
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa.", ip4[3], ip4[2], ip4[1], ip4[0]), nil
+	}
+
+	const hexDigits = "0123456789abcdef"
+	b := make([]byte, 0, len(address)*4+len("ip6.arpa."))
+	for i := len(address) - 1; i >= 0; i-- {
+		b = append(b, hexDigits[address[i]&0x0f], '.', hexDigits[address[i]>>4], '.')
+	}
+	return string(append(b, "ip6.arpa."...)), nil
+}
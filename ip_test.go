@@ -0,0 +1,65 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReverseDNS tests the ReverseDNS method
+func TestReverseDNS(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected string
+		}{
+			{"ipv4", "192.0.2.1", "1.2.0.192.in-addr.arpa."},
+			{"ipv4 spaces", " 8.8.4.4 ", "4.4.8.8.in-addr.arpa."},
+			{"ipv4 mapped", "::ffff:10.0.0.1", "1.0.0.10.in-addr.arpa."},
+			{"ipv6", "2001:db8::567:89ab",
+				"b.a.9.8.7.6.5.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa."},
+			{"ipv6 loopback", "::1",
+				"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.ip6.arpa."},
+			{"ipv6 uppercase", "2001:DB8::1",
+				"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa."},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := ReverseDNS(test.input)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		for _, input := range []string{"", "fail", "304.255.255.255", "192.2", "example.com"} {
+			output, err := ReverseDNS(input)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidIPAddress, input)
+			assert.Equal(t, "", output)
+		}
+	})
+}
+
+// BenchmarkReverseDNS benchmarks the ReverseDNS method
+func BenchmarkReverseDNS(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ReverseDNS("2001:db8::567:89ab")
+	}
+}
+
+// ExampleReverseDNS example using ReverseDNS()
+func ExampleReverseDNS() {
+	fmt.Println(ReverseDNS("192.0.2.1"))
+	fmt.Println(ReverseDNS("2001:db8::1"))
+	// Output:
+	// 1.2.0.192.in-addr.arpa. <nil>
+	// 1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa. <nil>
+}
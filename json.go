@@ -0,0 +1,253 @@
+package sanitize
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonRule is a compiled path rule for the JSON sanitizer
+type jsonRule struct {
+	fn       SanitizeFunc
+	path     string
+	segments []string
+}
+
+// jsonFrame is an open object or array while walking a JSON document
+type jsonFrame struct {
+	count     int    // Number of keys or elements written
+	expectKey bool   // Next token in the object is a key
+	isObject  bool   // Object or array
+	key       string // Current key in the object
+}
+
+// JSON walks a JSON document and applies the sanitizer for each rule to the string values
+// at the matching path. Paths use dot notation (user.email), array indexes (items.0.name or
+// items[0].name) and the wildcard * (items.*.name), a leading $. is ignored. A rule that
+// matches an object or array applies to every string inside of it, if more than one rule
+// matches a value the most specific rule is used. Key order is preserved, the output is compact.
+//
+//	View examples: json_test.go
+func JSON(input []byte, rules map[string]SanitizeFunc) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(input))
+	decoder.UseNumber()
+
+	var buf bytes.Buffer
+	buf.Grow(len(input))
+	if err := sanitizeJSONValue(decoder, &buf, compileJSONRules(rules)); err != nil {
+		return nil, err
+	}
+
+	// Only a single JSON value is allowed
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: unexpected data after the top-level value", ErrInvalidJSON)
+	}
+	return buf.Bytes(), nil
+}
+
+// NDJSON streams newline delimited JSON (JSON Lines) from r to w and sanitizes each record
+// like JSON(), the rules use the same path syntax. Records are read one line at a time so
+// the stream is never buffered as a whole, blank lines are skipped and each record is written
+// compact on its own line. An error is returned (with the line number) for a malformed record.
+//
+//	View examples: json_test.go
+func NDJSON(r io.Reader, w io.Writer, rules map[string]SanitizeFunc) error {
+	compiled := compileJSONRules(rules)
+	reader := bufio.NewReader(r)
+	writer := bufio.NewWriter(w)
+
+	var buf bytes.Buffer
+	for n := 1; ; n++ {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if record := bytes.TrimSpace(line); len(record) > 0 {
+			buf.Reset()
+			decoder := json.NewDecoder(bytes.NewReader(record))
+			decoder.UseNumber()
+			if serr := sanitizeJSONValue(decoder, &buf, compiled); serr != nil {
+				return fmt.Errorf("%w (line %d)", serr, n)
+			}
+			if _, terr := decoder.Token(); !errors.Is(terr, io.EOF) {
+				return fmt.Errorf("%w: unexpected data after the record (line %d)", ErrInvalidJSON, n)
+			}
+			buf.WriteByte('\n')
+			if _, werr := writer.Write(buf.Bytes()); werr != nil {
+				return werr
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	return writer.Flush()
+}
+
+// sanitizeJSONValue reads a single JSON value from the decoder and writes the sanitized value
+func sanitizeJSONValue(decoder *json.Decoder, buf *bytes.Buffer, rules []jsonRule) error {
+	var stack []*jsonFrame
+	var path []string
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return fmt.Errorf("%w: %s", ErrInvalidJSON, err.Error())
+		}
+
+		// End of an object or array
+		if delim, ok := token.(json.Delim); ok && (delim == '}' || delim == ']') {
+			buf.WriteByte(byte(delim))
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return nil
+			}
+			path = path[:len(path)-1]
+			continue
+		}
+
+		var frame *jsonFrame
+		if len(stack) > 0 {
+			frame = stack[len(stack)-1]
+		}
+
+		// Object key
+		if frame != nil && frame.isObject && frame.expectKey {
+			if frame.count > 0 {
+				buf.WriteByte(',')
+			}
+			frame.key, frame.expectKey = token.(string), false
+			frame.count++
+			writeJSONString(buf, frame.key)
+			buf.WriteByte(':')
+			continue
+		}
+
+		// Path of the value
+		valuePath := path
+		if frame != nil {
+			segment := frame.key
+			if frame.isObject {
+				frame.expectKey = true
+			} else {
+				if frame.count > 0 {
+					buf.WriteByte(',')
+				}
+				segment = strconv.Itoa(frame.count)
+				frame.count++
+			}
+			valuePath = append(path[:len(path):len(path)], segment)
+		}
+
+		switch value := token.(type) {
+		case json.Delim:
+			buf.WriteByte(byte(value))
+			stack = append(stack, &jsonFrame{isObject: value == '{', expectKey: value == '{'})
+			if frame != nil {
+				path = valuePath
+			}
+			continue
+		case string:
+			if fn := matchJSONRule(rules, valuePath); fn != nil {
+				value = fn(value)
+			}
+			writeJSONString(buf, value)
+		case json.Number:
+			buf.WriteString(value.String())
+		case bool:
+			buf.WriteString(strconv.FormatBool(value))
+		case nil:
+			buf.WriteString("null")
+		}
+
+		if frame == nil {
+			return nil
+		}
+	}
+}
+
+// compileJSONRules splits the rule paths into segments and sorts the rules from the most
+// to the least specific (longest path first, then the fewest wildcards)
+func compileJSONRules(rules map[string]SanitizeFunc) []jsonRule {
+	compiled := make([]jsonRule, 0, len(rules))
+	for path, fn := range rules {
+		if fn == nil {
+			continue
+		}
+		compiled = append(compiled, jsonRule{fn: fn, path: path, segments: splitJSONPath(path)})
+	}
+
+	sort.Slice(compiled, func(i, j int) bool {
+		a, b := compiled[i], compiled[j]
+		if len(a.segments) != len(b.segments) {
+			return len(a.segments) > len(b.segments)
+		}
+		if wa, wb := countWildcards(a.segments), countWildcards(b.segments); wa != wb {
+			return wa < wb
+		}
+		return a.path < b.path
+	})
+	return compiled
+}
+
+// splitJSONPath splits a path into segments: $.items[0].name => items, 0, name
+func splitJSONPath(path string) []string {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+	if len(path) == 0 {
+		return nil
+	}
+
+	segments := strings.Split(path, ".")
+	for i, segment := range segments {
+		segments[i] = strings.Trim(segment, `"'`)
+	}
+	return segments
+}
+
+// countWildcards returns the number of wildcard segments
+func countWildcards(segments []string) (count int) {
+	for _, segment := range segments {
+		if segment == "*" {
+			count++
+		}
+	}
+	return count
+}
+
+// matchJSONRule returns the sanitizer of the first rule that matches the path (or a parent of the path)
+func matchJSONRule(rules []jsonRule, path []string) SanitizeFunc {
+	for _, rule := range rules {
+		if len(rule.segments) > len(path) {
+			continue
+		}
+		matched := true
+		for i, segment := range rule.segments {
+			if segment != "*" && segment != path[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rule.fn
+		}
+	}
+	return nil
+}
+
+// writeJSONString writes the string as a JSON string (without escaping html characters)
+func writeJSONString(buf *bytes.Buffer, value string) {
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(value)
+	buf.Truncate(buf.Len() - 1) // Encode() adds a new line
+}
@@ -0,0 +1,175 @@
+package sanitize
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestJSON tests the JSON sanitize method
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	lower := SanitizeFunc(strings.ToLower)
+	alpha := func(s string) string { return Alpha(s, false) }
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected string
+			rules    map[string]SanitizeFunc
+		}{
+			{"no rules", `{"b": "X", "a": 1}`, `{"b":"X","a":1}`, nil},
+			{"top level key", `{"Name":"ABC","other":"DEF"}`, `{"Name":"abc","other":"DEF"}`,
+				map[string]SanitizeFunc{"Name": lower}},
+			{"nested key", `{"user":{"email":"A@B.COM","name":"X"}}`, `{"user":{"email":"a@b.com","name":"X"}}`,
+				map[string]SanitizeFunc{"user.email": lower}},
+			{"jsonpath prefix", `{"user":{"email":"A@B.COM"}}`, `{"user":{"email":"a@b.com"}}`,
+				map[string]SanitizeFunc{"$.user.email": lower}},
+			{"array index", `{"items":["A","B"]}`, `{"items":["A","b"]}`,
+				map[string]SanitizeFunc{"items[1]": lower}},
+			{"array wildcard", `{"items":[{"n":"A1"},{"n":"B2"}]}`, `{"items":[{"n":"A"},{"n":"B"}]}`,
+				map[string]SanitizeFunc{"items.*.n": alpha}},
+			{"container rule", `{"user":{"a":"X1","b":["Y2"]},"c":"Z3"}`, `{"user":{"a":"X","b":["Y"]},"c":"Z3"}`,
+				map[string]SanitizeFunc{"user": alpha}},
+			{"most specific wins", `{"user":{"a":"X1","b":"Y2"}}`, `{"user":{"a":"x1","b":"Y"}}`,
+				map[string]SanitizeFunc{"user": alpha, "user.a": lower}},
+			{"root string", `"ABC"`, `"abc"`, map[string]SanitizeFunc{"$": lower}},
+			{"other types kept", `{"a":1.50,"b":true,"c":null,"d":[],"e":{}}`, `{"a":1.50,"b":true,"c":null,"d":[],"e":{}}`,
+				map[string]SanitizeFunc{"*": lower}},
+			{"html not escaped", `{"a":"<b>x</b>"}`, `{"a":"x"}`,
+				map[string]SanitizeFunc{"a": func(s string) string { return HTML(s) }}},
+			{"keys not sanitized", `{"KEY":"V"}`, `{"KEY":"v"}`, map[string]SanitizeFunc{"*": lower}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := JSON([]byte(test.input), test.rules)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, string(output))
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		for _, input := range []string{"", "{", `{"a":}`, `{"a":1}}`, `[1,2`, `{"a":1} {"b":2}`, "nope"} {
+			output, err := JSON([]byte(input), nil)
+			require.Error(t, err, input)
+			assert.ErrorIs(t, err, ErrInvalidJSON)
+			assert.Nil(t, output)
+		}
+	})
+}
+
+// BenchmarkJSON benchmarks the JSON method
+func BenchmarkJSON(b *testing.B) {
+	input := []byte(`{"user":{"email":"Person@Example.COM","name":"John <b>Doe</b>"},"tags":["A","B"]}`)
+	rules := map[string]SanitizeFunc{
+		"user.email": func(s string) string { return Email(s, false) },
+		"user.name":  func(s string) string { return HTML(s) },
+	}
+	for i := 0; i < b.N; i++ {
+		_, _ = JSON(input, rules)
+	}
+}
+
+// ExampleJSON example using JSON()
+func ExampleJSON() {
+	output, err := JSON([]byte(`{"user":{"email":"mailto:Person@Example.COM","name":"John <b>Doe</b>"}}`),
+		map[string]SanitizeFunc{
+			"user.email": func(s string) string { return Email(s, false) },
+			"user.name":  func(s string) string { return HTML(s) },
+		},
+	)
+	fmt.Println(string(output), err)
+	// Output: {"user":{"email":"person@example.com","name":"John Doe"}} <nil>
+}
+
+// TestNDJSON tests the NDJSON method
+func TestNDJSON(t *testing.T) {
+	t.Parallel()
+
+	rules := map[string]SanitizeFunc{
+		"user.email": Funcs.Email,
+		"msg":        Funcs.Secrets,
+	}
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected string
+		}{
+			{"empty", "", ""},
+			{
+				"records", "{\"user\":{\"email\":\"A@B.COM\"},\"msg\":\"password=hunter2\"}\n{\"msg\":\"ok\",\"n\":1.50}\n",
+				"{\"user\":{\"email\":\"a@b.com\"},\"msg\":\"password=[REDACTED]\"}\n{\"msg\":\"ok\",\"n\":1.50}\n",
+			},
+			{"no trailing new line", `{"user":{"email":"A@B.COM"}}`, "{\"user\":{\"email\":\"a@b.com\"}}\n"},
+			{"blank lines skipped", "\n  \n{\"x\": 1}\r\n\n", "{\"x\":1}\n"},
+			{"compacted", "{ \"user\" : { \"email\" : \"X@Y.com\" } }\n", "{\"user\":{\"email\":\"x@y.com\"}}\n"},
+			{"not an object", "\"A@B.COM\"\n[1,2]\n", "\"A@B.COM\"\n[1,2]\n"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				var output bytes.Buffer
+				err := NDJSON(strings.NewReader(test.input), &output, rules)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output.String())
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"malformed", "{\"x\":1}\n{\"x\":\n"},
+			{"two values on a line", "{\"x\":1} {\"x\":2}\n"},
+			{"pretty printed", "{\n\"x\":1\n}\n"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				err := NDJSON(strings.NewReader(test.input), io.Discard, rules)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidJSON)
+				assert.Contains(t, err.Error(), "(line ")
+			})
+		}
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		input := strings.Repeat("{\"msg\":\"hello world\"}\n", 500)
+		require.Error(t, NDJSON(strings.NewReader(input), failingWriter{}, rules))
+	})
+}
+
+// BenchmarkNDJSON benchmarks the NDJSON method
+func BenchmarkNDJSON(b *testing.B) {
+	input := strings.Repeat("{\"user\":{\"email\":\"Person@Example.COM\"},\"msg\":\"token=abc123\"}\n", 100)
+	rules := map[string]SanitizeFunc{"user.email": Funcs.Email, "msg": Funcs.Secrets}
+	for i := 0; i < b.N; i++ {
+		_ = NDJSON(strings.NewReader(input), io.Discard, rules)
+	}
+}
+
+// ExampleNDJSON example using NDJSON()
+func ExampleNDJSON() {
+	input := "{\"user\":{\"email\":\"A@B.COM\"}}\n{\"user\":{\"email\":\"C@D.COM\"}}\n"
+	err := NDJSON(strings.NewReader(input), os.Stdout, map[string]SanitizeFunc{"user.email": Funcs.Email})
+	fmt.Println(err)
+	// Output:
+	// {"user":{"email":"a@b.com"}}
+	// {"user":{"email":"c@d.com"}}
+	// <nil>
+}
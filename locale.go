@@ -0,0 +1,212 @@
+package sanitize
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// countryCodes maps the ISO 3166-1 alpha-2 country codes to their alpha-3 codes
+var countryCodes = map[string]string{
+	"AD": "AND", "AE": "ARE", "AF": "AFG", "AG": "ATG", "AI": "AIA", "AL": "ALB", "AM": "ARM", "AO": "AGO",
+	"AQ": "ATA", "AR": "ARG", "AS": "ASM", "AT": "AUT", "AU": "AUS", "AW": "ABW", "AX": "ALA", "AZ": "AZE",
+	"BA": "BIH", "BB": "BRB", "BD": "BGD", "BE": "BEL", "BF": "BFA", "BG": "BGR", "BH": "BHR", "BI": "BDI",
+	"BJ": "BEN", "BL": "BLM", "BM": "BMU", "BN": "BRN", "BO": "BOL", "BQ": "BES", "BR": "BRA", "BS": "BHS",
+	"BT": "BTN", "BV": "BVT", "BW": "BWA", "BY": "BLR", "BZ": "BLZ",
+	"CA": "CAN", "CC": "CCK", "CD": "COD", "CF": "CAF", "CG": "COG", "CH": "CHE", "CI": "CIV", "CK": "COK",
+	"CL": "CHL", "CM": "CMR", "CN": "CHN", "CO": "COL", "CR": "CRI", "CU": "CUB", "CV": "CPV", "CW": "CUW",
+	"CX": "CXR", "CY": "CYP", "CZ": "CZE",
+	"DE": "DEU", "DJ": "DJI", "DK": "DNK", "DM": "DMA", "DO": "DOM", "DZ": "DZA",
+	"EC": "ECU", "EE": "EST", "EG": "EGY", "EH": "ESH", "ER": "ERI", "ES": "ESP", "ET": "ETH",
+	"FI": "FIN", "FJ": "FJI", "FK": "FLK", "FM": "FSM", "FO": "FRO", "FR": "FRA",
+	"GA": "GAB", "GB": "GBR", "GD": "GRD", "GE": "GEO", "GF": "GUF", "GG": "GGY", "GH": "GHA", "GI": "GIB",
+	"GL": "GRL", "GM": "GMB", "GN": "GIN", "GP": "GLP", "GQ": "GNQ", "GR": "GRC", "GS": "SGS", "GT": "GTM",
+	"GU": "GUM", "GW": "GNB", "GY": "GUY",
+	"HK": "HKG", "HM": "HMD", "HN": "HND", "HR": "HRV", "HT": "HTI", "HU": "HUN",
+	"ID": "IDN", "IE": "IRL", "IL": "ISR", "IM": "IMN", "IN": "IND", "IO": "IOT", "IQ": "IRQ", "IR": "IRN",
+	"IS": "ISL", "IT": "ITA",
+	"JE": "JEY", "JM": "JAM", "JO": "JOR", "JP": "JPN",
+	"KE": "KEN", "KG": "KGZ", "KH": "KHM", "KI": "KIR", "KM": "COM", "KN": "KNA", "KP": "PRK", "KR": "KOR",
+	"KW": "KWT", "KY": "CYM", "KZ": "KAZ",
+	"LA": "LAO", "LB": "LBN", "LC": "LCA", "LI": "LIE", "LK": "LKA", "LR": "LBR", "LS": "LSO", "LT": "LTU",
+	"LU": "LUX", "LV": "LVA", "LY": "LBY",
+	"MA": "MAR", "MC": "MCO", "MD": "MDA", "ME": "MNE", "MF": "MAF", "MG": "MDG", "MH": "MHL", "MK": "MKD",
+	"ML": "MLI", "MM": "MMR", "MN": "MNG", "MO": "MAC", "MP": "MNP", "MQ": "MTQ", "MR": "MRT", "MS": "MSR",
+	"MT": "MLT", "MU": "MUS", "MV": "MDV", "MW": "MWI", "MX": "MEX", "MY": "MYS", "MZ": "MOZ",
+	"NA": "NAM", "NC": "NCL", "NE": "NER", "NF": "NFK", "NG": "NGA", "NI": "NIC", "NL": "NLD", "NO": "NOR",
+	"NP": "NPL", "NR": "NRU", "NU": "NIU", "NZ": "NZL",
+	"OM": "OMN",
+	"PA": "PAN", "PE": "PER", "PF": "PYF", "PG": "PNG", "PH": "PHL", "PK": "PAK", "PL": "POL", "PM": "SPM",
+	"PN": "PCN", "PR": "PRI", "PS": "PSE", "PT": "PRT", "PW": "PLW", "PY": "PRY",
+	"QA": "QAT",
+	"RE": "REU", "RO": "ROU", "RS": "SRB", "RU": "RUS", "RW": "RWA",
+	"SA": "SAU", "SB": "SLB", "SC": "SYC", "SD": "SDN", "SE": "SWE", "SG": "SGP", "SH": "SHN", "SI": "SVN",
+	"SJ": "SJM", "SK": "SVK", "SL": "SLE", "SM": "SMR", "SN": "SEN", "SO": "SOM", "SR": "SUR", "SS": "SSD",
+	"ST": "STP", "SV": "SLV", "SX": "SXM", "SY": "SYR", "SZ": "SWZ",
+	"TC": "TCA", "TD": "TCD", "TF": "ATF", "TG": "TGO", "TH": "THA", "TJ": "TJK", "TK": "TKL", "TL": "TLS",
+	"TM": "TKM", "TN": "TUN", "TO": "TON", "TR": "TUR", "TT": "TTO", "TV": "TUV", "TW": "TWN", "TZ": "TZA",
+	"UA": "UKR", "UG": "UGA", "UM": "UMI", "US": "USA", "UY": "URY", "UZ": "UZB",
+	"VA": "VAT", "VC": "VCT", "VE": "VEN", "VG": "VGB", "VI": "VIR", "VN": "VNM", "VU": "VUT",
+	"WF": "WLF", "WS": "WSM",
+	"YE": "YEM", "YT": "MYT",
+	"ZA": "ZAF", "ZM": "ZMB", "ZW": "ZWE",
+}
+
+// CountryCode returns the ISO 3166-1 alpha-2 or alpha-3 country code in uppercase (us => US,
+// usa => USA) or an error if the code is not an assigned country code.
+//
+//	View examples: locale_test.go
+func CountryCode(original string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(original))
+	switch len(code) {
+	case 2:
+		if _, ok := countryCodes[code]; ok {
+			return code, nil
+		}
+	case 3:
+		for _, alpha3 := range countryCodes {
+			if alpha3 == code {
+				return code, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidCountryCode, original)
+}
+
+// LanguageTag returns the BCP 47 language tag in its canonical form or an error if the
+// tag is not well-formed (RFC 5646). Underscores are accepted as separators and the case
+// of each subtag is normalized (EN_us => en-US, zh-hant-tw => zh-Hant-TW), extensions
+// are sorted by their singleton.
+//
+//	View examples: locale_test.go
+func LanguageTag(original string) (string, error) {
+	tag := strings.ToLower(strings.Replace(strings.TrimSpace(original), "_", "-", -1))
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %q %s", ErrInvalidLanguageTag, original, reason)
+	}
+	if len(tag) == 0 {
+		return "", invalid("is empty")
+	}
+
+	subtags := strings.Split(tag, "-")
+	for _, subtag := range subtags {
+		if len(subtag) == 0 || len(subtag) > 8 || !isAlphaNumericASCII(subtag) {
+			return "", invalid("has an invalid subtag")
+		}
+	}
+
+	// A private use tag (x-whatever)
+	if subtags[0] == "x" {
+		if len(subtags) == 1 {
+			return "", invalid("has an empty private use")
+		}
+		return tag, nil
+	}
+
+	// Language (2-3 letters, or 4-8 letters for registered languages)
+	i := 0
+	if !isAlphaASCII(subtags[0]) || len(subtags[0]) < 2 {
+		return "", invalid("has an invalid language")
+	}
+	i++
+
+	// Extended languages (up to 3), only after a 2-3 letter language
+	if len(subtags[0]) <= 3 {
+		for n := 0; n < 3 && i < len(subtags) && len(subtags[i]) == 3 && isAlphaASCII(subtags[i]); n++ {
+			i++
+		}
+	}
+
+	// Script (4 letters, title case)
+	if i < len(subtags) && len(subtags[i]) == 4 && isAlphaASCII(subtags[i]) {
+		subtags[i] = strings.ToUpper(subtags[i][:1]) + subtags[i][1:]
+		i++
+	}
+
+	// Region (2 letters in uppercase, or 3 digits)
+	if i < len(subtags) && ((len(subtags[i]) == 2 && isAlphaASCII(subtags[i])) ||
+		(len(subtags[i]) == 3 && isDigitsASCII(subtags[i]))) {
+		subtags[i] = strings.ToUpper(subtags[i])
+		i++
+	}
+
+	// Variants (5-8 characters, or 4 starting with a digit)
+	variants := make(map[string]bool)
+	for i < len(subtags) && (len(subtags[i]) >= 5 || (len(subtags[i]) == 4 && isDigitsASCII(subtags[i][:1]))) {
+		if variants[subtags[i]] {
+			return "", invalid("has a duplicate variant")
+		}
+		variants[subtags[i]] = true
+		i++
+	}
+	canonical := subtags[:i]
+
+	// Extensions (a singleton followed by 2-8 character subtags) and private use
+	var extensions []string
+	var privateUse string
+	for i < len(subtags) {
+		singleton := subtags[i]
+		if len(singleton) != 1 {
+			return "", invalid("has an invalid subtag")
+		}
+		start := i
+		i++
+		if singleton == "x" {
+			if i == len(subtags) {
+				return "", invalid("has an empty private use")
+			}
+			privateUse = strings.Join(subtags[start:], "-")
+			break
+		}
+		for i < len(subtags) && len(subtags[i]) >= 2 {
+			i++
+		}
+		if i == start+1 {
+			return "", invalid("has an empty extension")
+		}
+		extension := strings.Join(subtags[start:i], "-")
+		for _, existing := range extensions {
+			if existing[0] == singleton[0] {
+				return "", invalid("has a duplicate extension")
+			}
+		}
+		extensions = append(extensions, extension)
+	}
+	sort.Strings(extensions)
+
+	canonical = append(canonical, extensions...)
+	if len(privateUse) > 0 {
+		canonical = append(canonical, privateUse)
+	}
+	return strings.Join(canonical, "-"), nil
+}
+
+// isAlphaASCII returns true if the string only contains ASCII letters
+func isAlphaASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if !isASCIILetter(value[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDigitsASCII returns true if the string only contains ASCII digits
+func isDigitsASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAlphaNumericASCII returns true if the string only contains ASCII letters and digits
+func isAlphaNumericASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if !isASCIILetter(value[i]) && (value[i] < '0' || value[i] > '9') {
+			return false
+		}
+	}
+	return true
+}
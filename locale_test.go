@@ -0,0 +1,164 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCountryCode tests the CountryCode method
+func TestCountryCode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("table", func(t *testing.T) {
+		assert.Len(t, countryCodes, 249)
+		seen := make(map[string]bool)
+		for alpha2, alpha3 := range countryCodes {
+			assert.Len(t, alpha2, 2)
+			assert.Len(t, alpha3, 3)
+			assert.False(t, seen[alpha3], alpha3)
+			seen[alpha3] = true
+		}
+	})
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected string
+		}{
+			{"alpha-2", "US", "US"},
+			{"alpha-2 lowercase", "gb", "GB"},
+			{"alpha-2 spaces", " de ", "DE"},
+			{"alpha-3", "USA", "USA"},
+			{"alpha-3 mixed case", "Fra", "FRA"},
+			{"alpha-3 not matching alpha-2", "che", "CHE"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := CountryCode(test.input)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"unassigned alpha-2", "ZZ"},
+			{"reserved alpha-2", "UK"},
+			{"unassigned alpha-3", "XYZ"},
+			{"too long", "UNITED"},
+			{"single letter", "U"},
+			{"punctuation", "U.S."},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := CountryCode(test.input)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidCountryCode)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkCountryCode benchmarks the CountryCode method
+func BenchmarkCountryCode(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = CountryCode("usa")
+	}
+}
+
+// ExampleCountryCode example using CountryCode()
+func ExampleCountryCode() {
+	code, err := CountryCode(" us ")
+	fmt.Println(code, err)
+	// Output: US <nil>
+}
+
+// TestLanguageTag tests the LanguageTag method
+func TestLanguageTag(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected string
+		}{
+			{"language", "EN", "en"},
+			{"language and region", "en-us", "en-US"},
+			{"underscore", "en_US", "en-US"},
+			{"script", "zh-hant-tw", "zh-Hant-TW"},
+			{"numeric region", "es-419", "es-419"},
+			{"extended language", "zh-YUE-hk", "zh-yue-HK"},
+			{"variant", "sl-ROZAJ-biske", "sl-rozaj-biske"},
+			{"digit variant", "de-CH-1996", "de-CH-1996"},
+			{"extensions sorted", "en-u-ca-gregory-a-bbb", "en-a-bbb-u-ca-gregory"},
+			{"private use", "en-US-x-Twain", "en-US-x-twain"},
+			{"private use only", "X-Whatever", "x-whatever"},
+			{"three letter language", "AST", "ast"},
+			{"spaces", "  fr-CA ", "fr-CA"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := LanguageTag(test.input)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"single letter language", "e"},
+			{"digit language", "12-US"},
+			{"empty subtag", "en--US"},
+			{"trailing separator", "en-US-"},
+			{"long subtag", "en-abcdefghi"},
+			{"symbols", "en-U$"},
+			{"duplicate variant", "de-1996-1996"},
+			{"duplicate extension", "en-u-ca-u-nu"},
+			{"empty extension", "en-u"},
+			{"empty private use", "en-x"},
+			{"misplaced subtag", "en-US-a1"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := LanguageTag(test.input)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidLanguageTag)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkLanguageTag benchmarks the LanguageTag method
+func BenchmarkLanguageTag(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = LanguageTag("zh_hant_tw")
+	}
+}
+
+// ExampleLanguageTag example using LanguageTag()
+func ExampleLanguageTag() {
+	tag, err := LanguageTag("zh_hant_tw")
+	fmt.Println(tag, err)
+	// Output: zh-Hant-TW <nil>
+}
@@ -0,0 +1,154 @@
+package sanitize
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// currencyDecimals are the active ISO 4217 currency codes with their number of decimal
+// places (minor units), the codes that are not listed here use 2 decimal places
+var currencyDecimals = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// currencyCodes are the active ISO 4217 currency codes with 2 decimal places
+const currencyCodes = "AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BMD BND BOB BOV BRL BSD BTN " +
+	"BWP BYN BZD CAD CDF CHE CHF CHW CNY COP COU CRC CUC CUP CVE CZK DKK DOP DZD EGP ERN ETB EUR FJD FKP " +
+	"GBP GEL GHS GIP GMD GTQ GYD HKD HNL HTG HUF IDR ILS INR IRR JMD KES KGS KHR KPW KYD KZT LAK LBP LKR " +
+	"LRD LSL MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO NOK NPR NZD PAB PEN " +
+	"PGK PHP PKR PLN QAR RON RSD RUB SAR SBD SCR SDG SEK SGD SHP SLE SLL SOS SRD SSP STN SVC SYP SZL THB " +
+	"TJS TMT TOP TRY TTD TWD TZS UAH USD USN UYU UZS VED VES WST XCD XCG YER ZAR ZMW ZWG ZWL"
+
+// Money returns the amount in the minor units of the currency ("$1,234.56" in USD => 123456
+// cents, "¥1,234" in JPY => 1234, "1.234,567 BHD" => 1234567 fils) or an error if the amount
+// or currency (ISO 4217) is invalid. Currency symbols, codes and white space are removed, both
+// "," and "." are accepted as the decimal separator (the last one is the decimal separator,
+// a single separator between a group of 1-3 digits and exactly 3 digits is a thousands
+// separator unless the currency has 3 decimal places) and every thousands separator must be
+// followed by exactly 3 digits (1,234,56 is rejected). A minus before or after the amount or
+// accounting parentheses around it make the amount negative (12-34 is rejected). Amounts with
+// more decimal places than the currency supports are rejected instead of rounded.
+//
+//	View examples: money_test.go
+func Money(original string, currency string) (minorUnits int64, err error) {
+	code := strings.ToUpper(strings.TrimSpace(currency))
+	decimals, ok := currencyDecimals[code]
+	if !ok {
+		if len(code) != 3 || strings.Contains(code, " ") || !strings.Contains(currencyCodes, code) {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+		}
+		decimals = 2
+	}
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %q %s", ErrInvalidAmount, original, reason)
+	}
+
+	// Keep the digits and separators, a sign is only accepted before or after the amount
+	var number []byte
+	minus, open, closed, after := false, false, false, false
+	for _, r := range foldDigits(original) {
+		switch {
+		case (r >= '0' && r <= '9') || r == '.' || r == ',':
+			if after {
+				return 0, invalid("has a sign inside the amount")
+			}
+			number = append(number, byte(r))
+		case r == '-' || r == '\u2212':
+			if minus || open {
+				return 0, invalid("has more than one sign")
+			}
+			minus, after = true, len(number) > 0
+		case r == '(':
+			if minus || open || len(number) > 0 {
+				return 0, invalid("has unbalanced parentheses")
+			}
+			open = true
+		case r == ')':
+			if !open || closed || len(number) == 0 {
+				return 0, invalid("has unbalanced parentheses")
+			}
+			closed, after = true, true
+		}
+	}
+	if open != closed {
+		return 0, invalid("has unbalanced parentheses")
+	}
+
+	integer, fraction, ok := splitAmount(string(number), decimals)
+	if !ok {
+		return 0, invalid("is not a valid amount")
+	}
+
+	// Extra decimal places are only accepted if they are zeros (¥100.00)
+	if len(fraction) > decimals {
+		if strings.Trim(fraction[decimals:], "0") != "" {
+			return 0, invalid(fmt.Sprintf("has more than %d decimal places for %s", decimals, code))
+		}
+		fraction = fraction[:decimals]
+	}
+	fraction += strings.Repeat("0", decimals-len(fraction))
+
+	if minorUnits, err = strconv.ParseInt(integer+fraction, 10, 64); err != nil {
+		return 0, invalid("is out of range")
+	}
+	if minus || open {
+		minorUnits = -minorUnits
+	}
+	return minorUnits, nil
+}
+
+// splitAmount splits the number into its integer and fraction digits, the last separator
+// is the decimal separator unless it is the only separator and looks like a thousands
+// separator (1,234 but not 0.001 or 1234.567)
+func splitAmount(number string, decimals int) (integer, fraction string, ok bool) {
+	last := strings.LastIndexAny(number, ".,")
+	if last >= 0 {
+		separator := number[last]
+		other := byte(',')
+		if separator == ',' {
+			other = '.'
+		}
+		single := strings.Count(number, string(separator)) == 1 && strings.IndexByte(number, other) < 0
+		grouping := strings.Count(number, string(separator)) > 1 ||
+			(single && len(number)-last-1 == 3 && last <= 3 && number[0] != '0' &&
+				(separator == ',' || decimals != 3))
+		if !grouping {
+			integer, fraction = number[:last], number[last+1:]
+		} else {
+			integer = number
+		}
+	} else {
+		integer = number
+	}
+
+	if !validGroups(integer) {
+		return "", "", false
+	}
+	integer = strings.NewReplacer(".", "", ",", "").Replace(integer)
+	if len(integer) == 0 && len(fraction) == 0 {
+		return "", "", false
+	}
+	if len(integer) == 0 {
+		integer = "0"
+	}
+	return integer, fraction, true
+}
+
+// validGroups returns true if every thousands separator in the integer digits is followed
+// by exactly 3 digits (1,234,567 but not 1,234,56, 1.2.3 or 1,,234)
+func validGroups(integer string) bool {
+	groups := strings.FieldsFunc(integer, func(r rune) bool { return r == '.' || r == ',' })
+	if strings.Count(integer, ".")+strings.Count(integer, ",") != len(groups)-1 {
+		return len(integer) == 0
+	}
+	for _, group := range groups[1:] {
+		if len(group) != 3 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,117 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMoney tests the Money method
+func TestMoney(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			currency string
+			expected int64
+		}{
+			{"dollars", "$1,234.56", "USD", 123456},
+			{"lowercase currency", "$1,234.56", "usd", 123456},
+			{"whole dollars", "$12", "USD", 1200},
+			{"one decimal", "12.5", "USD", 1250},
+			{"cents only", ".99", "USD", 99},
+			{"thousands separator", "1,234", "USD", 123400},
+			{"multiple separators", "1,234,567", "USD", 123456700},
+			{"european", "1.234,56 €", "EUR", 123456},
+			{"european thousands", "1.234.567", "EUR", 123456700},
+			{"european decimal", "12,5", "EUR", 1250},
+			{"swiss", "CHF 1'234.50", "CHF", 123450},
+			{"french spaces", "1 234,56", "EUR", 123456},
+			{"currency code", "USD 10.00", "USD", 1000},
+			{"negative", "-$5.25", "USD", -525},
+			{"negative after symbol", "$-5.25", "USD", -525},
+			{"accounting negative", "($5.25)", "USD", -525},
+			{"accounting negative after symbol", "$(5.25)", "USD", -525},
+			{"trailing minus", "5.25-", "USD", -525},
+			{"yen", "¥1,234", "JPY", 1234},
+			{"yen zero decimals", "¥100.00", "JPY", 100},
+			{"dinar", "1.234 BHD", "BHD", 1234},
+			{"dinar thousands", "1,234.567", "BHD", 1234567},
+			{"dinar two decimals", "1.5", "KWD", 1500},
+			{"four decimals", "1.2345", "CLF", 12345},
+			{"arabic-indic digits", "١٢.٥٠", "SAR", 1250},
+			{"zero", "0", "USD", 0},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := Money(test.input, test.currency)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			currency string
+			err      error
+		}{
+			{"empty", "", "USD", ErrInvalidAmount},
+			{"no digits", "$", "USD", ErrInvalidAmount},
+			{"separators only", ".,", "USD", ErrInvalidAmount},
+			{"too many decimals", "$1.234,567", "USD", ErrInvalidAmount},
+			{"yen decimals", "¥100.50", "JPY", ErrInvalidAmount},
+			{"sub-cent", "0.001", "USD", ErrInvalidAmount},
+			{"no thousands group", "1234.567", "USD", ErrInvalidAmount},
+			{"overflow", "99999999999999999999", "USD", ErrInvalidAmount},
+			{"short group", "1,234,56", "USD", ErrInvalidAmount},
+			{"short groups", "1.2.3", "USD", ErrInvalidAmount},
+			{"empty group", "1,,234", "USD", ErrInvalidAmount},
+			{"short group before decimal", "1,23.45", "USD", ErrInvalidAmount},
+			{"minus inside", "12-34", "USD", ErrInvalidAmount},
+			{"two signs", "--5", "USD", ErrInvalidAmount},
+			{"minus and parentheses", "(-5)", "USD", ErrInvalidAmount},
+			{"parenthesis inside", "12(34)", "USD", ErrInvalidAmount},
+			{"unclosed parenthesis", "(5.25", "USD", ErrInvalidAmount},
+			{"unopened parenthesis", "5.25)", "USD", ErrInvalidAmount},
+			{"unknown currency", "10", "XYZ", ErrInvalidCurrency},
+			{"empty currency", "10", "", ErrInvalidCurrency},
+			{"currency with space", "10", "D A", ErrInvalidCurrency},
+			{"currency symbol", "10", "$", ErrInvalidCurrency},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := Money(test.input, test.currency)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, test.err)
+				assert.Equal(t, int64(0), output)
+			})
+		}
+	})
+}
+
+// BenchmarkMoney benchmarks the Money method
+func BenchmarkMoney(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = Money("$1,234.56", "USD")
+	}
+}
+
+// ExampleMoney example using Money()
+func ExampleMoney() {
+	fmt.Println(Money("$1,234.56", "USD"))
+	fmt.Println(Money("¥1,234", "JPY"))
+	fmt.Println(Money("1.234,567", "BHD"))
+	// Output:
+	// 123456 <nil>
+	// 1234 <nil>
+	// 1234567 <nil>
+}
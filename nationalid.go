@@ -0,0 +1,82 @@
+package sanitize
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// NationalIDRule describes the format of a country's national identification numbers for
+// NationalID(). The number is matched after it is uppercased and stripped of everything
+// but letters and digits.
+type NationalIDRule struct {
+	Format    *regexp.Regexp             // Format of the stripped number
+	Validate  func(number string) bool   // Optional checksum or range validation
+	Canonical func(number string) string // Optional formatting of the valid number
+}
+
+// NationalIDRules are the supported countries by their ISO 3166-1 alpha-2 code, custom rules
+// can be added (or replaced) before the rules are used, the map must not be modified while
+// NationalID() is running.
+var NationalIDRules = map[string]NationalIDRule{
+	"ES": {Format: regexp.MustCompile(`^([0-9]{8}|[XYZ][0-9]{7})[A-Z]$`), Validate: vatChecksumES},
+	"GB": {Format: regexp.MustCompile(`^[A-CEGHJ-PR-TW-Z][A-CEGHJ-NPR-TW-Z][0-9]{6}[A-D]$`), Validate: validateUKNINumber},
+	"US": {
+		Format:    regexp.MustCompile(`^[0-9]{9}$`),
+		Validate:  validateSSN,
+		Canonical: func(number string) string { return number[:3] + "-" + number[3:5] + "-" + number[5:] },
+	},
+}
+
+// NationalID returns the national identification number in its canonical form or an error if
+// it is not valid for the country. The number is uppercased and stripped of spaces and
+// punctuation, the built-in countries are:
+//
+//	ES: DNI (12345678Z) and NIE (X1234567L) with the check letter
+//	GB: National Insurance number (AB 12 34 56 C => AB123456C), UK is accepted as well
+//	US: Social Security number (123456789 => 123-45-6789)
+//
+// Other countries can be supported by adding a NationalIDRule to NationalIDRules.
+//
+//	View examples: nationalid_test.go
+func NationalID(original string, country string) (string, error) {
+	number := removeMatches(vatStripRegExp, strings.ToUpper(original))
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == "UK" {
+		country = "GB"
+	}
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %q %s", ErrInvalidNationalID, original, reason)
+	}
+
+	rule, ok := NationalIDRules[country]
+	if !ok {
+		return "", invalid("has an unsupported country")
+	}
+	if rule.Format != nil && !rule.Format.MatchString(number) {
+		return "", invalid("has an invalid format")
+	}
+	if rule.Validate != nil && !rule.Validate(number) {
+		return "", invalid("is not valid")
+	}
+	if rule.Canonical != nil {
+		number = rule.Canonical(number)
+	}
+	return number, nil
+}
+
+// validateSSN rejects the Social Security numbers that are never issued (area 000, 666 or
+// 900-999, group 00 or serial 0000)
+func validateSSN(number string) bool {
+	area, group, serial := number[:3], number[3:5], number[5:]
+	return area != "000" && area != "666" && area[0] != '9' && group != "00" && serial != "0000"
+}
+
+// validateUKNINumber rejects the National Insurance number prefixes that are not allocated
+func validateUKNINumber(number string) bool {
+	switch number[:2] {
+	case "BG", "GB", "KN", "NK", "NT", "TN", "ZZ":
+		return false
+	}
+	return true
+}
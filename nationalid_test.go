@@ -0,0 +1,108 @@
+package sanitize
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNationalID tests the NationalID method
+func TestNationalID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			country  string
+			expected string
+		}{
+			{"es dni", "12345678Z", "ES", "12345678Z"},
+			{"es dni punctuation", "12.345.678-z", "es", "12345678Z"},
+			{"es nie", "X1234567L", "ES", "X1234567L"},
+			{"es nie y", "y-1234567-x", "ES", "Y1234567X"},
+			{"gb ni number", "AB 12 34 56 C", "GB", "AB123456C"},
+			{"gb ni number lowercase", "ab123456d", "gb", "AB123456D"},
+			{"uk alias", "AB123456C", " UK ", "AB123456C"},
+			{"us ssn", "123456789", "US", "123-45-6789"},
+			{"us ssn formatted", " 123-45-6789 ", "us", "123-45-6789"},
+			{"us ssn spaces", "078 05 1120", "US", "078-05-1120"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := NationalID(test.input, test.country)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name    string
+			input   string
+			country string
+		}{
+			{"empty", "", "US"},
+			{"unsupported country", "123456789", "FR"},
+			{"empty country", "123456789", ""},
+			{"es check letter", "12345678A", "ES"},
+			{"es nie check letter", "X1234567A", "ES"},
+			{"es cif", "A58818501", "ES"},
+			{"es too short", "1234567Z", "ES"},
+			{"gb first letter", "DA123456C", "GB"},
+			{"gb second letter", "AO123456C", "GB"},
+			{"gb prefix", "GB123456C", "GB"},
+			{"gb suffix", "AB123456E", "GB"},
+			{"us too short", "12345678", "US"},
+			{"us area 000", "000-12-3456", "US"},
+			{"us area 666", "666-12-3456", "US"},
+			{"us area 9xx", "900-12-3456", "US"},
+			{"us group 00", "123-00-4567", "US"},
+			{"us serial 0000", "123-45-0000", "US"},
+			{"us letters", "12345678O", "US"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := NationalID(test.input, test.country)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidNationalID)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+
+	t.Run("custom rule", func(t *testing.T) {
+		rule := NationalIDRule{Format: regexp.MustCompile(`^[0-9]{11}$`), Validate: checksumLuhn}
+		NationalIDRules["ZZ"] = rule
+		defer delete(NationalIDRules, "ZZ")
+
+		output, err := NationalID("12345 67890 3", "ZZ")
+		require.NoError(t, err)
+		assert.Equal(t, "12345678903", output)
+
+		_, err = NationalID("12345678904", "ZZ")
+		assert.ErrorIs(t, err, ErrInvalidNationalID)
+	})
+}
+
+// BenchmarkNationalID benchmarks the NationalID method
+func BenchmarkNationalID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = NationalID("AB 12 34 56 C", "GB")
+	}
+}
+
+// ExampleNationalID example using NationalID()
+func ExampleNationalID() {
+	fmt.Println(NationalID("123 45 6789", "US"))
+	fmt.Println(NationalID("ab 12 34 56 c", "UK"))
+	// Output:
+	// 123-45-6789 <nil>
+	// AB123456C <nil>
+}
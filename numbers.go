@@ -0,0 +1,464 @@
+package sanitize
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// numberTokenRegExp matches an integer or decimal number with an optional sign
+var numberTokenRegExp = regexp.MustCompile(`[-+]?([0-9]+(\.[0-9]+)?|\.[0-9]+)`)
+
+// decimalCommaLanguages are the languages that use a comma as the decimal separator (CLDR)
+var decimalCommaLanguages = map[string]bool{
+	"af": true, "az": true, "be": true, "bg": true, "bs": true, "ca": true, "cs": true, "da": true,
+	"de": true, "el": true, "es": true, "et": true, "eu": true, "fi": true, "fo": true, "fr": true,
+	"gl": true, "hr": true, "hu": true, "hy": true, "id": true, "is": true, "it": true, "ka": true,
+	"kk": true, "ky": true, "lt": true, "lv": true, "mk": true, "mn": true, "nb": true, "nl": true,
+	"nn": true, "no": true, "pl": true, "pt": true, "ro": true, "ru": true, "sk": true, "sl": true,
+	"sq": true, "sr": true, "sv": true, "tr": true, "uk": true, "uz": true, "vi": true,
+}
+
+// decimalSeparatorRegions are the language and region pairs that use a different decimal
+// separator than their language
+var decimalSeparatorRegions = map[string]byte{
+	"de-CH": '.', "de-LI": '.', "en-ZA": ',', "es-DO": '.', "es-GT": '.', "es-HN": '.', "es-MX": '.',
+	"es-NI": '.', "es-PA": '.', "es-PE": '.', "es-PR": '.', "es-SV": '.', "es-US": '.', "it-CH": '.',
+}
+
+// ordinalRegExp matches a number followed by an english ordinal suffix (1st, 2nd, 3rd, 4th)
+var ordinalRegExp = regexp.MustCompile(`(?i)\b([0-9]+)(?:st|nd|rd|th)\b`)
+
+// floatRegExp matches a well-formed decimal or scientific notation number
+var floatRegExp = regexp.MustCompile(`[+-]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?`)
+
+// romanNumeralRegExp is the standard (subtractive) form of the roman numerals 1 to 3999
+var romanNumeralRegExp = regexp.MustCompile(`^M{0,3}(CM|CD|D?C{0,3})(XC|XL|L?X{0,3})(IX|IV|V?I{0,3})$`)
+
+// percentUnits are the percentage units with the number of units in a ratio of 1 (longest first)
+var percentUnits = []struct {
+	suffix string
+	scale  float64
+}{
+	{"basis points", 10000}, {"basis point", 10000}, {"per mille", 1000}, {"percent", 100},
+	{"bps", 10000}, {"pct", 100}, {"bp", 10000}, {"%", 100}, {"\uFF05", 100}, {"\u2030", 1000},
+}
+
+// romanValues are the values of the roman numeral symbols
+var romanValues = map[byte]int{'I': 1, 'V': 5, 'X': 10, 'L': 50, 'C': 100, 'D': 500, 'M': 1000}
+
+// FoldDigits converts the decimal digits of every Unicode script (Arabic-Indic, Devanagari,
+// fullwidth, etc.) to the ASCII digits 0-9, all other characters are kept as-is.
+//
+//	View examples: numbers_test.go
+func FoldDigits(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(foldDigits(original))
+}
+
+// foldDigits converts the Unicode decimal digits to ASCII (returns the original if there are none)
+func foldDigits(original string) string {
+	var b *strings.Builder
+	for i, r := range original {
+		if r < utf8.RuneSelf {
+			if b != nil {
+				b.WriteRune(r)
+			}
+			continue
+		}
+		digit, ok := digitValue(r)
+		if !ok {
+			if b != nil {
+				b.WriteRune(r)
+			}
+			continue
+		}
+		if b == nil {
+			b = new(strings.Builder)
+			b.Grow(len(original))
+			b.WriteString(original[:i])
+		}
+		b.WriteByte('0' + byte(digit))
+	}
+	if b == nil {
+		return original
+	}
+	return b.String()
+}
+
+// digitValue returns the value of a Unicode decimal digit, the digits of each script are
+// a contiguous run starting at zero so the value is the offset within its range
+func digitValue(r rune) (int, bool) {
+	if !unicode.IsDigit(r) {
+		return 0, false
+	}
+	for _, rng := range unicode.Nd.R16 {
+		if r >= rune(rng.Lo) && r <= rune(rng.Hi) {
+			return int(r-rune(rng.Lo)) % 10, true
+		}
+	}
+	for _, rng := range unicode.Nd.R32 {
+		if r >= rune(rng.Lo) && r <= rune(rng.Hi) {
+			return int(r-rune(rng.Lo)) % 10, true
+		}
+	}
+	return 0, false
+}
+
+// Ordinals removes the english ordinal suffixes from numbers (3rd floor => 3 floor,
+// 21ST => 21), the suffix is removed even if it does not match the number (3th => 3).
+//
+//	View examples: numbers_test.go
+func Ordinals(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(ordinalRegExp.ReplaceAllString(original, "$1"))
+}
+
+// RomanNumeral returns the value of the roman numeral (VIII => 8, mcmxcix => 1999) or an
+// error if it is not a numeral in the standard form between 1 (I) and 3999 (MMMCMXCIX).
+//
+//	View examples: numbers_test.go
+func RomanNumeral(original string) (int, error) {
+	numeral := strings.ToUpper(strings.TrimSpace(original))
+	if len(numeral) == 0 || !romanNumeralRegExp.MatchString(numeral) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidRomanNumeral, original)
+	}
+
+	value := 0
+	for i := 0; i < len(numeral); i++ {
+		current := romanValues[numeral[i]]
+		if i+1 < len(numeral) && current < romanValues[numeral[i+1]] {
+			value -= current
+		} else {
+			value += current
+		}
+	}
+	return value, nil
+}
+
+// Percent returns the percentage as a ratio ("12.5 %" => 0.125) or an error if it is not a valid
+// percentage. The units %, percent, pct, per mille (‰), bps and basis points are supported
+// and a number without a unit is a percentage. Both "." and "," are accepted as the decimal
+// separator (a single "," followed by 3 digits is a thousands separator: "1,250 bps"). Use
+// PercentInRange to validate the bounds of the ratio.
+//
+//	View examples: numbers_test.go
+func Percent(original string, opts ...Option) (float64, error) {
+	o := newOptions(opts)
+	if err := o.checkSize(original); err != nil {
+		return 0, err
+	}
+	original = o.before(original)
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %q %s", ErrInvalidPercent, original, reason)
+	}
+
+	// Remove the unit
+	value, scale := strings.TrimSpace(foldDigits(original)), 100.0
+	for _, unit := range percentUnits {
+		if len(value) >= len(unit.suffix) && strings.EqualFold(value[len(value)-len(unit.suffix):], unit.suffix) {
+			value, scale = value[:len(value)-len(unit.suffix)], unit.scale
+			break
+		}
+	}
+
+	// Only a sign, digits, separators and white space are allowed
+	number := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r):
+			return -1
+		case r == '\u2212':
+			return '-'
+		}
+		return r
+	}, value)
+	if len(number) == 0 || strings.TrimLeft(number, "+-0123456789.,") != "" || strings.LastIndexAny(number, "+-") > 0 {
+		return 0, invalid("is not a number")
+	}
+
+	// Thousands and decimal separators
+	switch last := strings.LastIndexAny(number, ".,"); {
+	case last < 0:
+	case strings.Count(number, ".") > 0 && strings.Count(number, ",") > 0:
+		number = strings.NewReplacer(".", "", ",", "").Replace(number[:last]) + "." + number[last+1:]
+	case strings.Count(number, string(number[last])) > 1, number[last] == ',' && len(number)-last-1 == 3:
+		number = strings.NewReplacer(".", "", ",", "").Replace(number)
+	default:
+		number = strings.Replace(number, ",", ".", 1)
+	}
+
+	percent, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, invalid("is not a number")
+	}
+	return percent / scale, nil
+}
+
+// PercentInRange returns the percentage as a ratio like Percent, an error is returned if the
+// ratio is not between lower and upper (inclusive) or the range is invalid. Use 0 and 1 for
+// 0% to 100% ("150%" => error).
+//
+//	View examples: numbers_test.go
+func PercentInRange(original string, lower, upper float64, opts ...Option) (float64, error) {
+	if lower > upper {
+		return 0, fmt.Errorf("%w: invalid range %g to %g", ErrInvalidPercent, lower, upper)
+	}
+	ratio, err := Percent(original, opts...)
+	if err != nil {
+		return 0, err
+	}
+	if ratio < lower || ratio > upper {
+		return 0, fmt.Errorf("%w: %q is not between %g and %g", ErrInvalidPercent, original, lower, upper)
+	}
+	return ratio, nil
+}
+
+// ScientificNotationStrict returns the first well-formed decimal or scientific notation number
+// in the string ("value: 6.02e23 mol" => 6.02e23, "1.5EUR" => 1.5) or an error if there is
+// none. Unlike ScientificNotation the result can always be parsed (1e2e3, 1.2.3e4 and +- are
+// skipped), a sign directly after a letter or digit is a separator like ExtractDecimal
+// (price-10 => 10). Use WithCanonicalFloat to format the result with strconv (6.02e23 =>
+// 6.02e+23). Numbers outside of the WithRange option are rejected.
+//
+//	View examples: numbers_test.go
+func ScientificNotationStrict(original string, opts ...Option) (string, error) {
+	o := newOptions(opts)
+	if err := o.checkSize(original); err != nil {
+		return "", err
+	}
+	original = o.before(original)
+
+	for _, loc := range floatRegExp.FindAllStringIndex(original, -1) {
+		start, end := loc[0], loc[1]
+
+		// A sign that follows a letter or digit is a separator (price-10)
+		if c := original[start]; (c == '-' || c == '+') && start > 0 && isAlphaNumericASCII(original[start-1:start]) {
+			start++
+		}
+		if !isFloatBoundary(original, start, end) {
+			continue
+		}
+		token := original[start:end]
+		value, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return "", fmt.Errorf("%w: %q is out of range", ErrInvalidNumber, token)
+		}
+		if o != nil && o.rangeSet && (value < o.rangeMin || value > o.rangeMax) {
+			return "", fmt.Errorf("%w: %q is not between %g and %g", ErrInvalidNumber, token, o.rangeMin, o.rangeMax)
+		}
+		if o != nil && o.canonicalFloat {
+			token = strconv.FormatFloat(value, 'g', -1, 64)
+		}
+		return o.after(token), nil
+	}
+	return "", fmt.Errorf("%w: %q does not contain a well-formed number", ErrInvalidNumber, original)
+}
+
+// isFloatBoundary returns true if the number from start to end is not part of a malformed
+// number (1.2.3, 1e2e3, 1e, e10), it can be next to a word (1.5EUR, 3.5em) or a period
+func isFloatBoundary(original string, start, end int) bool {
+	digit := func(i int) bool { return i >= 0 && i < len(original) && original[i] >= '0' && original[i] <= '9' }
+	letter := func(i int) bool { return i >= 0 && i < len(original) && isASCIILetter(original[i]) }
+	if start > 0 {
+		switch c := original[start-1]; {
+		case digit(start - 1), c == '.':
+			return false
+		case (c == 'e' || c == 'E') && !letter(start-2): // An exponent without a number
+			return false
+		}
+	}
+	if end < len(original) {
+		switch c := original[end]; {
+		case digit(end), c == '.' && digit(end+1):
+			return false
+		case (c == 'e' || c == 'E') && !letter(end+1): // An exponent without digits
+			return false
+		}
+	}
+	return true
+}
+
+// ExtractDecimal returns the first integer or decimal number in the string ("(123) 456-7890"
+// => 123, "Total: -12.50 USD" => -12.50) or an empty string if there is none. Unlike Decimal
+// the digits of separate numbers are never joined together, a sign directly after a letter
+// or digit is a separator (1-2-3 => 1). Thousands separators are not supported (1,234 => 1).
+//
+//	View examples: numbers_test.go
+func ExtractDecimal(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	if numbers := extractNumbers(original, false, 1); len(numbers) > 0 {
+		return o.after(o.clamp(numbers[0]))
+	}
+	return ""
+}
+
+// ExtractAllDecimals returns all the integer and decimal numbers in the string, see ExtractDecimal.
+//
+//	View examples: numbers_test.go
+func ExtractAllDecimals(original string) []string {
+	return extractNumbers(original, false, -1)
+}
+
+// ExtractInteger returns the first integer in the string ("(123) 456-7890" => 123) or an empty
+// string if there is none, decimal numbers are skipped ("1.5 or 2" => 2). See ExtractDecimal.
+//
+//	View examples: numbers_test.go
+func ExtractInteger(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	if numbers := extractNumbers(original, true, 1); len(numbers) > 0 {
+		return o.after(o.clamp(numbers[0]))
+	}
+	return ""
+}
+
+// ExtractAllIntegers returns all the integers in the string, see ExtractInteger.
+//
+//	View examples: numbers_test.go
+func ExtractAllIntegers(original string) []string {
+	return extractNumbers(original, true, -1)
+}
+
+// extractNumbers returns up to limit (-1 for all) number tokens, optionally only integers
+func extractNumbers(original string, integers bool, limit int) []string {
+	var numbers []string
+	for _, loc := range numberTokenRegExp.FindAllStringIndex(original, -1) {
+		start, end := loc[0], loc[1]
+
+		// A sign that follows a letter or digit is a separator (1-2-3, a-1)
+		if c := original[start]; (c == '-' || c == '+') && start > 0 && isAlphaNumericASCII(original[start-1:start]) {
+			start++
+		}
+		if integers && strings.IndexByte(original[start:end], '.') >= 0 {
+			continue
+		}
+		numbers = append(numbers, original[start:end])
+		if len(numbers) == limit {
+			break
+		}
+	}
+	return numbers
+}
+
+// DecimalLocale returns the number formatted for the locale (a BCP 47 language tag) as a plain
+// decimal with a "." separator and no thousands separators ("1.234,56" in de-DE => 1234.56,
+// "1'234.56" in de-CH => 1234.56) or an error if the locale or number is invalid. Currency
+// symbols, letters and white space are removed like Decimal, an error is returned if there
+// is more than one decimal separator, a thousands separator after the decimal separator or
+// a thousands separator that is not followed by exactly 3 digits ("1.5" in de => error).
+//
+//	View examples: numbers_test.go
+func DecimalLocale(original, locale string) (string, error) {
+	tag, err := LanguageTag(locale)
+	if err != nil {
+		return "", err
+	}
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %q %s", ErrInvalidNumber, original, reason)
+	}
+
+	// Decimal separator of the language (or language and region)
+	subtags := strings.Split(tag, "-")
+	separator, grouping := byte('.'), byte(',')
+	if decimalCommaLanguages[subtags[0]] {
+		separator, grouping = ',', '.'
+	}
+	for _, subtag := range subtags[1:] {
+		if regional, ok := decimalSeparatorRegions[subtags[0]+"-"+subtag]; ok && regional != separator {
+			separator, grouping = regional, separator
+		}
+	}
+
+	// A thousands separator must be followed by exactly 3 digits (group is -1 outside of a group)
+	var b strings.Builder
+	b.Grow(len(original))
+	decimal, digits, group := false, 0, -1
+	for _, r := range foldDigits(original) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+			digits++
+			if group >= 0 {
+				group++
+			}
+		case r == rune(separator):
+			if decimal {
+				return "", invalid("has more than one decimal separator")
+			}
+			if group >= 0 && group != 3 {
+				return "", invalid("has a thousands separator that is not followed by 3 digits")
+			}
+			decimal, group = true, -1
+			b.WriteByte('.')
+		case r == rune(grouping) || r == '\'' || r == '\u2019':
+			if decimal {
+				return "", invalid("has a thousands separator after the decimal separator")
+			}
+			if digits == 0 || (group >= 0 && group != 3) {
+				return "", invalid("has a thousands separator that is not followed by 3 digits")
+			}
+			group = 0
+		case (r == '-' || r == '\u2212') && digits == 0 && b.Len() == 0:
+			b.WriteByte('-')
+		}
+	}
+	if digits == 0 {
+		return "", invalid("does not contain any digits")
+	}
+	if group >= 0 && group != 3 {
+		return "", invalid("has a thousands separator that is not followed by 3 digits")
+	}
+	return b.String(), nil
+}
+
+// ClampInt returns the first integer in the string (see ExtractInteger) clamped to the range
+// lower to upper (inclusive), "page=500" with a range of 1 to 100 => 100. An error is returned
+// if there is no integer or the range is invalid, integers that overflow an int64 are clamped.
+//
+//	View examples: numbers_test.go
+func ClampInt(original string, lower, upper int64) (int64, error) {
+	if lower > upper {
+		return 0, fmt.Errorf("%w: invalid range %d to %d", ErrInvalidNumber, lower, upper)
+	}
+	numbers := extractNumbers(foldDigits(original), true, 1)
+	if len(numbers) == 0 {
+		return 0, fmt.Errorf("%w: %q does not contain an integer", ErrInvalidNumber, original)
+	}
+
+	value, err := strconv.ParseInt(numbers[0], 10, 64)
+	if err != nil { // Out of range of an int64
+		if numbers[0][0] == '-' {
+			return lower, nil
+		}
+		return upper, nil
+	}
+	if value < lower {
+		return lower, nil
+	} else if value > upper {
+		return upper, nil
+	}
+	return value, nil
+}
+
+// clamp applies the WithRange option to a number returned by a numeric sanitizer
+func (o *options) clamp(number string) string {
+	if o == nil || !o.rangeSet || len(number) == 0 {
+		return number
+	}
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return number
+	}
+	if value < o.rangeMin {
+		return strconv.FormatFloat(o.rangeMin, 'f', -1, 64)
+	} else if value > o.rangeMax {
+		return strconv.FormatFloat(o.rangeMax, 'f', -1, 64)
+	}
+	return number
+}
@@ -0,0 +1,705 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFoldDigits tests the FoldDigits method
+func TestFoldDigits(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"ascii", "abc 123", "abc 123"},
+		{"arabic-indic", "\u0661\u0662\u0663", "123"},
+		{"extended arabic-indic", "\u06F4\u06F5", "45"},
+		{"devanagari", "\u0969\u096F", "39"},
+		{"fullwidth", "\uFF10\uFF17", "07"},
+		{"bengali", "\u09E6\u09EF", "09"},
+		{"mathematical bold", "\U0001D7CF\U0001D7D8", "10"},
+		{"mathematical monospace", "\U0001D7F6\U0001D7FF", "09"},
+		{"mixed", "price: \u0661\u0662.5 USD", "price: 12.5 USD"},
+		{"superscript is not a decimal digit", "x\u00B2", "x\u00B2"},
+		{"letters kept", "\u00E9t\u00E9 \uFF11", "\u00E9t\u00E9 1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, FoldDigits(test.input))
+		})
+	}
+
+	t.Run("numeric option", func(t *testing.T) {
+		assert.Equal(t, "123", Numeric("\u0661\u0662\u0663", WithFoldDigits()))
+		assert.Equal(t, "", Numeric("\u0661\u0662\u0663"))
+	})
+
+	t.Run("decimal option", func(t *testing.T) {
+		assert.Equal(t, "-12.50", Decimal("-\uFF11\uFF12.\uFF15\uFF10", WithFoldDigits()))
+	})
+}
+
+// BenchmarkFoldDigits benchmarks the FoldDigits method
+func BenchmarkFoldDigits(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = FoldDigits("price: \u0661\u0662\u0663.\u0664\u0665")
+	}
+}
+
+// BenchmarkFoldDigits_ASCII benchmarks the FoldDigits method (nothing to fold)
+func BenchmarkFoldDigits_ASCII(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = FoldDigits("price: 123.45")
+	}
+}
+
+// ExampleFoldDigits example using FoldDigits()
+func ExampleFoldDigits() {
+	fmt.Println(FoldDigits("\u0661\u0662\u0663 \uFF14\uFF15\uFF16"))
+	fmt.Println(Numeric("\u0967\u0968\u0969", WithFoldDigits()))
+	// Output:
+	// 123 456
+	// 123
+}
+
+// TestOrdinals tests the Ordinals method
+func TestOrdinals(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"no ordinals", "Floor 3", "Floor 3"},
+		{"first", "1st", "1"},
+		{"second", "2nd place", "2 place"},
+		{"third", "3rd floor", "3 floor"},
+		{"fourth", "4th", "4"},
+		{"teens", "11th 12th 13th", "11 12 13"},
+		{"uppercase", "21ST Century", "21 Century"},
+		{"wrong suffix", "3th", "3"},
+		{"punctuation", "May 5th, 2024", "May 5, 2024"},
+		{"hyphenated", "2nd-floor", "2-floor"},
+		{"not a suffix", "5stars 4three", "5stars 4three"},
+		{"letters before", "A1st", "A1st"},
+		{"words", "the first and north", "the first and north"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Ordinals(test.input))
+		})
+	}
+}
+
+// BenchmarkOrdinals benchmarks the Ordinals method
+func BenchmarkOrdinals(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Ordinals("Suite 200, 3rd floor")
+	}
+}
+
+// ExampleOrdinals example using Ordinals()
+func ExampleOrdinals() {
+	fmt.Println(Ordinals("Suite 200, 3rd floor"))
+	// Output: Suite 200, 3 floor
+}
+
+// TestRomanNumeral tests the RomanNumeral method
+func TestRomanNumeral(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected int
+		}{
+			{"one", "I", 1},
+			{"four", "IV", 4},
+			{"eight", "VIII", 8},
+			{"nine", "IX", 9},
+			{"fourteen", "XIV", 14},
+			{"forty", "XL", 40},
+			{"ninety", "XC", 90},
+			{"four hundred", "CD", 400},
+			{"lowercase", "mcmxcix", 1999},
+			{"mixed case", "MmXxIv", 2024},
+			{"spaces", " XII ", 12},
+			{"maximum", "MMMCMXCIX", 3999},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := RomanNumeral(test.input)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"spaces", "   "},
+			{"digits", "12"},
+			{"too many repeats", "IIII"},
+			{"not subtractive form", "IC"},
+			{"repeated subtraction", "IIX"},
+			{"too large", "MMMM"},
+			{"other letters", "XIZ"},
+			{"name", "Henry VIII"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := RomanNumeral(test.input)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidRomanNumeral)
+				assert.Equal(t, 0, output)
+			})
+		}
+	})
+}
+
+// BenchmarkRomanNumeral benchmarks the RomanNumeral method
+func BenchmarkRomanNumeral(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = RomanNumeral("MCMXCIX")
+	}
+}
+
+// ExampleRomanNumeral example using RomanNumeral()
+func ExampleRomanNumeral() {
+	value, err := RomanNumeral("viii")
+	fmt.Println(value, err)
+	// Output: 8 <nil>
+}
+
+// TestPercent tests the Percent method
+func TestPercent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			expected float64
+		}{
+			{"percent sign", "12.5%", 0.125},
+			{"percent with space", "12.5 %", 0.125},
+			{"decimal comma", "12,5%", 0.125},
+			{"no unit", "50", 0.5},
+			{"word", "75 Percent", 0.75},
+			{"pct", "3pct", 0.03},
+			{"fullwidth", "\uFF15\uFF10\uFF05", 0.5},
+			{"per mille", "5\u2030", 0.005},
+			{"basis points", "1250 bps", 0.125},
+			{"basis points thousands", "1,250 bps", 0.125},
+			{"basis point", "1 bp", 0.0001},
+			{"basis points words", "25 Basis Points", 0.0025},
+			{"negative", "-2.5%", -0.025},
+			{"unicode minus", "\u22122.5%", -0.025},
+			{"positive sign", "+10%", 0.1},
+			{"thousands and decimal", "1.234,5%", 12.345},
+			{"surrounding spaces", "  10 %  ", 0.1},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := Percent(test.input)
+				require.NoError(t, err)
+				assert.InDelta(t, test.expected, output, 1e-12)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"unit only", "%"},
+			{"text", "ten percent"},
+			{"letters", "12a%"},
+			{"sign in the middle", "1-2%"},
+			{"two signs", "--5%"},
+			{"infinity", "inf%"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := Percent(test.input)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidPercent)
+				assert.Zero(t, output)
+			})
+		}
+	})
+}
+
+// BenchmarkPercent benchmarks the Percent method
+func BenchmarkPercent(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = Percent("12,5 %")
+	}
+}
+
+// ExamplePercent example using Percent()
+func ExamplePercent() {
+	fmt.Println(Percent("12,5 %"))
+	fmt.Println(Percent("1250 bps"))
+	// Output:
+	// 0.125 <nil>
+	// 0.125 <nil>
+}
+
+// TestPercentInRange tests the PercentInRange method
+func TestPercentInRange(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		lower    float64
+		upper    float64
+		expected float64
+		err      bool
+	}{
+		{"in range", "50%", 0, 1, 0.5, false},
+		{"lower bound inclusive", "0%", 0, 1, 0, false},
+		{"upper bound inclusive", "100%", 0, 1, 1, false},
+		{"basis points", "25 bps", 0, 0.01, 0.0025, false},
+		{"above range", "150%", 0, 1, 0, true},
+		{"below range", "-1%", 0, 1, 0, true},
+		{"invalid percentage", "ten percent", 0, 1, 0, true},
+		{"invalid range", "50%", 1, 0, 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, err := PercentInRange(test.input, test.lower, test.upper)
+			if test.err {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidPercent)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.InDelta(t, test.expected, output, 1e-12)
+		})
+	}
+}
+
+// BenchmarkPercentInRange benchmarks the PercentInRange method
+func BenchmarkPercentInRange(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = PercentInRange("12,5 %", 0, 1)
+	}
+}
+
+// ExamplePercentInRange example using PercentInRange()
+func ExamplePercentInRange() {
+	fmt.Println(PercentInRange("50%", 0, 1))
+	fmt.Println(PercentInRange("150%", 0, 1))
+	// Output:
+	// 0.5 <nil>
+	// 0 invalid percentage: "150%" is not between 0 and 1
+}
+
+// TestScientificNotationStrict tests the ScientificNotationStrict method
+func TestScientificNotationStrict(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			opts     []Option
+			expected string
+		}{
+			{"integer", "42", nil, "42"},
+			{"decimal", "-3.14", nil, "-3.14"},
+			{"leading dot", ".5", nil, ".5"},
+			{"trailing dot", "5.", nil, "5."},
+			{"scientific", "6.02e23", nil, "6.02e23"},
+			{"uppercase exponent", "1.5E-10", nil, "1.5E-10"},
+			{"signed exponent", "+1e+3", nil, "+1e+3"},
+			{"surrounding text", "value: 6.02e23 mol", nil, "6.02e23"},
+			{"first number", "1.5 and 2.5", nil, "1.5"},
+			{"skips malformed", "1e2e3 then 7", nil, "7"},
+			{"skips two dots", "x 1.2.3e4 7", nil, "7"},
+			{"currency code", "1.5EUR", nil, "1.5"},
+			{"unit", "width 3.5em", nil, "3.5"},
+			{"sign after letter", "price-10", nil, "10"},
+			{"sign after space", "price -10", nil, "-10"},
+			{"end of sentence", "It is 1.5.", nil, "1.5"},
+			{"exponent before letters", "1e5kg", nil, "1e5"},
+			{"canonical", "6.02E23", []Option{WithCanonicalFloat()}, "6.02e+23"},
+			{"canonical trailing zeros", "1.50", []Option{WithCanonicalFloat()}, "1.5"},
+			{"canonical plus sign", "+0.5", []Option{WithCanonicalFloat()}, "0.5"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := ScientificNotationStrict(test.input, test.opts...)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+		}{
+			{"empty", ""},
+			{"text", "no numbers here"},
+			{"double exponent", "1e2e3"},
+			{"signs only", "+-"},
+			{"two dots", "1.2.3e4"},
+			{"two dots and exponent", "1.2e3.4"},
+			{"exponent only", "e10"},
+			{"missing exponent digits", "1e"},
+			{"dot only", "."},
+			{"out of range", "1e999"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := ScientificNotationStrict(test.input)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidNumber)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkScientificNotationStrict benchmarks the ScientificNotationStrict method
+func BenchmarkScientificNotationStrict(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ScientificNotationStrict("value: 6.02e23 mol")
+	}
+}
+
+// ExampleScientificNotationStrict example using ScientificNotationStrict()
+func ExampleScientificNotationStrict() {
+	fmt.Println(ScientificNotationStrict("value: 6.02E23 mol"))
+	fmt.Println(ScientificNotationStrict("value: 6.02E23 mol", WithCanonicalFloat()))
+	fmt.Println(ScientificNotationStrict("1.2.3e4"))
+	// Output:
+	// 6.02E23 <nil>
+	// 6.02e+23 <nil>
+	//  invalid number: "1.2.3e4" does not contain a well-formed number
+}
+
+// TestExtractDecimal tests the ExtractDecimal and ExtractAllDecimals methods
+func TestExtractDecimal(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"empty", "", nil},
+		{"no numbers", "none", nil},
+		{"integer", "42", []string{"42"}},
+		{"phone number", "(123) 456-7890", []string{"123", "456", "7890"}},
+		{"hyphenated", "1-2-3", []string{"1", "2", "3"}},
+		{"negative", "Total: -12.50 USD", []string{"-12.50"}},
+		{"positive sign", "+5 and -6", []string{"+5", "-6"}},
+		{"leading dot", "ratio .5", []string{".5"}},
+		{"trailing dot", "costs 5.", []string{"5"}},
+		{"letters", "a-1 b2", []string{"1", "2"}},
+		{"thousands", "1,234.5", []string{"1", "234.5"}},
+		{"mixed", "between 1.5 and 2", []string{"1.5", "2"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ExtractAllDecimals(test.input))
+			expected := ""
+			if len(test.expected) > 0 {
+				expected = test.expected[0]
+			}
+			assert.Equal(t, expected, ExtractDecimal(test.input))
+		})
+	}
+}
+
+// BenchmarkExtractDecimal benchmarks the ExtractDecimal method
+func BenchmarkExtractDecimal(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = ExtractDecimal("Total: -12.50 USD")
+	}
+}
+
+// ExampleExtractDecimal example using ExtractDecimal()
+func ExampleExtractDecimal() {
+	fmt.Println(ExtractDecimal("Total: -12.50 USD"))
+	fmt.Println(ExtractAllDecimals("(123) 456-7890"))
+	// Output:
+	// -12.50
+	// [123 456 7890]
+}
+
+// TestExtractInteger tests the ExtractInteger and ExtractAllIntegers methods
+func TestExtractInteger(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"empty", "", nil},
+		{"integer", "42", []string{"42"}},
+		{"phone number", "(123) 456-7890", []string{"123", "456", "7890"}},
+		{"hyphenated", "1-2-3", []string{"1", "2", "3"}},
+		{"negative", "-7 degrees", []string{"-7"}},
+		{"skips decimals", "1.5 or 2", []string{"2"}},
+		{"decimals only", "1.5 or 2.5", nil},
+		{"version", "v10 build 7", []string{"10", "7"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ExtractAllIntegers(test.input))
+			expected := ""
+			if len(test.expected) > 0 {
+				expected = test.expected[0]
+			}
+			assert.Equal(t, expected, ExtractInteger(test.input))
+		})
+	}
+}
+
+// BenchmarkExtractInteger benchmarks the ExtractInteger method
+func BenchmarkExtractInteger(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = ExtractInteger("(123) 456-7890")
+	}
+}
+
+// ExampleExtractInteger example using ExtractInteger()
+func ExampleExtractInteger() {
+	fmt.Println(ExtractInteger("(123) 456-7890"))
+	fmt.Println(ExtractAllIntegers("1-2-3"))
+	// Output:
+	// 123
+	// [1 2 3]
+}
+
+// TestDecimalLocale tests the DecimalLocale method
+func TestDecimalLocale(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			locale   string
+			expected string
+		}{
+			{"english", "1,234.56", "en-US", "1234.56"},
+			{"english language only", "$1,234,567.89", "en", "1234567.89"},
+			{"german", "1.234,56", "de-DE", "1234.56"},
+			{"german currency", "1.234,56 \u20ac", "de", "1234.56"},
+			{"german negative", "-1.234,5", "de", "-1234.5"},
+			{"french spaces", "1\u202f234,56", "fr-FR", "1234.56"},
+			{"swiss german", "1'234.56", "de-CH", "1234.56"},
+			{"swiss german typographic", "1\u2019234.56", "de_ch", "1234.56"},
+			{"spanish", "1.234,56", "es-ES", "1234.56"},
+			{"mexican spanish", "1,234.56", "es-MX", "1234.56"},
+			{"brazilian", "R$ 1.234,56", "pt-BR", "1234.56"},
+			{"south african english", "1 234,56", "en-ZA", "1234.56"},
+			{"script subtag", "1.234,56", "sr-Latn-RS", "1234.56"},
+			{"integer", "1.234", "de", "1234"},
+			{"unicode minus", "\u22125,5", "sv", "-5.5"},
+			{"arabic-indic digits", "\u0661\u0662.\u0665", "en", "12.5"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := DecimalLocale(test.input, test.locale)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name   string
+			input  string
+			locale string
+			err    error
+		}{
+			{"empty", "", "en", ErrInvalidNumber},
+			{"no digits", "abc", "en", ErrInvalidNumber},
+			{"two decimal separators", "1,234,56", "de", ErrInvalidNumber},
+			{"grouping after decimal", "1.234,56", "en", ErrInvalidNumber},
+			{"short group", "1.5", "de", ErrInvalidNumber},
+			{"short group before decimal", "12,34", "en-US", ErrInvalidNumber},
+			{"short groups", "1,2,3", "en", ErrInvalidNumber},
+			{"long group", "1,2345.6", "en", ErrInvalidNumber},
+			{"empty group", "1,,234", "en", ErrInvalidNumber},
+			{"leading grouping", ",123", "en", ErrInvalidNumber},
+			{"short swiss group", "1'23.5", "de-CH", ErrInvalidNumber},
+			{"invalid locale", "1.5", "not a locale", ErrInvalidLanguageTag},
+			{"empty locale", "1.5", "", ErrInvalidLanguageTag},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := DecimalLocale(test.input, test.locale)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, test.err)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkDecimalLocale benchmarks the DecimalLocale method
+func BenchmarkDecimalLocale(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = DecimalLocale("1.234,56", "de-DE")
+	}
+}
+
+// ExampleDecimalLocale example using DecimalLocale()
+func ExampleDecimalLocale() {
+	fmt.Println(DecimalLocale("1.234,56", "de-DE"))
+	fmt.Println(DecimalLocale("1,234.56", "en-US"))
+	// Output:
+	// 1234.56 <nil>
+	// 1234.56 <nil>
+}
+
+// TestClampInt tests the ClampInt method
+func TestClampInt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			lower    int64
+			upper    int64
+			expected int64
+		}{
+			{"in range", "25", 1, 100, 25},
+			{"above range", "500", 1, 100, 100},
+			{"below range", "0", 1, 100, 1},
+			{"negative", "-5", -10, 10, -5},
+			{"negative below range", "-50", -10, 10, -10},
+			{"surrounding text", "page=7", 1, 100, 7},
+			{"spaces", "  42  ", 0, 100, 42},
+			{"overflow", "99999999999999999999", 0, 100, 100},
+			{"negative overflow", "-99999999999999999999", -100, 100, -100},
+			{"single value range", "5", 3, 3, 3},
+			{"fullwidth digits", "\uFF15\uFF10", 1, 100, 50},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := ClampInt(test.input, test.lower, test.upper)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+			lower int64
+			upper int64
+		}{
+			{"empty", "", 1, 100},
+			{"text", "all", 1, 100},
+			{"decimal", "2.5", 1, 100},
+			{"invalid range", "5", 10, 1},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := ClampInt(test.input, test.lower, test.upper)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidNumber)
+				assert.Equal(t, int64(0), output)
+			})
+		}
+	})
+}
+
+// BenchmarkClampInt benchmarks the ClampInt method
+func BenchmarkClampInt(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ClampInt("page=500", 1, 100)
+	}
+}
+
+// ExampleClampInt example using ClampInt()
+func ExampleClampInt() {
+	fmt.Println(ClampInt("500", 1, 100))
+	fmt.Println(ClampInt("25", 1, 100))
+	// Output:
+	// 100 <nil>
+	// 25 <nil>
+}
+
+// TestWithRange tests the WithRange option
+func TestWithRange(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		fn       func(string, ...Option) string
+		input    string
+		opt      Option
+		expected string
+	}{
+		{"numeric in range", Numeric, "age: 42", WithRange(0, 150), "42"},
+		{"numeric above range", Numeric, "age: 420", WithRange(0, 150), "150"},
+		{"decimal below range", Decimal, "-12.5", WithRange(0, 150), "0"},
+		{"decimal in range", Decimal, "12.5", WithRange(0, 150), "12.5"},
+		{"extract decimal", ExtractDecimal, "qty 0.25", WithRange(0.5, 10), "0.5"},
+		{"extract integer", ExtractInteger, "size=1000", WithRange(1, 100), "100"},
+		{"empty", Numeric, "none", WithRange(0, 150), ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.fn(test.input, test.opt))
+		})
+	}
+
+	t.Run("scientific notation strict", func(t *testing.T) {
+		output, err := ScientificNotationStrict("1e3", WithRange(0, 100))
+		require.ErrorIs(t, err, ErrInvalidNumber)
+		assert.Equal(t, "", output)
+
+		output, err = ScientificNotationStrict("1e2", WithRange(0, 100))
+		require.NoError(t, err)
+		assert.Equal(t, "1e2", output)
+	})
+}
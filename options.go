@@ -0,0 +1,488 @@
+package sanitize
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Option is a functional option that adjusts the output of a sanitizer.
+// Options can be passed to any of the string sanitizers and are applied
+// after the sanitizer has filtered the input.
+//
+//	View examples: options_test.go
+type Option func(*options)
+
+// caseMode is the casing applied to the sanitized output
+type caseMode int
+
+// Supported case modes
+const (
+	caseNone caseMode = iota
+	caseLower
+	caseUpper
+	caseTitle
+)
+
+// options holds the settings collected from a list of Option values
+type options struct {
+	asciiOnly           bool
+	canonicalFloat      bool
+	caseMode            caseMode
+	collapseSpaces      bool
+	dropped             func(Dropped)
+	foldDigits          bool
+	indentTabs          bool
+	ipVersion           int
+	isbn13              bool
+	keepANSIColors      bool
+	keepCDATA           bool
+	matchTimeout        time.Duration
+	maxInputBytes       int
+	maxLength           int
+	patternBudget       int
+	percentEncoding     bool
+	rangeMax            float64
+	rangeMin            float64
+	rangeSet            bool
+	rejectLoopback      bool
+	rejectPrivate       bool
+	removeTrailingSlash bool
+	schemeDefault       string
+	scriptTags          []string
+	sortQuery           bool
+	stripEmailDots      bool
+	stripEmailTag       bool
+	stripFragment       bool
+	stripQuery          bool
+	stripTracking       bool
+	stripTrackingPixels bool
+	tabWidth            int
+	trim                bool
+	unicodeEmail        bool
+}
+
+// newOptions builds the settings from the given options (nil if there are none)
+func newOptions(opts []Option) *options {
+	if len(opts) == 0 {
+		return nil
+	}
+	o := new(options)
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// before applies the pre-processing options to the original value
+func (o *options) before(value string) string {
+	if o == nil {
+		return value
+	}
+
+	if o.maxInputBytes > 0 && len(value) > o.maxInputBytes {
+		value = truncateBytes(value, o.maxInputBytes)
+	}
+	if o.asciiOnly {
+		value = toASCII(value, true)
+	}
+
+	return value
+}
+
+// checkSize returns ErrInputTooLarge if the value is larger than the maximum input size,
+// this is used by the sanitizers that return an error instead of truncating the input
+func (o *options) checkSize(value string) error {
+	if o != nil && o.maxInputBytes > 0 && len(value) > o.maxInputBytes {
+		return fmt.Errorf("%w: %d bytes (max %d)", ErrInputTooLarge, len(value), o.maxInputBytes)
+	}
+	return nil
+}
+
+// after applies the post-processing options to the sanitized value
+func (o *options) after(value string) string {
+	if o == nil {
+		return value
+	}
+
+	if o.collapseSpaces {
+		value = collapseSpaces(value)
+	}
+	if o.trim {
+		value = strings.TrimSpace(value)
+	}
+
+	switch o.caseMode {
+	case caseLower:
+		value = strings.ToLower(value)
+	case caseUpper:
+		value = strings.ToUpper(value)
+	case caseTitle:
+		value = TitleCase(value)
+	case caseNone:
+	}
+
+	return value
+}
+
+// WithLowercase forces the sanitized output to lowercase
+func WithLowercase() Option {
+	return func(o *options) {
+		o.caseMode = caseLower
+	}
+}
+
+// WithUppercase forces the sanitized output to uppercase
+func WithUppercase() Option {
+	return func(o *options) {
+		o.caseMode = caseUpper
+	}
+}
+
+// WithTitleCase converts the sanitized output to title case (see TitleCase)
+func WithTitleCase() Option {
+	return func(o *options) {
+		o.caseMode = caseTitle
+	}
+}
+
+// WithASCIIOnly transliterates the input to ASCII before it is sanitized,
+// runes without an ASCII equivalent are removed (see ASCII)
+func WithASCIIOnly() Option {
+	return func(o *options) {
+		o.asciiOnly = true
+	}
+}
+
+// WithCanonicalFloat formats the number with strconv (6.02E23 => 6.02e+23, 1.50 => 1.5),
+// this is only used by ScientificNotationStrict
+func WithCanonicalFloat() Option {
+	return func(o *options) {
+		o.canonicalFloat = true
+	}
+}
+
+// WithCollapseSpaces replaces every run of white space in the sanitized
+// output with a single space
+func WithCollapseSpaces() Option {
+	return func(o *options) {
+		o.collapseSpaces = true
+	}
+}
+
+// WithDropped calls fn for every dangerous construct that is removed (tag names, event
+// handlers, script protocols and XSS strings), so that security monitoring can alert when
+// an attack was neutralized. This is only used by HTML, Scripts, XSS and XSSWithPolicy.
+func WithDropped(fn func(Dropped)) Option {
+	return func(o *options) {
+		o.dropped = fn
+	}
+}
+
+// WithFoldDigits converts the Unicode digits (Arabic-Indic, fullwidth, etc.) to ASCII before
+// the input is filtered (see FoldDigits), this is only used by Numeric and Decimal
+func WithFoldDigits() Option {
+	return func(o *options) {
+		o.foldDigits = true
+	}
+}
+
+// WithIndentTabs indents with tabs instead of spaces, this is only used by Indentation
+func WithIndentTabs() Option {
+	return func(o *options) {
+		o.indentTabs = true
+	}
+}
+
+// WithIPv4Only only accepts ipv4 addresses (including ipv4-mapped ipv6 addresses),
+// this is only used by IPAddress
+func WithIPv4Only() Option {
+	return func(o *options) {
+		o.ipVersion = 4
+	}
+}
+
+// WithIPv6Only only accepts ipv6 addresses, this is only used by IPAddress
+func WithIPv6Only() Option {
+	return func(o *options) {
+		o.ipVersion = 6
+	}
+}
+
+// WithISBN13 converts ISBN-10 numbers to ISBN-13 (0306406152 => 9780306406157),
+// this is only used by ISBN
+func WithISBN13() Option {
+	return func(o *options) {
+		o.isbn13 = true
+	}
+}
+
+// WithKeepANSIColors keeps the color and style escape sequences (ESC [ 31 m) while the
+// other terminal escape sequences are removed, this is only used by ANSI
+func WithKeepANSIColors() Option {
+	return func(o *options) {
+		o.keepANSIColors = true
+	}
+}
+
+// WithKeepCDATA keeps the text of CDATA sections (<![CDATA[a & b]]> => a & b) instead of
+// removing the sections, HTML and XML still remove the markup inside a section. This is
+// only used by HTML, XML and HTMLToText
+func WithKeepCDATA() Option {
+	return func(o *options) {
+		o.keepCDATA = true
+	}
+}
+
+// WithMatchTimeout limits the time spent waiting for a pattern to match, ErrPatternTimeout is
+// returned if matching takes longer, this is only used by CustomSafe and CustomReplace. A
+// match can not be canceled, after the timeout it keeps running in a goroutine until it
+// finishes (in linear time), use WithMaxInputBytes to bound that work.
+func WithMatchTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.matchTimeout = d
+	}
+}
+
+// WithMaxInputBytes limits the size of the input before it is sanitized, this protects
+// services from spending unbounded time on attacker controlled input. Larger input is
+// truncated (on a rune boundary) by the string sanitizers, the sanitizers that return an
+// error (Domain, EmailStrict, NormalizeURL) return ErrInputTooLarge instead.
+func WithMaxInputBytes(n int) Option {
+	return func(o *options) {
+		o.maxInputBytes = n
+	}
+}
+
+// WithMaxLength limits the length of the result (in characters), this is only used by TruncateWords
+func WithMaxLength(n int) Option {
+	return func(o *options) {
+		o.maxLength = n
+	}
+}
+
+// WithPatternBudget sets the maximum number of instructions a pattern may compile to (the
+// default is 10000), this is only used by CustomSafe and CustomReplace
+func WithPatternBudget(n int) Option {
+	return func(o *options) {
+		o.patternBudget = n
+	}
+}
+
+// WithPercentEncoding validates and normalizes the percent-encoding of the url: a "%" that is
+// not followed by two hex digits is removed (%ZZ => ZZ, %%20 => %20), the hex digits are
+// uppercased and the allowed unreserved characters are decoded (%41 => A), this is only used by
+// URI and URL
+func WithPercentEncoding() Option {
+	return func(o *options) {
+		o.percentEncoding = true
+	}
+}
+
+// WithRange clamps the number to the range lower to upper (inclusive), this is only used by
+// the numeric sanitizers (Numeric, Decimal, ExtractDecimal and ExtractInteger), the
+// ScientificNotationStrict sanitizer returns an error for a number outside of the range
+func WithRange(lower, upper float64) Option {
+	return func(o *options) {
+		o.rangeMin, o.rangeMax, o.rangeSet = lower, upper, true
+	}
+}
+
+// WithRejectLoopback rejects the loopback addresses (127.0.0.0/8 and ::1), this is only
+// used by IPAddress
+func WithRejectLoopback() Option {
+	return func(o *options) {
+		o.rejectLoopback = true
+	}
+}
+
+// WithRejectPrivate rejects the addresses that are not publicly routable other than the
+// loopback addresses (private, link-local, carrier-grade NAT, unique local, multicast,
+// documentation, etc.), this is only used by IPAddress
+func WithRejectPrivate() Option {
+	return func(o *options) {
+		o.rejectPrivate = true
+	}
+}
+
+// WithRemoveTrailingSlash removes the trailing slash from url paths (other than
+// the root path), this is only used by NormalizeURL
+func WithRemoveTrailingSlash() Option {
+	return func(o *options) {
+		o.removeTrailingSlash = true
+	}
+}
+
+// WithSchemeDefault adds the scheme to urls without one (example.com/a => https://example.com/a
+// and //example.com => https://example.com), this is only used by URL and NormalizeURL
+func WithSchemeDefault(scheme string) Option {
+	return func(o *options) {
+		o.schemeDefault = scheme
+	}
+}
+
+// WithScriptTags sets the tags (elements) that are removed by Scripts(),
+// the default tags are script, iframe, embed and object
+func WithScriptTags(tags ...string) Option {
+	return func(o *options) {
+		o.scriptTags = tags
+	}
+}
+
+// WithSortQuery sorts the url query parameters by key, this is only used by NormalizeURL
+func WithSortQuery() Option {
+	return func(o *options) {
+		o.sortQuery = true
+	}
+}
+
+// WithStripEmailDots removes the dots from the local part of email addresses
+// for providers that ignore them (gmail.com and googlemail.com), this is only
+// used by the email sanitizers and is intended for de-duplication
+func WithStripEmailDots() Option {
+	return func(o *options) {
+		o.stripEmailDots = true
+	}
+}
+
+// WithStripEmailTag removes the sub-addressing tag from the local part of email
+// addresses (user+tag@example.com => user@example.com), this is only used by the
+// email sanitizers
+func WithStripEmailTag() Option {
+	return func(o *options) {
+		o.stripEmailTag = true
+	}
+}
+
+// WithStripFragment removes the fragment (#section) from the url, this is only used by URL
+// and NormalizeURL
+func WithStripFragment() Option {
+	return func(o *options) {
+		o.stripFragment = true
+	}
+}
+
+// WithStripQuery removes the query (?key=value) from the url, this is only used by URL and
+// NormalizeURL
+func WithStripQuery() Option {
+	return func(o *options) {
+		o.stripQuery = true
+	}
+}
+
+// WithStripTrackingParams removes the known tracking parameters (utm_*, fbclid, gclid, etc.)
+// from the url query, this is only used by NormalizeURL
+func WithStripTrackingParams() Option {
+	return func(o *options) {
+		o.stripTracking = true
+	}
+}
+
+// WithStripTrackingPixels removes the hidden and 1x1 images that are used to track when an
+// email is opened, this is only used by EmailHTML
+func WithStripTrackingPixels() Option {
+	return func(o *options) {
+		o.stripTrackingPixels = true
+	}
+}
+
+// WithTabWidth sets the number of spaces per tab (the default is 4), this is only used by Indentation
+func WithTabWidth(n int) Option {
+	return func(o *options) {
+		o.tabWidth = n
+	}
+}
+
+// WithTrim removes leading and trailing white space from the sanitized output
+func WithTrim() Option {
+	return func(o *options) {
+		o.trim = true
+	}
+}
+
+// WithUnicodeEmail keeps the unicode letters, marks and numbers of internationalized email
+// addresses (RFC 6531) instead of removing them (tést@exámple.com stays as-is), this is only
+// used by the email sanitizers
+func WithUnicodeEmail() Option {
+	return func(o *options) {
+		o.unicodeEmail = true
+	}
+}
+
+// percentEncode applies the WithPercentEncoding option to the value, only the characters
+// that the table allows are decoded
+func (o *options) percentEncode(value string, table *charTable) string {
+	if o == nil || !o.percentEncoding {
+		return value
+	}
+	return normalizePercentEncoding(removeInvalidEscapes(value), table)
+}
+
+// urlParts applies the WithSchemeDefault, WithStripQuery and WithStripFragment options to the url
+func (o *options) urlParts(value string) string {
+	if o == nil || len(value) == 0 {
+		return value
+	}
+
+	if len(o.schemeDefault) > 0 && !urlSchemeRegExp.MatchString(value) {
+		if strings.HasPrefix(value, "//") {
+			value = o.schemeDefault + ":" + value
+		} else {
+			value = o.schemeDefault + "://" + value
+		}
+	}
+
+	fragment := ""
+	if i := strings.IndexByte(value, '#'); i >= 0 {
+		value, fragment = value[:i], value[i:]
+	}
+	if i := strings.IndexByte(value, '?'); i >= 0 && o.stripQuery {
+		value = value[:i]
+	}
+	if o.stripFragment {
+		return value
+	}
+	return value + fragment
+}
+
+// report returns the callback for the removed dangerous constructs (nil if there is none)
+func (o *options) report() func(Dropped) {
+	if o == nil {
+		return nil
+	}
+	return o.dropped
+}
+
+// collapseSpaces replaces every run of white space with a single space
+func collapseSpaces(value string) string {
+	var b strings.Builder
+	b.Grow(len(value))
+	inSpace := false
+	for _, r := range value {
+		if unicode.IsSpace(r) {
+			if !inSpace {
+				b.WriteByte(' ')
+			}
+			inSpace = true
+			continue
+		}
+		inSpace = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// truncateBytes truncates the value to at most n bytes without splitting a rune
+func truncateBytes(value string, n int) string {
+	if len(value) <= n {
+		return value
+	}
+	for n > 0 && !utf8.RuneStart(value[n]) {
+		n--
+	}
+	return value[:n]
+}
@@ -0,0 +1,268 @@
+package sanitize
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWithCase tests the case-transform options
+func TestWithCase(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+		option   Option
+	}{
+		{"lowercase", "Test This String-!123", "testthisstring", WithLowercase()},
+		{"uppercase", "Test This String-!123", "TESTTHISSTRING", WithUppercase()},
+		{"title case", "test THIS String-!123", "Testthisstring", WithTitleCase()},
+		{"nil option", "Test This String-!123", "TestThisString", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Alpha(test.input, false, test.option))
+		})
+	}
+
+	t.Run("last option wins", func(t *testing.T) {
+		assert.Equal(t, "TEST", Alpha("test", false, WithLowercase(), WithUppercase()))
+	})
+
+	t.Run("with spaces", func(t *testing.T) {
+		assert.Equal(t, "Test This String", AlphaNumeric("test this string!", true, WithTitleCase()))
+	})
+
+	t.Run("domain", func(t *testing.T) {
+		output, err := Domain("https://www.example.com", false, true, WithUppercase())
+		assert.NoError(t, err)
+		assert.Equal(t, "EXAMPLE.COM", output)
+	})
+}
+
+// TestWithTrim tests the WithTrim and WithCollapseSpaces options
+func TestWithTrim(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+		options  []Option
+	}{
+		{"trim", "  Test This String!  ", "Test This String", []Option{WithTrim()}},
+		{"collapse", "  Test  This   String!  ", " Test This String ", []Option{WithCollapseSpaces()}},
+		{"trim and collapse", "Test  This   String!", "Test This String", []Option{WithTrim(), WithCollapseSpaces()}},
+		{"line breaks", "\nTest\n\tThis\r\nString ", "Test This String", []Option{WithTrim(), WithCollapseSpaces()}},
+		{"only spaces", "   !!!   ", "", []Option{WithTrim(), WithCollapseSpaces()}},
+		{"with case", " test  this ", "TEST THIS", []Option{WithTrim(), WithCollapseSpaces(), WithUppercase()}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Alpha(test.input, true, test.options...))
+		})
+	}
+}
+
+// BenchmarkWithTrim benchmarks the Alpha method with trim and collapse options
+func BenchmarkWithTrim(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Alpha("  This  is the   test string. ", true, WithTrim(), WithCollapseSpaces())
+	}
+}
+
+// ExampleWithTrim example using Alpha() with the WithTrim() and WithCollapseSpaces() options
+func ExampleWithTrim() {
+	fmt.Println(Alpha(" Test  This   String! ", true, WithTrim(), WithCollapseSpaces()))
+	// Output: Test This String
+}
+
+// BenchmarkWithTitleCase benchmarks the Alpha method with an option
+func BenchmarkWithTitleCase(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Alpha("This is the test string.", true, WithTitleCase())
+	}
+}
+
+// ExampleWithLowercase example using Alpha() and the WithLowercase() option
+func ExampleWithLowercase() {
+	fmt.Println(Alpha("Example String!", false, WithLowercase()))
+	// Output: examplestring
+}
+
+// ExampleWithUppercase example using PathName() and the WithUppercase() option
+func ExampleWithUppercase() {
+	fmt.Println(PathName("Example-String!", WithUppercase()))
+	// Output: EXAMPLE-STRING
+}
+
+// ExampleWithTitleCase example using FormalName() and the WithTitleCase() option
+func ExampleWithTitleCase() {
+	fmt.Println(FormalName("JOHN o'reilly!", WithTitleCase()))
+	// Output: John O'reilly
+}
+
+// TestWithMaxInputBytes tests the WithMaxInputBytes option
+func TestWithMaxInputBytes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("truncates", func(t *testing.T) {
+		assert.Equal(t, "Test", Alpha("Test This String", false, WithMaxInputBytes(5)))
+	})
+
+	t.Run("under the limit", func(t *testing.T) {
+		assert.Equal(t, "TestThisString", Alpha("Test This String", false, WithMaxInputBytes(100)))
+	})
+
+	t.Run("zero is unlimited", func(t *testing.T) {
+		assert.Equal(t, "TestThisString", Alpha("Test This String", false, WithMaxInputBytes(0)))
+	})
+
+	t.Run("rune boundary", func(t *testing.T) {
+		assert.Equal(t, "caf", ASCII("café", false, WithMaxInputBytes(4)))
+		assert.Equal(t, "Cr", RemoveDigits("Crème", WithMaxInputBytes(3)))
+	})
+
+	t.Run("before other options", func(t *testing.T) {
+		assert.Equal(t, "TEST", Alpha(" test this", true, WithMaxInputBytes(6), WithTrim(), WithUppercase()))
+	})
+
+	t.Run("unclosed markup after truncation", func(t *testing.T) {
+		assert.Equal(t, "safe ", Scripts("safe <script>alert(1)</script>", WithMaxInputBytes(15)))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := Domain("https://example.com/"+strings.Repeat("a", 100), false, false, WithMaxInputBytes(50))
+		assert.ErrorIs(t, err, ErrInputTooLarge)
+
+		_, err = EmailStrict(strings.Repeat("a", 100)+"@example.com", false, WithMaxInputBytes(50))
+		assert.ErrorIs(t, err, ErrInputTooLarge)
+
+		_, err = NormalizeURL("https://example.com/"+strings.Repeat("a", 100), WithMaxInputBytes(50))
+		assert.ErrorIs(t, err, ErrInputTooLarge)
+
+		output, err := NormalizeURL("https://example.com/", WithMaxInputBytes(50))
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com/", output)
+	})
+}
+
+// largeHTML is a 1MB html document used to benchmark the worst case of the html sanitizers
+var largeHTML = strings.Repeat(`<div class="a"><p>Some <b>text</b> <script>alert(1)</script> here</p></div>`, 1<<20/76)
+
+// BenchmarkAlpha_Large benchmarks the Alpha method with a 1MB input
+func BenchmarkAlpha_Large(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Alpha(largeHTML, true)
+	}
+}
+
+// BenchmarkHTML_Large benchmarks the HTML method with a 1MB input
+func BenchmarkHTML_Large(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = HTML(largeHTML)
+	}
+}
+
+// BenchmarkHTML_LargeWithMaxInputBytes benchmarks the HTML method with a 1MB input limited to 64KB
+func BenchmarkHTML_LargeWithMaxInputBytes(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = HTML(largeHTML, WithMaxInputBytes(1<<16))
+	}
+}
+
+// BenchmarkScripts_Large benchmarks the Scripts method with a 1MB input
+func BenchmarkScripts_Large(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Scripts(largeHTML)
+	}
+}
+
+// BenchmarkXSS_Large benchmarks the XSS method with a 1MB input
+func BenchmarkXSS_Large(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = XSS(largeHTML)
+	}
+}
+
+// ExampleWithMaxInputBytes example using Alpha() and the WithMaxInputBytes() option
+func ExampleWithMaxInputBytes() {
+	fmt.Println(Alpha("Example String!", false, WithMaxInputBytes(7)))
+	// Output: Example
+}
+
+// TestWithDropped tests the WithDropped option
+func TestWithDropped(t *testing.T) {
+	t.Parallel()
+
+	collect := func(dropped *[]Dropped) Option {
+		return WithDropped(func(d Dropped) { *dropped = append(*dropped, d) })
+	}
+
+	var tests = []struct {
+		name     string
+		fn       func(string, ...Option) string
+		input    string
+		expected []Dropped
+	}{
+		{"html clean", HTML, "<p>Hello <b>World</b></p>", nil},
+		{"html script", HTML, "<SCRIPT>alert(1)</SCRIPT>", []Dropped{{DroppedTag, "script"}}},
+		{"html handler", HTML, `<img src=x onerror="alert(1)">`, []Dropped{{DroppedHandler, "onerror"}}},
+		{"html protocol", HTML, `<a href=" JavaScript:alert(1)">x</a>`, []Dropped{{DroppedProtocol, "javascript"}}},
+		{"html entity protocol", HTML, `<a href="&#106;avascript:x">x</a>`, []Dropped{{DroppedProtocol, "javascript"}}},
+		{"html safe link", HTML, `<a href="https://example.com">x</a>`, nil},
+		{"html several", HTML, `<iframe src="data:text/html,x" onload=y></iframe>`, []Dropped{
+			{DroppedTag, "iframe"}, {DroppedProtocol, "data"}, {DroppedHandler, "onload"},
+		}},
+		{"scripts", Scripts, `<p>ok</p><object onclick=x></object><script>1</script>`, []Dropped{
+			{DroppedTag, "object"}, {DroppedHandler, "onclick"}, {DroppedTag, "script"},
+		}},
+		{"scripts clean", Scripts, `<p onclick="x">ok</p>`, nil},
+		{"xss", XSS, "JavaScript:eval(1)", []Dropped{{DroppedPattern, "javascript:"}, {DroppedPattern, "eval("}}},
+		{"xss clean", XSS, "no xss here", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var dropped []Dropped
+			_ = test.fn(test.input, collect(&dropped))
+			assert.Equal(t, test.expected, dropped)
+		})
+	}
+
+	t.Run("xss policy", func(t *testing.T) {
+		var dropped []Dropped
+		_ = XSSWithPolicy(`<iframe SRCDOC="x">`, XSSPolicy{ExtraHandlers: []string{"srcdoc"}}, collect(&dropped))
+		assert.Equal(t, []Dropped{{DroppedPattern, "srcdoc="}}, dropped)
+	})
+
+	t.Run("profile", func(t *testing.T) {
+		var dropped []Dropped
+		_ = ProfileV2.XSS("fromCharCode(88)", collect(&dropped))
+		assert.Equal(t, []Dropped{{DroppedPattern, "fromcharcode"}}, dropped)
+	})
+
+	t.Run("output is unchanged", func(t *testing.T) {
+		input := `<a href="javascript:x" onclick=y>z</a><script>1</script>`
+		assert.Equal(t, HTML(input), HTML(input, WithDropped(func(Dropped) {})))
+		assert.Equal(t, Scripts(input), Scripts(input, WithDropped(nil)))
+	})
+}
+
+// ExampleWithDropped example using HTML() and the WithDropped() option
+func ExampleWithDropped() {
+	output := HTML(`<img src=x onerror="alert(1)"><script>steal()</script>Hi`, WithDropped(func(d Dropped) {
+		fmt.Println("removed", d.Kind, d.Value)
+	}))
+	fmt.Println(output)
+	// Output:
+	// removed handler onerror
+	// removed tag script
+	// steal()Hi
+}
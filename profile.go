@@ -0,0 +1,118 @@
+package sanitize
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Profile is a versioned set of sanitizer behaviors. When the filtering rules of a sanitizer
+// are tightened the old behavior is kept in a profile, so pipelines that re-sanitize stored
+// data can pin it and keep getting the same results.
+//
+//	View examples: profile_test.go
+type Profile int
+
+// Sanitizer profiles, any profile other than ProfileV1 and ProfileV2 uses the current behavior.
+// ProfileV1 is the original behavior of XSS, HTML, XML, Scripts and FirstToUpper, the
+// later profiles only differ in XSS.
+const (
+	ProfileV1     Profile = 1         // The original sanitizers, XSS removes each string once in order
+	ProfileV2     Profile = 2         // XSS removes the strings until none are left, matching case
+	ProfileV3     Profile = 3         // XSS matches the strings ignoring case (current)
+	ProfileLatest         = ProfileV3 // The current behavior of the package functions
+)
+
+// xssTokensV1 are the strings removed by XSS in ProfileV1 (in order) and ProfileV2 (matching
+// case), this must never change
+var xssTokensV1 = []string{
+	"<script", "script>", "eval(", "eval&#40;", "javascript:", "javascript&#58;",
+	"fromCharCode", "&#62;", "&#60;", "&lt;", "&rt;",
+}
+
+// The regular expressions of HTML and Scripts in ProfileV1, these must never change
+var (
+	htmlRegExpV1   = regexp.MustCompile(`(?i)<[^>]*>`)
+	scriptRegExpV1 = regexp.MustCompile(`(?i)<(script|iframe|embed|object)[^>]*>.*</(script|iframe|embed|object)>`)
+)
+
+// XSS runs the XSS sanitizer of the profile. In ProfileV1 each string is removed once in
+// order, so removing one string can re-form another (javascjavascript:ript: => javascript:).
+// ProfileV1 and ProfileV2 match the case of the strings (<SCRIPT is kept).
+//
+//	View examples: profile_test.go
+func (p Profile) XSS(original string, opts ...Option) string {
+	switch p {
+	case ProfileV1:
+		o := newOptions(opts)
+		original = o.before(original)
+		report := o.report()
+		for _, token := range xssTokensV1 {
+			if report != nil {
+				for i := strings.Count(original, token); i > 0; i-- {
+					report(Dropped{Kind: DroppedPattern, Value: strings.ToLower(token)})
+				}
+			}
+			original = strings.Replace(original, token, "", -1)
+		}
+		return o.after(original)
+	case ProfileV2:
+		o := newOptions(opts)
+		return o.after(removeTokens(o.before(original), xssTokensV1, false, o.report()))
+	default:
+		return XSS(original, opts...)
+	}
+}
+
+// HTML runs the HTML sanitizer of the profile. In ProfileV1 everything from a < to the next >
+// is removed with a regular expression, so the content of comments, scripts and styles is kept.
+//
+//	View examples: profile_test.go
+func (p Profile) HTML(original string, opts ...Option) string {
+	if p != ProfileV1 {
+		return HTML(original, opts...)
+	}
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(htmlRegExpV1.ReplaceAllString(original, ""))
+}
+
+// Scripts runs the Scripts sanitizer of the profile. In ProfileV1 the script, iframe, embed
+// and object elements are removed with a regular expression, only on a single line and from
+// the first start tag to the last end tag.
+//
+//	View examples: profile_test.go
+func (p Profile) Scripts(original string, opts ...Option) string {
+	if p != ProfileV1 {
+		return Scripts(original, opts...)
+	}
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(scriptRegExpV1.ReplaceAllString(original, ""))
+}
+
+// FirstToUpper runs the FirstToUpper sanitizer of the profile. In ProfileV1 invalid UTF-8 is
+// replaced with U+FFFD.
+//
+//	View examples: profile_test.go
+func (p Profile) FirstToUpper(original string) string {
+	if p != ProfileV1 || len(original) < 2 {
+		return FirstToUpper(original)
+	}
+	runes := []rune(original)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// Funcs returns the built-in sanitizers (like Funcs) with the behavior of the profile
+//
+//	View examples: profile_test.go
+func (p Profile) Funcs() FuncSet {
+	funcs := Funcs
+	funcs.FirstToUpper = p.FirstToUpper
+	funcs.HTML = func(s string) string { return p.HTML(s) }
+	funcs.Scripts = func(s string) string { return p.Scripts(s) }
+	funcs.XML = func(s string) string { return p.HTML(s) }
+	funcs.XSS = func(s string) string { return p.XSS(s) }
+	return funcs
+}
@@ -0,0 +1,126 @@
+package sanitize
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestProfile_XSS tests the XSS method of the profiles
+func TestProfile_XSS(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		profile  Profile
+		input    string
+		expected string
+	}{
+		{"v1 script", ProfileV1, "<script>alert(1)</script>", ">alert(1)</"},
+		{"v1 re-formed", ProfileV1, "evaeval(l(x)", "eval(x)"},
+		{"v1 order", ProfileV1, "javascjavascript:ript:", "javascript:"},
+		{"v1 options", ProfileV1, " eval(x) ", "x)"},
+		{"v2 script", ProfileV2, "<script>alert(1)</script>", ">alert(1)</"},
+		{"v2 re-formed", ProfileV2, "evaeval(l(x)", "x)"},
+		{"v2 matches case", ProfileV2, "<SCRIPT>JavaScript:x", "<SCRIPT>JavaScript:x"},
+		{"v3 ignores case", ProfileV3, "<SCRIPT>JavaScript:x", ">x"},
+		{"latest", ProfileLatest, "javascjavascript:ript:", ""},
+		{"zero value", Profile(0), "evaeval(l(x)", "x)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.profile.XSS(test.input, WithTrim()))
+		})
+	}
+
+	t.Run("v1 reports the removed strings", func(t *testing.T) {
+		var dropped []Dropped
+		_ = ProfileV1.XSS("<script>eval(x)</script>", WithDropped(func(d Dropped) { dropped = append(dropped, d) }))
+		assert.Equal(t, []Dropped{
+			{Kind: DroppedPattern, Value: "<script"},
+			{Kind: DroppedPattern, Value: "script>"},
+			{Kind: DroppedPattern, Value: "eval("},
+		}, dropped)
+	})
+
+	t.Run("v1 matches the original token list", func(t *testing.T) {
+		lower := make([]string, len(xssTokensV1))
+		for i, token := range xssTokensV1 {
+			lower[i] = strings.ToLower(token)
+		}
+		assert.Equal(t, xssTokens, lower)
+	})
+}
+
+// TestProfile_V1 tests the original behavior of the sanitizers pinned by ProfileV1
+func TestProfile_V1(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name   string
+		fn     func(Profile, string) string
+		input  string
+		v1     string
+		latest string
+	}{
+		{"html comment", func(p Profile, s string) string { return p.HTML(s) }, "<!--<b>x</b>-->y", "x-->y", "y"},
+		{"html style", func(p Profile, s string) string { return p.HTML(s) }, "<style>a</style>b", "ab", "ab"},
+		{
+			"scripts multiple lines", func(p Profile, s string) string { return p.Scripts(s) },
+			"<script>\nx\n</script>y", "<script>\nx\n</script>y", "y",
+		},
+		{
+			"scripts greedy", func(p Profile, s string) string { return p.Scripts(s) },
+			"<script>a</script>b<script>c</script>", "", "b",
+		},
+		{"first to upper invalid utf-8", Profile.FirstToUpper, "a\xff", "A\ufffd", "A\xff"},
+		{"xml", func(p Profile, s string) string { return p.Funcs().XML(s) }, "<!--<b>x</b>-->y", "x-->y", "y"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.v1, test.fn(ProfileV1, test.input))
+			assert.Equal(t, test.latest, test.fn(ProfileLatest, test.input))
+		})
+	}
+
+	t.Run("options", func(t *testing.T) {
+		assert.Equal(t, "x", ProfileV1.HTML(" <b>x</b> ", WithTrim()))
+		assert.Equal(t, "y", ProfileV1.Scripts(" <script>x</script>y ", WithTrim()))
+	})
+}
+
+// TestProfile_Funcs tests the Funcs method of the profiles
+func TestProfile_Funcs(t *testing.T) {
+	t.Parallel()
+
+	funcs := ProfileV1.Funcs()
+	assert.Equal(t, "javascript:", funcs.XSS("javascjavascript:ript:"))
+	assert.Equal(t, "x-->y", funcs.HTML("<!--<b>x</b>-->y"))
+	assert.Equal(t, "y", ProfileV2.Funcs().HTML("<!--<b>x</b>-->y"))
+	assert.Equal(t, "", ProfileV2.Funcs().XSS("javascjavascript:ript:"))
+	assert.Equal(t, "Eval(x)", ProfileV2.Funcs().XSS("Eval(x)"))
+	assert.Equal(t, "bob@example.com", funcs.Email("Bob@Example.com"))
+
+	value := reflect.ValueOf(funcs)
+	for i := 0; i < value.NumField(); i++ {
+		assert.False(t, value.Field(i).IsNil(), value.Type().Field(i).Name)
+	}
+}
+
+// BenchmarkProfile_XSS benchmarks the XSS method of ProfileV1
+func BenchmarkProfile_XSS(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = ProfileV1.XSS("<script>alert(1)</script>")
+	}
+}
+
+// ExampleProfile_XSS example using the XSS method of a Profile
+func ExampleProfile_XSS() {
+	fmt.Printf("%q %q\n", ProfileV1.XSS("javascjavascript:ript:"), ProfileV2.XSS("javascjavascript:ript:"))
+	// Output: "javascript:" ""
+}
@@ -0,0 +1,67 @@
+package sanitize
+
+import (
+	"strings"
+)
+
+// promLabelValueEscaper escapes a label value for the Prometheus text exposition format
+var promLabelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// PromMetricName returns a valid Prometheus metric name ([a-zA-Z_:][a-zA-Z0-9_:]*). Every
+// other character is replaced with an underscore (http.requests-total => http_requests_total,
+// like the underscore escaping of the Prometheus clients) and a leading digit is prefixed with
+// an underscore.
+//
+//	View examples: prometheus_test.go
+func PromMetricName(original string) string {
+	return promName(original, true)
+}
+
+// PromLabelName returns a valid Prometheus label name ([a-zA-Z_][a-zA-Z0-9_]*), see
+// PromMetricName. The names starting with "__" are reserved for internal use, a leading
+// double underscore is reduced to a single underscore.
+//
+//	View examples: prometheus_test.go
+func PromLabelName(original string) string {
+	name := promName(original, false)
+	for strings.HasPrefix(name, "__") {
+		name = name[1:]
+	}
+	return name
+}
+
+// PromLabelValue escapes a label value for the Prometheus text exposition format (backslashes,
+// double quotes and new lines are escaped as \\, \" and \n) and removes invalid UTF-8. Only
+// use it when writing the exposition format directly, the Prometheus clients escape label
+// values themselves.
+//
+//	View examples: prometheus_test.go
+func PromLabelValue(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(promLabelValueEscaper.Replace(strings.ToValidUTF8(original, "")))
+}
+
+// promName replaces the invalid characters of a metric (with colons) or label name
+func promName(original string, colons bool) string {
+	if len(original) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.Grow(len(original) + 1)
+	if original[0] >= '0' && original[0] <= '9' {
+		b.WriteByte('_')
+	}
+	for _, r := range original {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_':
+			b.WriteRune(r)
+		case r == ':' && colons:
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,124 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPromMetricName tests the PromMetricName method
+func TestPromMetricName(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"valid", "http_requests_total", "http_requests_total"},
+		{"recording rule", "job:http_requests:rate5m", "job:http_requests:rate5m"},
+		{"dots and dashes", "http.requests-total", "http_requests_total"},
+		{"spaces", "api latency ms", "api_latency_ms"},
+		{"leading digit", "5xx_errors", "_5xx_errors"},
+		{"unicode", "café_hits", "caf__hits"},
+		{"quotes and braces", `x{a="b"}`, "x_a__b__"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, PromMetricName(test.input))
+		})
+	}
+}
+
+// BenchmarkPromMetricName benchmarks the PromMetricName method
+func BenchmarkPromMetricName(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = PromMetricName("http.requests-total")
+	}
+}
+
+// ExamplePromMetricName example using PromMetricName()
+func ExamplePromMetricName() {
+	fmt.Println(PromMetricName("api.requests-total"))
+	// Output: api_requests_total
+}
+
+// TestPromLabelName tests the PromLabelName method
+func TestPromLabelName(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"valid", "status_code", "status_code"},
+		{"colon", "a:b", "a_b"},
+		{"dashes", "user-agent", "user_agent"},
+		{"leading digit", "1st", "_1st"},
+		{"reserved", "__name__", "_name__"},
+		{"reserved after replace", "..x", "_x"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, PromLabelName(test.input))
+		})
+	}
+}
+
+// BenchmarkPromLabelName benchmarks the PromLabelName method
+func BenchmarkPromLabelName(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = PromLabelName("user-agent")
+	}
+}
+
+// ExamplePromLabelName example using PromLabelName()
+func ExamplePromLabelName() {
+	fmt.Println(PromLabelName("__user-agent"))
+	// Output: _user_agent
+}
+
+// TestPromLabelValue tests the PromLabelValue method
+func TestPromLabelValue(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"plain", "GET /api", "GET /api"},
+		{"quotes", `say "hi"`, `say \"hi\"`},
+		{"backslash", `C:\temp`, `C:\\temp`},
+		{"new line", "a\nb", `a\nb`},
+		{"injection", "x\"} 1\nfake_metric{a=\"", `x\"} 1\nfake_metric{a=\"`},
+		{"unicode", "café", "café"},
+		{"invalid utf-8", "caf\xe9", "caf"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, PromLabelValue(test.input))
+		})
+	}
+}
+
+// BenchmarkPromLabelValue benchmarks the PromLabelValue method
+func BenchmarkPromLabelValue(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = PromLabelValue("x\"} 1\nfake_metric{a=\"")
+	}
+}
+
+// ExamplePromLabelValue example using PromLabelValue()
+func ExamplePromLabelValue() {
+	fmt.Printf("http_requests_total{path=\"%s\"} 1\n", PromLabelValue("/a\"} 1\nfake{x=\""))
+	// Output: http_requests_total{path="/a\"} 1\nfake{x=\""} 1
+}
@@ -6,102 +6,299 @@ If you have any suggestions or comments, please feel free to open an issue on th
 package sanitize
 
 import (
+	"fmt"
 	"net"
 	"net/url"
 	"regexp"
+	"regexp/syntax"
 	"strings"
+	"time"
 	"unicode"
+	"unicode/utf8"
 )
 
 // Set all the regular expressions
 var (
-	alphaNumericRegExp           = regexp.MustCompile(`[^a-zA-Z0-9]`)                                                             // Alpha numeric
-	alphaNumericWithSpacesRegExp = regexp.MustCompile(`[^a-zA-Z0-9\s]`)                                                           // Alphanumeric (with spaces)
-	alphaRegExp                  = regexp.MustCompile(`[^a-zA-Z]`)                                                                // Alpha characters
-	alphaWithSpacesRegExp        = regexp.MustCompile(`[^a-zA-Z\s]`)                                                              // Alpha characters (with spaces)
-	bitcoinCashAddrRegExp        = regexp.MustCompile(`[^ac-hj-np-zAC-HJ-NP-Z02-9]`)                                              // Bitcoin `cashaddr` address accepted characters
-	bitcoinRegExp                = regexp.MustCompile(`[^a-km-zA-HJ-NP-Z1-9]`)                                                    // Bitcoin address accepted characters
-	decimalRegExp                = regexp.MustCompile(`[^0-9.-]`)                                                                 // Decimals (positive and negative)
-	domainRegExp                 = regexp.MustCompile(`[^a-zA-Z0-9-.]`)                                                           // Domain accepted characters
-	emailRegExp                  = regexp.MustCompile(`[^a-zA-Z0-9-_.@+]`)                                                        // Email address characters
-	formalNameRegExp             = regexp.MustCompile(`[^a-zA-Z0-9-',.\s]`)                                                       // Characters recognized in surnames and proper names
-	htmlRegExp                   = regexp.MustCompile(`(?i)<[^>]*>`)                                                              // HTML/XML tags or any alligator open/close tags
-	ipAddressRegExp              = regexp.MustCompile(`[^a-zA-Z0-9:.]`)                                                           // IPV4 and IPV6 characters only
-	numericRegExp                = regexp.MustCompile(`[^0-9]`)                                                                   // Numbers only
-	pathNameRegExp               = regexp.MustCompile(`[^a-zA-Z0-9-_]`)                                                           // Path name (file name, seo)
-	punctuationRegExp            = regexp.MustCompile(`[^a-zA-Z0-9-'"#&!?,.\s]+`)                                                 // Standard accepted punctuation characters
-	scientificNotationRegExp     = regexp.MustCompile(`[^0-9.eE+-]`)                                                              // Scientific Notation (float) (positive and negative)
-	scriptRegExp                 = regexp.MustCompile(`(?i)<(script|iframe|embed|object)[^>]*>.*</(script|iframe|embed|object)>`) // Scripts and embeds
-	singleLineRegExp             = regexp.MustCompile(`(\r)|(\n)|(\t)|(\v)|(\f)`)                                                 // Carriage returns, line feeds, tabs, for single line transition
-	timeRegExp                   = regexp.MustCompile(`[^0-9:]`)                                                                  // Time allowed characters
-	uriRegExp                    = regexp.MustCompile(`[^a-zA-Z0-9-_/?&=#%]`)                                                     // URI allowed characters
-	urlRegExp                    = regexp.MustCompile(`[^a-zA-Z0-9-_/:.,?&@=#%]`)                                                 // URL allowed characters
-	wwwRegExp                    = regexp.MustCompile(`(?i)www.`)                                                                 // For removing www
+	removePunctuationRegExp = regexp.MustCompile(`[[:punct:]\p{P}]`)         // Punctuation and ASCII symbols to remove
+	removeWhitespaceRegExp  = regexp.MustCompile(`[\s\v\p{Z}]`)              // White space to remove
+	singleLineRegExp        = regexp.MustCompile(`(\r)|(\n)|(\t)|(\v)|(\f)`) // Carriage returns, line feeds, tabs, for single line transition
+	wwwRegExp               = regexp.MustCompile(`(?i)www.`)                 // For removing www
 )
 
 // emptySpace is an empty space for replacing
 var emptySpace = []byte("")
 
+// removeMatches removes all matches of the regular expression from the string. The original
+// string is returned as-is (without allocating) when there is nothing to remove.
+func removeMatches(re *regexp.Regexp, original string) string {
+	if !re.MatchString(original) {
+		return original
+	}
+	return string(re.ReplaceAll([]byte(original), emptySpace))
+}
+
+// xssTokens are the known XSS attack strings removed by XSS() (lowercase, they are matched
+// ignoring case)
+var xssTokens = []string{
+	"<script", "script>", "eval(", "eval&#40;", "javascript:", "javascript&#58;",
+	"fromcharcode", "&#62;", "&#60;", "&lt;", "&rt;",
+}
+
+// defaultPatternBudget is the maximum number of instructions of a CustomSafe pattern
+const defaultPatternBudget = 10000
+
+// patternSize returns the number of instructions the (valid) regex compiles to, this grows
+// with the length of the pattern and the counted repetitions (a{1000} is 1000 copies of a)
+func patternSize(regExp string) int {
+	re, err := syntax.Parse(regExp, syntax.Perl)
+	if err != nil {
+		return 0
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return 0
+	}
+	return len(prog.Inst)
+}
+
+// removeTokens removes all the tokens from the string in a single pass. Removing a token
+// never re-forms another one (javascjavascript:ript: => ""), the text around a removed token
+// is checked again as it is joined. With foldCase the tokens (which must be lowercase) are
+// matched ignoring the case of ASCII letters. The report callback (optional) is called with
+// each removed token. The original string is returned as-is when there is nothing to remove.
+func removeTokens(original string, tokens []string, foldCase bool, report func(Dropped)) string {
+	found := false
+	for _, token := range tokens {
+		if containsToken(original, token, foldCase) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return original
+	}
+
+	buf := make([]byte, 0, len(original))
+	for i := 0; i < len(original); i++ {
+		buf = append(buf, original[i])
+		for _, token := range tokens {
+			if len(buf) >= len(token) && equalToken(string(buf[len(buf)-len(token):]), token, foldCase) {
+				buf = buf[:len(buf)-len(token)]
+				if report != nil {
+					report(Dropped{Kind: DroppedPattern, Value: strings.ToLower(token)})
+				}
+				break
+			}
+		}
+	}
+	return string(buf)
+}
+
+// containsToken reports whether the token is in the string (see removeTokens)
+func containsToken(s, token string, foldCase bool) bool {
+	if !foldCase {
+		return strings.Contains(s, token)
+	}
+	for i := 0; i+len(token) <= len(s); i++ {
+		if equalToken(s[i:i+len(token)], token, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalToken reports whether s is the token, with foldCase the ASCII letters of s are
+// lowercased before they are compared (the token must be lowercase)
+func equalToken(s, token string, foldCase bool) bool {
+	if !foldCase {
+		return s == token
+	}
+	if len(s) != len(token) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != token[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // Alpha returns only alpha characters. Set the parameter spaces to true if you
 // want to allow space characters. Valid characters are a-z and A-Z.
 //
 //	View examples: sanitize_test.go
-func Alpha(original string, spaces bool) string {
+func Alpha(original string, spaces bool, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
 
 	// Leave white spaces?
 	if spaces {
-		return string(alphaWithSpacesRegExp.ReplaceAll([]byte(original), emptySpace))
+		return o.after(filterASCII(original, alphaWithSpacesTable))
 	}
 
 	// No spaces
-	return string(alphaRegExp.ReplaceAll([]byte(original), emptySpace))
+	return o.after(filterASCII(original, alphaTable))
 }
 
 // AlphaNumeric returns only alphanumeric characters. Set the parameter spaces to true
 // if you want to allow space characters. Valid characters are a-z, A-Z and 0-9.
 //
 //	View examples: sanitize_test.go
-func AlphaNumeric(original string, spaces bool) string {
+func AlphaNumeric(original string, spaces bool, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
 
 	// Leave white spaces?
 	if spaces {
-		return string(alphaNumericWithSpacesRegExp.ReplaceAll([]byte(original), emptySpace))
+		return o.after(filterASCII(original, alphaNumericWithSpacesTable))
 	}
 
 	// No spaces
-	return string(alphaNumericRegExp.ReplaceAll([]byte(original), emptySpace))
+	return o.after(filterASCII(original, alphaNumericTable))
 }
 
 // BitcoinAddress returns sanitized value for bitcoin address
 //
 //	View examples: sanitize_test.go
-func BitcoinAddress(original string) string {
-	return string(bitcoinRegExp.ReplaceAll([]byte(original), emptySpace))
+func BitcoinAddress(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(filterASCII(original, bitcoinTable))
 }
 
 // BitcoinCashAddress returns sanitized value for bitcoin `cashaddr`
 // address (https://www.bitcoinabc.org/2018-01-14-CashAddr/)
 //
 //	View examples: sanitize_test.go
-func BitcoinCashAddress(original string) string {
-	return string(bitcoinCashAddrRegExp.ReplaceAll([]byte(original), emptySpace))
+func BitcoinCashAddress(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(filterASCII(original, bitcoinCashTable))
 }
 
 // Custom uses a custom regex string and returns the sanitized result.
 // This is used for any additional regex that this package does not contain.
 //
 //	View examples: sanitize_test.go
-func Custom(original string, regExp string) string {
+func Custom(original string, regExp string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
 
 	// Return the processed string or panic if regex fails
-	return string(regexp.MustCompile(regExp).ReplaceAll([]byte(original), emptySpace))
+	return o.after(string(regexp.MustCompile(regExp).ReplaceAll([]byte(original), emptySpace)))
+}
+
+// CustomCompiled uses a compiled regex and returns the sanitized result. Use this
+// instead of Custom to avoid compiling the same regex on every call. A nil regex
+// never panics, an empty string is returned instead.
+//
+//	View examples: sanitize_test.go
+func CustomCompiled(original string, re *regexp.Regexp, opts ...Option) string {
+	if re == nil {
+		return ""
+	}
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(removeMatches(re, original))
+}
+
+// CustomSafe uses a custom regex string and returns the sanitized result. Unlike
+// Custom, an invalid regex returns an error (ErrInvalidPattern) instead of panicking,
+// use this for patterns that come from configuration or user input. Matching always runs
+// in linear time (there is no backtracking), patterns that compile to more than the
+// complexity budget (see WithPatternBudget) return ErrPatternTooComplex and the
+// WithMatchTimeout option returns ErrPatternTimeout if matching takes too long (the match
+// itself finishes in the background).
+//
+//	View examples: sanitize_test.go
+func CustomSafe(original string, regExp string, opts ...Option) (string, error) {
+	o := newOptions(opts)
+	re, err := o.compilePattern(regExp)
+	if err != nil {
+		return "", err
+	}
+	return o.matchPattern(func() string {
+		return CustomCompiled(original, re, opts...)
+	})
+}
+
+// CustomReplace replaces the matches of a custom regex string with the replacement and
+// returns the result. The replacement can reference the capture groups by number or name
+// ($1, ${1} or ${name}, use ${1} when a letter or digit follows) and $$ is a literal $,
+// this allows transformations such as reformatting dates or masking digits. The pattern
+// is checked like CustomSafe (ErrInvalidPattern, ErrPatternTooComplex and ErrPatternTimeout).
+//
+//	View examples: sanitize_test.go
+func CustomReplace(original, regExp, replacement string, opts ...Option) (string, error) {
+	o := newOptions(opts)
+	re, err := o.compilePattern(regExp)
+	if err != nil {
+		return "", err
+	}
+	return o.matchPattern(func() string {
+		original = o.before(original)
+		if !re.MatchString(original) {
+			return o.after(original)
+		}
+		return o.after(re.ReplaceAllString(original, replacement))
+	})
+}
+
+// compilePattern compiles the regex string, the pattern must be valid and within the
+// complexity budget (see WithPatternBudget)
+func (o *options) compilePattern(regExp string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(regExp)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidPattern, err.Error())
+	}
+
+	budget := defaultPatternBudget
+	if o != nil && o.patternBudget > 0 {
+		budget = o.patternBudget
+	}
+	if size := patternSize(regExp); size > budget {
+		return nil, fmt.Errorf("%w: %d instructions (max %d)", ErrPatternTooComplex, size, budget)
+	}
+	return re, nil
+}
+
+// matchPattern runs the match and returns ErrPatternTimeout if it takes longer than the
+// match timeout (see WithMatchTimeout)
+func (o *options) matchPattern(match func() string) (string, error) {
+	if o == nil || o.matchTimeout <= 0 {
+		return match(), nil
+	}
+
+	// The match can not be canceled, it finishes in the background (in linear time)
+	result := make(chan string, 1)
+	go func() {
+		result <- match()
+	}()
+	timer := time.NewTimer(o.matchTimeout)
+	defer timer.Stop()
+	select {
+	case output := <-result:
+		return output, nil
+	case <-timer.C:
+		return "", fmt.Errorf("%w: %s", ErrPatternTimeout, o.matchTimeout)
+	}
 }
 
 // Decimal returns sanitized decimal/float values in either positive or negative.
 //
 //	View examples: sanitize_test.go
-func Decimal(original string) string {
-	return string(decimalRegExp.ReplaceAll([]byte(original), emptySpace))
+func Decimal(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	if o != nil && o.foldDigits {
+		original = foldDigits(original)
+	}
+	return o.after(o.clamp(filterASCII(original, decimalTable)))
 }
 
 // Domain returns a proper hostname / domain name. Preserve case is to flag keeping the case
@@ -109,7 +306,12 @@ func Decimal(original string) string {
 // This method returns an error if parse critically fails.
 //
 //	View examples: sanitize_test.go
-func Domain(original string, preserveCase bool, removeWww bool) (string, error) {
+func Domain(original string, preserveCase bool, removeWww bool, opts ...Option) (string, error) {
+	o := newOptions(opts)
+	if err := o.checkSize(original); err != nil {
+		return "", err
+	}
+	original = o.before(original)
 
 	// Try to see if we have a host
 	if len(original) == 0 {
@@ -134,30 +336,33 @@ func Domain(original string, preserveCase bool, removeWww bool) (string, error)
 
 	// Keeps the exact case of the original input string
 	if preserveCase {
-		return string(domainRegExp.ReplaceAll([]byte(u.Host), emptySpace)), nil
+		return o.after(filterASCII(u.Host, domainTable)), nil
 	}
 
 	// Generally all domains should be uniform and lowercase
-	return string(domainRegExp.ReplaceAll([]byte(strings.ToLower(u.Host)), emptySpace)), nil
+	return o.after(filterASCII(strings.ToLower(u.Host), domainTable)), nil
 }
 
 // Email returns a sanitized email address string. Email addresses are forced
-// to lowercase and removes any mail-to prefixes.
+// to lowercase and removes any mail-to prefixes. Non-ASCII characters are removed
+// unless WithUnicodeEmail is used (internationalized addresses, RFC 6531).
 //
 //	View examples: sanitize_test.go
-func Email(original string, preserveCase bool) string {
+func Email(original string, preserveCase bool, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	original = strings.Replace(original, "mailto:", "", -1)
 
-	// Leave the email address in its original case
-	if preserveCase {
-		return string(emailRegExp.ReplaceAll(
-			[]byte(strings.Replace(original, "mailto:", "", -1)), emptySpace),
-		)
+	// Standard is forced to lowercase
+	if !preserveCase {
+		original = strings.ToLower(original)
 	}
 
-	// Standard is forced to lowercase
-	return string(emailRegExp.ReplaceAll(
-		[]byte(strings.ToLower(strings.Replace(original, "mailto:", "", -1))), emptySpace),
-	)
+	// Keep the unicode letters, marks and numbers of internationalized addresses
+	if o != nil && o.unicodeEmail {
+		return o.after(o.email(strings.Map(unicodeEmailRune, original)))
+	}
+	return o.after(o.email(filterASCII(original, emailTable)))
 }
 
 // FirstToUpper overwrites the first letter as an uppercase letter
@@ -171,124 +376,218 @@ func FirstToUpper(original string) string {
 		return strings.ToUpper(original)
 	}
 
-	runes := []rune(original)
-	runes[0] = unicode.ToUpper(runes[0])
-	return string(runes)
+	// Already upper case, nothing to change
+	r, size := utf8.DecodeRuneInString(original)
+	if upper := unicode.ToUpper(r); upper != r {
+		return string(upper) + original[size:]
+	}
+	return original
 }
 
 // FormalName returns a formal name or surname (for First, Middle and Last)
 //
 //	View examples: sanitize_test.go
-func FormalName(original string) string {
-	return string(formalNameRegExp.ReplaceAll([]byte(original), emptySpace))
+func FormalName(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(filterASCII(original, formalNameTable))
 }
 
-// HTML returns a string without any <HTML> tags.
+// HTML returns a string without any <HTML> tags, the text content of all elements is kept.
+// Comments are removed with their content, including conditional comments (<!--[if mso]>)
+// and unterminated comments (up to the end of the string). CDATA sections and directives
+// are removed as well, use WithKeepCDATA to keep the text of CDATA sections.
 //
 //	View examples: sanitize_test.go
-func HTML(original string) string {
-	return string(htmlRegExp.ReplaceAll([]byte(original), emptySpace))
+func HTML(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(stripTags(original, o != nil && o.keepCDATA, o.report()))
 }
 
-// IPAddress returns an ip address for both ipv4 and ipv6 formats.
+// IPAddress returns an ip address for both ipv4 and ipv6 formats. Use the options
+// WithIPv4Only(), WithIPv6Only(), WithRejectLoopback() and WithRejectPrivate() to only
+// accept certain addresses (an empty string is returned for any other address).
 //
 //	View examples: sanitize_test.go
-func IPAddress(original string) string {
+func IPAddress(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+
 	// Parse the IP - Remove any invalid characters first
 	ipAddress := net.ParseIP(
-		string(ipAddressRegExp.ReplaceAll([]byte(original), emptySpace)),
+		filterASCII(original, ipAddressTable),
 	)
-	if ipAddress == nil {
+	if ipAddress == nil || !o.allowIP(ipAddress) {
 		return ""
 	}
 
-	return ipAddress.String()
+	return o.after(ipAddress.String())
 }
 
 // Numeric returns numbers only.
 //
 //	View examples: sanitize_test.go
-func Numeric(original string) string {
-	return string(numericRegExp.ReplaceAll([]byte(original), emptySpace))
+func Numeric(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	if o != nil && o.foldDigits {
+		original = foldDigits(original)
+	}
+	return o.after(o.clamp(filterASCII(original, numericTable)))
 }
 
 // PathName returns a formatted path compliant name.
 //
 //	View examples: sanitize_test.go
-func PathName(original string) string {
-	return string(pathNameRegExp.ReplaceAll([]byte(original), emptySpace))
+func PathName(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(filterASCII(original, pathNameTable))
 }
 
 // Punctuation returns a string with basic punctuation preserved.
 //
 //	View examples: sanitize_test.go
-func Punctuation(original string) string {
-	return string(punctuationRegExp.ReplaceAll([]byte(original), emptySpace))
+func Punctuation(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(filterASCII(original, punctuationTable))
+}
+
+// RemoveDigits returns the string with all digits (0-9) removed.
+// This is the inverse of Numeric.
+//
+//	View examples: sanitize_test.go
+func RemoveDigits(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(filterASCII(original, removeDigitsTable))
+}
+
+// RemoveLetters returns the string with all letters (a-z and A-Z) removed.
+// This is the inverse of Alpha.
+//
+//	View examples: sanitize_test.go
+func RemoveLetters(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(filterASCII(original, removeLettersTable))
+}
+
+// RemoveNonASCII returns the string with all non-ASCII characters removed.
+//
+//	View examples: sanitize_test.go
+func RemoveNonASCII(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(filterASCII(original, removeNonASCIITable))
+}
+
+// RemovePunctuation returns the string with all punctuation removed, this includes
+// the ASCII symbols (!"#$%&'()*+,-./:;<=>?@[\]^_`{|}~) and any unicode punctuation.
+//
+//	View examples: sanitize_test.go
+func RemovePunctuation(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(removeMatches(removePunctuationRegExp, original))
+}
+
+// RemoveWhitespace returns the string with all white space removed (spaces, tabs,
+// carriage returns, line feeds and unicode spaces).
+//
+//	View examples: sanitize_test.go
+func RemoveWhitespace(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(removeMatches(removeWhitespaceRegExp, original))
 }
 
 // ScientificNotation returns sanitized decimal/float values in either positive or negative.
 //
 //	View examples: sanitize_test.go
-func ScientificNotation(original string) string {
-	return string(scientificNotationRegExp.ReplaceAll([]byte(original), emptySpace))
+func ScientificNotation(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(filterASCII(original, scientificNotationTable))
 }
 
-// Scripts removes all scripts, iframes and embeds tags from string.
+// Scripts removes all scripts, iframes, embeds and objects (tags and content) from string.
+// Unclosed tags are removed up to the end of the string. Use the WithScriptTags() option
+// to change the list of tags that are removed.
 //
 //	View examples: sanitize_test.go
-func Scripts(original string) string {
-	return string(scriptRegExp.ReplaceAll([]byte(original), emptySpace))
+func Scripts(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+
+	tags := scriptTags
+	if o != nil && len(o.scriptTags) > 0 {
+		tags = o.scriptTags
+	}
+	return o.after(removeElements(original, tags, o.report()))
 }
 
 // SingleLine returns a single line string, removes all carriage returns.
 //
 //	View examples: sanitize_test.go
-func SingleLine(original string) string {
-	return singleLineRegExp.ReplaceAllString(original, " ")
+func SingleLine(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	if !singleLineRegExp.MatchString(original) {
+		return o.after(original)
+	}
+	return o.after(singleLineRegExp.ReplaceAllString(original, " "))
 }
 
 // Time returns just the time part of the string.
 //
 //	View examples: sanitize_test.go
-func Time(original string) string {
-	return string(timeRegExp.ReplaceAll([]byte(original), emptySpace))
+func Time(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(filterASCII(original, timeTable))
 }
 
-// URI returns allowed URI characters only.
+// URI returns allowed URI characters only. Use WithPercentEncoding to also validate and
+// normalize the percent-encoding.
 //
 //	View examples: sanitize_test.go
-func URI(original string) string {
-	return string(uriRegExp.ReplaceAll([]byte(original), emptySpace))
+func URI(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(filterASCII(o.percentEncode(original, uriTable), uriTable))
 }
 
-// URL returns a formatted url friendly string.
+// URL returns a formatted url friendly string. Credentials in the url (user:pass@) are
+// kept, use RedactURLCredentials before the url is logged or stored. Use WithPercentEncoding
+// to also validate and normalize the percent-encoding, WithStripQuery and WithStripFragment
+// to remove those parts and WithSchemeDefault to add a scheme to urls without one.
 //
 //	View examples: sanitize_test.go
-func URL(original string) string {
-	return string(urlRegExp.ReplaceAll([]byte(original), emptySpace))
+func URL(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(o.urlParts(filterASCII(o.percentEncode(original, urlTable), urlTable)))
 }
 
 // XML returns a string without any <XML> tags - alias of HTML.
 //
 //	View examples: sanitize_test.go
-func XML(original string) string {
-	return HTML(original)
+func XML(original string, opts ...Option) string {
+	return HTML(original, opts...)
 }
 
-// XSS removes known XSS attack strings or script strings.
+// XSS removes known XSS attack strings or script strings, ignoring case (<SCRIPT and
+// JavaScript: are removed). Strings are removed until none are left, so removing one can
+// not re-form another (javascjavascript:ript: => ""). Use XSSWithPolicy to remove
+// additional strings.
 //
 //	View examples: sanitize_test.go
-func XSS(original string) string {
-	original = strings.Replace(original, "<script", "", -1)
-	original = strings.Replace(original, "script>", "", -1)
-	original = strings.Replace(original, "eval(", "", -1)
-	original = strings.Replace(original, "eval&#40;", "", -1)
-	original = strings.Replace(original, "javascript:", "", -1)
-	original = strings.Replace(original, "javascript&#58;", "", -1)
-	original = strings.Replace(original, "fromCharCode", "", -1)
-	original = strings.Replace(original, "&#62;", "", -1)
-	original = strings.Replace(original, "&#60;", "", -1)
-	original = strings.Replace(original, "&lt;", "", -1)
-	original = strings.Replace(original, "&rt;", "", -1)
-	return original
+func XSS(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	original = removeTokens(original, xssTokens, true, o.report())
+	return o.after(original)
 }
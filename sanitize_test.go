@@ -2,7 +2,10 @@ package sanitize
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -236,6 +239,175 @@ func ExampleCustom_numeric() {
 	// Output: 2
 }
 
+// TestCustomCompiled tests the custom compiled sanitize method
+func TestCustomCompiled(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		expected string
+		regex    *regexp.Regexp
+	}{
+		{"ThisWorks123!", "ThisWorks123", regexp.MustCompile(`[^a-zA-Z0-9]`)},
+		{"ThisWorks1.23!", "1.23", regexp.MustCompile(`[^0-9.-]`)},
+		{"ThisWorks1.23!", "ThisWorks123", regexp.MustCompile(`[^0-9a-zA-Z]`)},
+		{"ThisWorks1.23!", "", nil},
+	}
+
+	for _, test := range tests {
+		output := CustomCompiled(test.input, test.regex)
+		assert.Equal(t, test.expected, output)
+	}
+}
+
+// BenchmarkCustomCompiled benchmarks the CustomCompiled method
+func BenchmarkCustomCompiled(b *testing.B) {
+	re := regexp.MustCompile(`[^a-zA-Z0-9]`)
+	for i := 0; i < b.N; i++ {
+		_ = CustomCompiled("This is the test string 12345.", re)
+	}
+}
+
+// ExampleCustomCompiled example using CustomCompiled() using an alpha regex
+func ExampleCustomCompiled() {
+	fmt.Println(CustomCompiled("Example String 2!", regexp.MustCompile(`[^a-zA-Z]`)))
+	// Output: ExampleString
+}
+
+// TestCustomSafe tests the custom safe sanitize method
+func TestCustomSafe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid patterns", func(t *testing.T) {
+		output, err := CustomSafe("ThisWorks1.23!", `[^0-9.-]`)
+		require.NoError(t, err)
+		assert.Equal(t, "1.23", output)
+	})
+
+	t.Run("invalid patterns", func(t *testing.T) {
+		for _, pattern := range []string{`[^0-9`, `(abc`, `a**`, `\p{Unknown}`} {
+			output, err := CustomSafe("ThisWorks1.23!", pattern)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidPattern)
+			assert.Equal(t, "", output)
+		}
+	})
+
+	t.Run("complexity budget", func(t *testing.T) {
+		for _, pattern := range []string{strings.Repeat(`[a-z]{1000}`, 11), strings.Repeat("ab", 6000)} {
+			output, err := CustomSafe("ThisWorks1.23!", pattern)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrPatternTooComplex)
+			assert.Equal(t, "", output)
+		}
+
+		_, err := CustomSafe("ThisWorks1.23!", `[^0-9.-]{1,50}`, WithPatternBudget(10))
+		assert.ErrorIs(t, err, ErrPatternTooComplex)
+
+		output, err := CustomSafe("ab-"+strings.Repeat("x", 1000), `x{1000}`, WithPatternBudget(2000))
+		require.NoError(t, err)
+		assert.Equal(t, "ab-", output)
+	})
+
+	t.Run("match timeout", func(t *testing.T) {
+		output, err := CustomSafe("ThisWorks1.23!", `[^0-9.-]`, WithMatchTimeout(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, "1.23", output)
+
+		input := strings.Repeat("abcdefghij", 1<<20)
+		output, err = CustomSafe(input, `(\w+\s*)+[0-9]`, WithMatchTimeout(time.Nanosecond))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrPatternTimeout)
+		assert.Equal(t, "", output)
+	})
+}
+
+// BenchmarkCustomSafe benchmarks the CustomSafe method
+func BenchmarkCustomSafe(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = CustomSafe("This is the test string 12345.", `[^a-zA-Z0-9]`)
+	}
+}
+
+// ExampleCustomSafe example using CustomSafe() with an invalid regex
+func ExampleCustomSafe() {
+	_, err := CustomSafe("Example String 2!", `[^a-zA-Z`)
+	fmt.Println(err)
+	// Output: invalid regular expression: error parsing regexp: missing closing ]: `[^a-zA-Z`
+}
+
+// ExampleCustomSafe_budget example using CustomSafe() with a complexity budget
+func ExampleCustomSafe_budget() {
+	_, err := CustomSafe("Example String 2!", `[a-z]{1,100}`, WithPatternBudget(50))
+	fmt.Println(err)
+	// Output: pattern is too complex: 201 instructions (max 50)
+}
+
+// TestCustomReplace tests the CustomReplace method
+func TestCustomReplace(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid patterns", func(t *testing.T) {
+		var tests = []struct {
+			name        string
+			input       string
+			pattern     string
+			replacement string
+			expected    string
+		}{
+			{"date", "Due 2024-03-15", `(\d{4})-(\d{2})-(\d{2})`, "$3/$2/$1", "Due 15/03/2024"},
+			{"named groups", "2024-03-15", `(?P<y>\d{4})-(?P<m>\d{2})-(?P<d>\d{2})`, "${d}.${m}.${y}", "15.03.2024"},
+			{"mask", "4111111111111111", `^\d{12}(\d{4})$`, "************$1", "************1111"},
+			{"braces", "ab", `(a)(b)`, "${1}x$2", "axb"},
+			{"literal dollar", "5", `(\d)`, "$$$1", "$5"},
+			{"deletion", "a1b2", `\d`, "", "ab"},
+			{"no match", "no digits", `\d`, "#", "no digits"},
+			{"empty", "", `\d`, "#", ""},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := CustomReplace(test.input, test.pattern, test.replacement)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid patterns", func(t *testing.T) {
+		output, err := CustomReplace("2024-03-15", `(\d{4}`, "$1")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidPattern)
+		assert.Equal(t, "", output)
+
+		output, err = CustomReplace("2024-03-15", `\d{1,100}`, "$1", WithPatternBudget(10))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrPatternTooComplex)
+		assert.Equal(t, "", output)
+	})
+
+	t.Run("options", func(t *testing.T) {
+		output, err := CustomReplace(" 2024-03-15 ", `(\d{4})-(\d{2})-(\d{2})`, "$2/$3/$1",
+			WithTrim(), WithMatchTimeout(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, "03/15/2024", output)
+	})
+}
+
+// BenchmarkCustomReplace benchmarks the CustomReplace method
+func BenchmarkCustomReplace(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = CustomReplace("Due 2024-03-15", `(\d{4})-(\d{2})-(\d{2})`, "$3/$2/$1")
+	}
+}
+
+// ExampleCustomReplace example using CustomReplace() to mask all but the last 4 digits
+func ExampleCustomReplace() {
+	output, _ := CustomReplace("Card: 4111 1111 1111 1234", `\d{4} \d{4} \d{4} (\d{4})`, "**** **** **** $1")
+	fmt.Println(output)
+	// Output: Card: **** **** **** 1234
+}
+
 // TestDecimal tests the decimal sanitize method
 func TestDecimal(t *testing.T) {
 	t.Parallel()
@@ -458,6 +630,15 @@ func TestEmail(t *testing.T) {
 		output := Email(test.input, test.preserveCase)
 		assert.Equal(t, test.expected, output)
 	}
+
+	t.Run("unicode email", func(t *testing.T) {
+		assert.Equal(t, "tst@exmple.com", Email("t\u00e9st@ex\u00e1mple.com", false))
+		assert.Equal(t, "t\u00e9st@ex\u00e1mple.com", Email("T\u00c9st@Ex\u00e1mple.com", false, WithUnicodeEmail()))
+		assert.Equal(t, "T\u00c9st@ex\u00e1mple.com", Email("T\u00c9st@ex\u00e1mple.com", true, WithUnicodeEmail()))
+		assert.Equal(t, "\u7528\u6237@\u4f8b\u5b50.\u5e7f\u544a",
+			Email("mailto: \u7528\u6237@\u4f8b\u5b50.\u5e7f\u544a\u2026", false, WithUnicodeEmail()))
+		assert.Equal(t, "test_me@gmail.com", Email(" <<test_ME @GmAil.com!>> ", false, WithUnicodeEmail()))
+	})
 }
 
 // BenchmarkEmail benchmarks the Email method
@@ -486,6 +667,15 @@ func ExampleEmail_preserveCase() {
 	// Output: Person@Example.COM
 }
 
+// ExampleEmail_unicode example using Email() with internationalized addresses
+func ExampleEmail_unicode() {
+	fmt.Println(Email("T\u00e9st@Ex\u00e1mple.com", false))
+	fmt.Println(Email("T\u00e9st@Ex\u00e1mple.com", false, WithUnicodeEmail()))
+	// Output:
+	// tst@exmple.com
+	// tést@exámple.com
+}
+
 // TestFirstToUpper tests the first to upper method
 func TestFirstToUpper(t *testing.T) {
 	t.Parallel()
@@ -565,18 +755,45 @@ func TestHTML(t *testing.T) {
 		{"<b>This works?</b>", "This works?"},
 		{"<html><b>This works?</b><i></i></br></html>", "This works?"},
 		{"<html><b class='test'>This works?</b><i></i></br></html>", "This works?"},
+		{"No tags", "No tags"},
+		{"<p title='a > b'>Quoted</p>", "Quoted"},
+		{"<!-- a > b -->Comment", "Comment"},
+		{"1 < 2 and 3 > 2", "1 < 2 and 3 > 2"},
+		{"Unclosed <b class='x'", "Unclosed "},
+		{"<![CDATA[data]]>Text<!DOCTYPE html>", "Text"},
+		{"<P>Upper</P>", "Upper"},
+		{"<<b>b>x", "b>x"},
+		{"<</i>/b>x", "/b>x"},
+		{"a < <b></b>b", "a < b"},
+		{"<style><b>bold</b></style>", "bold"},
+		{"a<!-- <b>hidden</b> -->b", "ab"},
+		{"a<!-- unterminated <b>hidden</b>", "a"},
+		{"a<!--b--!>c", "ac"},
+		{"a<!-->b", "ab"},
+		{"<!--[if mso]><table><tr><td>Outlook</td></tr></table><![endif]-->All", "All"},
+		{"<!--[if !mso]><!-->Not Outlook<!--<![endif]-->", "Not Outlook"},
+		{"<![if !IE]>Not IE<![endif]>", "Not IE"},
+		{"a<![CDATA[<b>x</b> ]]> ]]>b", "a ]]>b"},
+		{"a<![CDATA[unterminated", "a"},
 	}
 
 	for _, test := range tests {
 		output := HTML(test.input)
 		assert.Equal(t, test.expected, output)
 	}
+
+	t.Run("keep cdata", func(t *testing.T) {
+		assert.Equal(t, "Fish & chips", HTML("<![CDATA[Fish & chips]]>", WithKeepCDATA()))
+		assert.Equal(t, "a 1 < 2 b", HTML("a<![CDATA[ <b>1 < 2</b> ]]>b", WithKeepCDATA()))
+		assert.Equal(t, "ab>", HTML("a<![CDATA[<]]>b>", WithKeepCDATA()))
+		assert.Equal(t, "text", XML("<name><![CDATA[text]]></name>", WithKeepCDATA()))
+	})
 }
 
 // BenchmarkHTML benchmarks the HTML method
 func BenchmarkHTML(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		_ = FormalName("<html><b>Test This!</b></html>")
+		_ = HTML("<html><b>Test This!</b></html>")
 	}
 }
 
@@ -620,6 +837,37 @@ func TestIPAddress(t *testing.T) {
 		output := IPAddress(test.input)
 		assert.Equal(t, test.expected, output)
 	}
+
+	t.Run("options", func(t *testing.T) {
+		var optionTests = []struct {
+			name     string
+			input    string
+			opts     []Option
+			expected string
+		}{
+			{"ipv4 only", "8.8.8.8", []Option{WithIPv4Only()}, "8.8.8.8"},
+			{"ipv4 only mapped", "::ffff:8.8.8.8", []Option{WithIPv4Only()}, "8.8.8.8"},
+			{"ipv4 only rejects ipv6", "2606:4700::1111", []Option{WithIPv4Only()}, ""},
+			{"ipv6 only", "2606:4700::1111", []Option{WithIPv6Only()}, "2606:4700::1111"},
+			{"ipv6 only rejects ipv4", "8.8.8.8", []Option{WithIPv6Only()}, ""},
+			{"reject loopback", "127.0.0.1", []Option{WithRejectLoopback()}, ""},
+			{"reject loopback ipv6", "::1", []Option{WithRejectLoopback()}, ""},
+			{"reject loopback allows private", "10.0.0.1", []Option{WithRejectLoopback()}, "10.0.0.1"},
+			{"reject private", "192.168.0.1", []Option{WithRejectPrivate()}, ""},
+			{"reject private link-local", "169.254.169.254", []Option{WithRejectPrivate()}, ""},
+			{"reject private unique local", "fd00::1", []Option{WithRejectPrivate()}, ""},
+			{"reject private allows loopback", "127.0.0.1", []Option{WithRejectPrivate()}, "127.0.0.1"},
+			{"reject private allows public", "8.8.8.8", []Option{WithRejectPrivate()}, "8.8.8.8"},
+			{"public ipv4", " 1.1.1.1 ", []Option{WithIPv4Only(), WithRejectPrivate(), WithRejectLoopback()}, "1.1.1.1"},
+			{"public ipv4 rejects", "127.0.0.1", []Option{WithIPv4Only(), WithRejectPrivate(), WithRejectLoopback()}, ""},
+		}
+
+		for _, test := range optionTests {
+			t.Run(test.name, func(t *testing.T) {
+				assert.Equal(t, test.expected, IPAddress(test.input, test.opts...))
+			})
+		}
+	})
 }
 
 // BenchmarkIPAddress benchmarks the IPAddress method
@@ -642,6 +890,15 @@ func ExampleIPAddress() {
 	// Output: 192.168.0.1
 }
 
+// ExampleIPAddress_public example using IPAddress() to only accept public ipv4 addresses
+func ExampleIPAddress_public() {
+	fmt.Println(IPAddress(" 8.8.8.8 ", WithIPv4Only(), WithRejectPrivate(), WithRejectLoopback()))
+	fmt.Println(IPAddress(" 192.168.0.1 ", WithIPv4Only(), WithRejectPrivate(), WithRejectLoopback()) == "")
+	// Output:
+	// 8.8.8.8
+	// true
+}
+
 // ExampleIPAddress_ipv6 example using IPAddress() for IPV6 address
 func ExampleIPAddress_ipv6() {
 	fmt.Println(IPAddress(" 2602:305:bceb:1bd0:44ef:fedb:4f8f:da4f "))
@@ -747,6 +1004,167 @@ func ExamplePunctuation() {
 	// Output: "Does" 'this' work? this too
 }
 
+// TestRemoveDigits tests the RemoveDigits method
+func TestRemoveDigits(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		expected string
+	}{
+		{"Test1 This2 String3!", "Test This String!"},
+		{"1234567890", ""},
+		{"No digits", "No digits"},
+		{"  $-1.034234  Price", "  $-.  Price"},
+	}
+
+	for _, test := range tests {
+		output := RemoveDigits(test.input)
+		assert.Equal(t, test.expected, output)
+	}
+}
+
+// BenchmarkRemoveDigits benchmarks the RemoveDigits method
+func BenchmarkRemoveDigits(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = RemoveDigits("This is the test string 12345.")
+	}
+}
+
+// ExampleRemoveDigits example using RemoveDigits()
+func ExampleRemoveDigits() {
+	fmt.Println(RemoveDigits("Example String 2!"))
+	// Output: Example String !
+}
+
+// TestRemoveLetters tests the RemoveLetters method
+func TestRemoveLetters(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		expected string
+	}{
+		{"Test1 This2 String3!", "1 2 3!"},
+		{"abcXYZ", ""},
+		{"(555) 123-4567 ext", "(555) 123-4567 "},
+	}
+
+	for _, test := range tests {
+		output := RemoveLetters(test.input)
+		assert.Equal(t, test.expected, output)
+	}
+}
+
+// BenchmarkRemoveLetters benchmarks the RemoveLetters method
+func BenchmarkRemoveLetters(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = RemoveLetters("This is the test string 12345.")
+	}
+}
+
+// ExampleRemoveLetters example using RemoveLetters()
+func ExampleRemoveLetters() {
+	fmt.Println(RemoveLetters("Example String 2!"))
+	// Output:  2!
+}
+
+// TestRemoveNonASCII tests the RemoveNonASCII method
+func TestRemoveNonASCII(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		expected string
+	}{
+		{"“This is a quote with tick`s … ” ☺ ", "This is a quote with tick`s    "},
+		{"café", "caf"},
+		{"plain ascii!", "plain ascii!"},
+	}
+
+	for _, test := range tests {
+		output := RemoveNonASCII(test.input)
+		assert.Equal(t, test.expected, output)
+	}
+}
+
+// BenchmarkRemoveNonASCII benchmarks the RemoveNonASCII method
+func BenchmarkRemoveNonASCII(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = RemoveNonASCII("“This is a quote with tick`s … ” ☺ ")
+	}
+}
+
+// ExampleRemoveNonASCII example using RemoveNonASCII()
+func ExampleRemoveNonASCII() {
+	fmt.Println(RemoveNonASCII("Café ☺"))
+	// Output: Caf
+}
+
+// TestRemovePunctuation tests the RemovePunctuation method
+func TestRemovePunctuation(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		expected string
+	}{
+		{"Test This String-!123", "Test This String123"},
+		{`~!@#$%^&*()-_Symbols=+[{]};:'"<>,./?`, "Symbols"},
+		{"“This is a quote with tick`s … ” ☺", "This is a quote with ticks   ☺"},
+	}
+
+	for _, test := range tests {
+		output := RemovePunctuation(test.input)
+		assert.Equal(t, test.expected, output)
+	}
+}
+
+// BenchmarkRemovePunctuation benchmarks the RemovePunctuation method
+func BenchmarkRemovePunctuation(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = RemovePunctuation("Hello, world! This is the test string.")
+	}
+}
+
+// ExampleRemovePunctuation example using RemovePunctuation()
+func ExampleRemovePunctuation() {
+	fmt.Println(RemovePunctuation("Example, String #2!"))
+	// Output: Example String 2
+}
+
+// TestRemoveWhitespace tests the RemoveWhitespace method
+func TestRemoveWhitespace(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		expected string
+	}{
+		{" Test This String ", "TestThisString"},
+		{"\nThis\r\n\tThat\v\f", "ThisThat"},
+		{"non\u00a0breaking\u2003space", "nonbreakingspace"},
+	}
+
+	for _, test := range tests {
+		output := RemoveWhitespace(test.input)
+		assert.Equal(t, test.expected, output)
+	}
+}
+
+// BenchmarkRemoveWhitespace benchmarks the RemoveWhitespace method
+func BenchmarkRemoveWhitespace(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = RemoveWhitespace("This is the test string 12345.")
+	}
+}
+
+// ExampleRemoveWhitespace example using RemoveWhitespace()
+func ExampleRemoveWhitespace() {
+	fmt.Println(RemoveWhitespace(" Example\tString 2! "))
+	// Output: ExampleString2!
+}
+
 // TestScientificNotation tests the scientific notation sanitize method
 func TestScientificNotation(t *testing.T) {
 	t.Parallel()
@@ -797,12 +1215,36 @@ func TestScripts(t *testing.T) {
 		{`this <iframe width="50" class="something"></iframe>`, "this "},
 		{`this <embed width="50" class="something"></embed>`, "this "},
 		{`this <object width="50" class="something"></object>`, "this "},
+		{"this <SCRIPT>alert(1)</SCRIPT>", "this "},
+		{"this <script>oops", "this "},
+		{"this <script src='x'", "this "},
+		{"<script>a()</script> keep <script>b()</script> this", " keep  this"},
+		{"<object><object>inner</object>still inside</object>after", "after"},
+		{"<iframe>unclosed <b>text</b>", ""},
+		{"<script>document.write('</scr' + 'ipt>')</script>done", "done"},
+		{"<embed src='x'>after embed", "after embed"},
+		{"stray </script> end tag", "stray  end tag"},
+		{"<p>keep <b>this</b></p>", "<p>keep <b>this</b></p>"},
+		{"1 < 2 and 3 > 2", "1 < 2 and 3 > 2"},
+		{"<<script></script>script>alert(1)", "script>alert(1)"},
+		{"<<<iframe></iframe>b>", "b>"},
+		{"<svg><style><script>alert(1)</script></style></svg>", "<svg><style></style></svg>"},
+		{"<svg><title><script>alert(1)</script></title></svg>", "<svg><title></title></svg>"},
+		{"<math><style><iframe src=x></iframe>a</style></math>", "<math><style>a</style></math>"},
+		{"<svg><style><script>alert(1)</style>b</script>", "<svg><style></style>b"},
+		{"<textarea><script>x</script></textarea>", "<textarea></textarea>"},
 	}
 
 	for _, test := range tests {
 		output := Scripts(test.input)
 		assert.Equal(t, test.expected, output)
 	}
+
+	t.Run("custom tags", func(t *testing.T) {
+		output := Scripts("<style>b{}</style><script>x</script><form><input></form>ok",
+			WithScriptTags("style", "form"))
+		assert.Equal(t, "<script>x</script>ok", output)
+	})
 }
 
 // BenchmarkScripts benchmarks the Scripts method
@@ -818,6 +1260,12 @@ func ExampleScripts() {
 	// Output: DoesWork?
 }
 
+// ExampleScripts_customTags example using Scripts() with the WithScriptTags() option
+func ExampleScripts_customTags() {
+	fmt.Println(Scripts("<style>p{}</style><p>Text</p>", WithScriptTags("style")))
+	// Output: <p>Text</p>
+}
+
 // TestSingleLine test the single line sanitize method
 func TestSingleLine(t *testing.T) {
 	t.Parallel()
@@ -909,12 +1357,33 @@ func TestURI(t *testing.T) {
 		output := URI(test.input)
 		assert.Equal(t, test.expected, output)
 	}
+
+	t.Run("percent-encoding", func(t *testing.T) {
+		var tests = []struct {
+			input    string
+			expected string
+		}{
+			{"/a%20b?q=%2f", "/a%20b?q=%2F"},
+			{"/%ZZ/x", "/ZZ/x"},
+			{"/a%%20b", "/a%20b"},
+			{"/%7euser/%41%2d", "/%7Euser/A-"},
+			{"/trailing%", "/trailing"},
+			{"/short%2", "/short2"},
+			{"/%25%2541", "/%25%2541"},
+		}
+
+		for _, test := range tests {
+			output := URI(test.input, WithPercentEncoding())
+			assert.Equal(t, test.expected, output)
+			assert.Equal(t, output, URI(output, WithPercentEncoding()))
+		}
+	})
 }
 
 // BenchmarkURI benchmarks the URI method
 func BenchmarkURI(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		_ = XSS("/Test/This/Url/?param=value")
+		_ = URI("/Test/This/Url/?param=value")
 	}
 }
 
@@ -947,6 +1416,35 @@ func TestURL(t *testing.T) {
 			assert.Equal(t, test.expected, output)
 		})
 	}
+
+	t.Run("percent-encoding", func(t *testing.T) {
+		output := URL("https://domain.com/a%zz/b%2fc?q=%e2%82%ac&x=%%20#page%", WithPercentEncoding())
+		assert.Equal(t, "https://domain.com/azz/b%2Fc?q=%E2%82%AC&x=%20#page", output)
+		assert.Equal(t, output, URL(output, WithPercentEncoding()))
+	})
+
+	t.Run("url parts", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			opts     []Option
+			expected string
+		}{
+			{"strip query", "https://domain.com/a?b=1#top", []Option{WithStripQuery()}, "https://domain.com/a#top"},
+			{"strip fragment", "https://domain.com/a?b=1#top", []Option{WithStripFragment()}, "https://domain.com/a?b=1"},
+			{"strip both", "/a?b=1#top", []Option{WithStripQuery(), WithStripFragment()}, "/a"},
+			{"scheme default", "domain.com/a", []Option{WithSchemeDefault("https")}, "https://domain.com/a"},
+			{"protocol relative", "//domain.com/a", []Option{WithSchemeDefault("https")}, "https://domain.com/a"},
+			{"scheme kept", "ftp://domain.com/a", []Option{WithSchemeDefault("https")}, "ftp://domain.com/a"},
+			{"empty", "", []Option{WithSchemeDefault("https")}, ""},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				assert.Equal(t, test.expected, URL(test.input, test.opts...))
+			})
+		}
+	})
 }
 
 // BenchmarkURL benchmarks the URL method
@@ -962,6 +1460,19 @@ func ExampleURL() {
 	// Output: https://Example.com/This/Works?No&this
 }
 
+// ExampleURL_canonical example using URL() with the url options
+func ExampleURL_canonical() {
+	opts := []Option{WithSchemeDefault("https"), WithStripQuery(), WithStripFragment()}
+	fmt.Println(URL("example.com/page?ref=home#comments", opts...))
+	// Output: https://example.com/page
+}
+
+// ExampleURL_percentEncoding example using URL() with the WithPercentEncoding() option
+func ExampleURL_percentEncoding() {
+	fmt.Println(URL("https://example.com/%7euser/%41%%20b%ZZ?q=%2f", WithPercentEncoding()))
+	// Output: https://example.com/%7Euser/A%20bZZ?q=%2F
+}
+
 // TestXML tests the XML sanitize method
 func TestXML(t *testing.T) {
 	t.Parallel()
@@ -1008,11 +1519,21 @@ func TestXSS(t *testing.T) {
 		{"javascript&#58;('test');", "('test');"},
 		{"fromCharCode('test');", "('test');"},
 		{"&#60;&#62;fromCharCode('test');&#62;&#60;", "('test');"},
+		{"<scr<scriptipt>alert(1)</scr</scriptipt>", ">alert(1)</scr</scriptipt>"},
+		{"javascjavascript:ript:alert(1)", "alert(1)"},
+		{"evaeval(l(x)", "x)"},
+		{"&l&lt;t;", ""},
+		{"<SCRIPT>alert(1)</Script>", ">alert(1)</"},
+		{"JavaScript:alert(1)", "alert(1)"},
+		{"String.FROMCHARCODE(88)", "String.(88)"},
+		{"&LT;b&#X3C;", "b&#X3C;"},
+		{"no xss here", "no xss here"},
 	}
 
 	for _, test := range tests {
 		output := XSS(test.input)
 		assert.Equal(t, test.expected, output)
+		assert.Equal(t, output, XSS(output))
 	}
 }
 
@@ -1028,3 +1549,34 @@ func ExampleXSS() {
 	fmt.Println(XSS("<script>This?</script>"))
 	// Output: >This?</
 }
+
+// TestCleanInputAllocations tests that clean input is returned without allocating
+func TestCleanInputAllocations(t *testing.T) {
+	var tests = []struct {
+		name string
+		fn   func() string
+	}{
+		{"Alpha", func() string { return Alpha("TestThisString", false) }},
+		{"Alpha with spaces", func() string { return Alpha("Test This String", true) }},
+		{"AlphaNumeric", func() string { return AlphaNumeric("Test123", false) }},
+		{"BitcoinAddress", func() string { return BitcoinAddress("1K6c7LGpdB8LwoGNVfG51dRV9UUEijbrWs") }},
+		{"Decimal", func() string { return Decimal("-123.45") }},
+		{"Email", func() string { return Email("person@example.com", false) }},
+		{"Email preserve case", func() string { return Email("Person@Example.com", true) }},
+		{"FirstToUpper", func() string { return FirstToUpper("Already upper") }},
+		{"FormalName", func() string { return FormalName("John O'Reilly") }},
+		{"HTML", func() string { return HTML("No tags here") }},
+		{"Numeric", func() string { return Numeric("1234567890") }},
+		{"PathName", func() string { return PathName("my-file_name") }},
+		{"Scripts", func() string { return Scripts("No scripts here") }},
+		{"SingleLine", func() string { return SingleLine("One line") }},
+		{"URL", func() string { return URL("https://example.com/path?a=b") }},
+		{"XSS", func() string { return XSS("Nothing dangerous") }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, 0.0, testing.AllocsPerRun(100, func() { _ = test.fn() }))
+		})
+	}
+}
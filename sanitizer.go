@@ -0,0 +1,85 @@
+package sanitize
+
+import "fmt"
+
+// Sanitizer is a typed sanitizer that can fail, it is the building block for tooling that
+// composes sanitizers type-safely. Every SanitizeFunc (including the Funcs and Presets) is a
+// Sanitizer[string], validators can be adapted with SanitizerFunc.
+//
+//	View examples: sanitizer_test.go
+type Sanitizer[T any] interface {
+	Sanitize(value T) (T, error)
+}
+
+// SanitizerFunc adapts a function to the Sanitizer interface (SanitizerFunc[string](TxID))
+type SanitizerFunc[T any] func(value T) (T, error)
+
+// Sanitize calls the function
+func (f SanitizerFunc[T]) Sanitize(value T) (T, error) {
+	return f(value)
+}
+
+// Sanitize runs the function, a SanitizeFunc never returns an error
+func (fn SanitizeFunc) Sanitize(value string) (string, error) {
+	return fn(value), nil
+}
+
+// Compose returns a Sanitizer that runs each of the sanitizers in order, nil sanitizers
+// are skipped and the first error stops the chain
+//
+//	View examples: sanitizer_test.go
+func Compose[T any](sanitizers ...Sanitizer[T]) Sanitizer[T] {
+	return SanitizerFunc[T](func(value T) (T, error) {
+		var err error
+		for _, s := range sanitizers {
+			if s == nil {
+				continue
+			}
+			if value, err = s.Sanitize(value); err != nil {
+				return value, err
+			}
+		}
+		return value, nil
+	})
+}
+
+// SliceSanitizer returns a Sanitizer that sanitizes a copy of a slice with s, the first error
+// (with the index of the element) is returned with a nil slice
+//
+//	View examples: sanitizer_test.go
+func SliceSanitizer[T any](s Sanitizer[T]) Sanitizer[[]T] {
+	return SanitizerFunc[[]T](func(values []T) ([]T, error) {
+		if values == nil {
+			return nil, nil
+		}
+		sanitized := make([]T, len(values))
+		for i, value := range values {
+			var err error
+			if sanitized[i], err = s.Sanitize(value); err != nil {
+				return nil, fmt.Errorf("%w (index %d)", err, i)
+			}
+		}
+		return sanitized, nil
+	})
+}
+
+// MapSanitizer returns a Sanitizer that sanitizes a copy of a map with s (the keys are kept),
+// the first error (with the key of the value) is returned with a nil map
+//
+//	View examples: sanitizer_test.go
+func MapSanitizer[K comparable, V any](s Sanitizer[V]) Sanitizer[map[K]V] {
+	return SanitizerFunc[map[K]V](func(values map[K]V) (map[K]V, error) {
+		if values == nil {
+			return nil, nil
+		}
+		sanitized := make(map[K]V, len(values))
+		for key, value := range values {
+			clean, err := s.Sanitize(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w (key %v)", err, key)
+			}
+			sanitized[key] = clean
+		}
+		return sanitized, nil
+	})
+}
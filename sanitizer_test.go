@@ -0,0 +1,166 @@
+package sanitize
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSanitizer tests the Sanitizer implementations
+func TestSanitizer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sanitize func", func(t *testing.T) {
+		var s Sanitizer[string] = Funcs.Email
+		output, err := s.Sanitize("Bob@Example.COM")
+		require.NoError(t, err)
+		assert.Equal(t, "bob@example.com", output)
+	})
+
+	t.Run("sanitizer func", func(t *testing.T) {
+		s := SanitizerFunc[string](booleanString)
+		output, err := s.Sanitize(" Yes ")
+		require.NoError(t, err)
+		assert.Equal(t, "true", output)
+
+		_, err = s.Sanitize("maybe")
+		assert.ErrorIs(t, err, ErrInvalidBoolean)
+	})
+}
+
+// booleanString returns the boolean as a string (a validator used in the tests)
+func booleanString(value string) (string, error) {
+	b, err := Boolean(value)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(b), nil
+}
+
+// TestCompose tests the Compose method
+func TestCompose(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs in order", func(t *testing.T) {
+		s := Compose[string](SanitizeFunc(strings.TrimSpace), nil, SanitizerFunc[string](booleanString))
+		output, err := s.Sanitize("  on ")
+		require.NoError(t, err)
+		assert.Equal(t, "true", output)
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		calls := 0
+		count := SanitizeFunc(func(s string) string { calls++; return s })
+		s := Compose[string](SanitizerFunc[string](booleanString), count)
+		_, err := s.Sanitize("maybe")
+		assert.ErrorIs(t, err, ErrInvalidBoolean)
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		output, err := Compose[int]().Sanitize(5)
+		require.NoError(t, err)
+		assert.Equal(t, 5, output)
+	})
+}
+
+// TestSliceSanitizer tests the SliceSanitizer method
+func TestSliceSanitizer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sanitized copy", func(t *testing.T) {
+		input := []string{"A@B.COM", " c@d.com "}
+		output, err := SliceSanitizer[string](Funcs.Email).Sanitize(input)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a@b.com", "c@d.com"}, output)
+		assert.Equal(t, "A@B.COM", input[0])
+	})
+
+	t.Run("nil and empty", func(t *testing.T) {
+		s := SliceSanitizer[string](Funcs.Email)
+		output, err := s.Sanitize(nil)
+		require.NoError(t, err)
+		assert.Nil(t, output)
+
+		output, err = s.Sanitize([]string{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{}, output)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		output, err := SliceSanitizer[string](SanitizerFunc[string](booleanString)).Sanitize([]string{"yes", "maybe"})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidBoolean)
+		assert.Contains(t, err.Error(), "(index 1)")
+		assert.Nil(t, output)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		s := SliceSanitizer(SliceSanitizer[string](Funcs.Numeric))
+		output, err := s.Sanitize([][]string{{"a1", "2b"}, {"c3"}})
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{{"1", "2"}, {"3"}}, output)
+	})
+}
+
+// TestMapSanitizer tests the MapSanitizer method
+func TestMapSanitizer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sanitized copy", func(t *testing.T) {
+		input := map[string]string{"Home": "A@B.COM", "work": " c@d.com "}
+		output, err := MapSanitizer[string, string](Funcs.Email).Sanitize(input)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"Home": "a@b.com", "work": "c@d.com"}, output)
+		assert.Equal(t, "A@B.COM", input["Home"])
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		output, err := MapSanitizer[int, string](Funcs.Email).Sanitize(nil)
+		require.NoError(t, err)
+		assert.Nil(t, output)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		s := MapSanitizer[int, string](SanitizerFunc[string](booleanString))
+		output, err := s.Sanitize(map[int]string{7: "maybe"})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidBoolean)
+		assert.Contains(t, err.Error(), "(key 7)")
+		assert.Nil(t, output)
+	})
+
+	t.Run("slice values", func(t *testing.T) {
+		s := MapSanitizer[string](SliceSanitizer[string](Funcs.Alpha))
+		output, err := s.Sanitize(map[string][]string{"tags": {"#go", "rust!"}})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"tags": {"go", "rust"}}, output)
+	})
+}
+
+// BenchmarkSliceSanitizer benchmarks the SliceSanitizer method
+func BenchmarkSliceSanitizer(b *testing.B) {
+	s := SliceSanitizer[string](Funcs.Email)
+	input := []string{"A@B.COM", " c@d.com ", "mailto:e@f.com"}
+	for i := 0; i < b.N; i++ {
+		_, _ = s.Sanitize(input)
+	}
+}
+
+// ExampleSliceSanitizer example using SliceSanitizer()
+func ExampleSliceSanitizer() {
+	emails := SliceSanitizer[string](Funcs.Email)
+	fmt.Println(emails.Sanitize([]string{"A@B.COM", " mailto:c@d.com "}))
+	// Output: [a@b.com c@d.com] <nil>
+}
+
+// ExampleCompose example using Compose()
+func ExampleCompose() {
+	txID := Compose[string](Funcs.RemoveWhitespace, SanitizerFunc[string](TxID))
+	_, err := txID.Sanitize("not a hash")
+	fmt.Println(err != nil)
+	// Output: true
+}
@@ -0,0 +1,141 @@
+// Package sanitizetest provides test helpers for sanitizers built on go-sanitize: a
+// deterministic corpus of tricky input, property assertions and golden-file checks.
+package sanitizetest
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mrz1836/go-sanitize"
+)
+
+// UpdateEnv is the environment variable that rewrites the golden files with the current
+// output of the sanitizers (SANITIZETEST_UPDATE=1 go test ./...)
+const UpdateEnv = "SANITIZETEST_UPDATE"
+
+// fragments are combined in pairs to build the corpus, so tokens can be split across them
+var fragments = []string{
+	"", "abc", "  Hello   World  ", "MiXeD CaSe", "a-b_c.d", "o'Neil, Jr.", "123", "-1.5e-10", "$1,000.50",
+	"<b>bold</b> &amp; <i>x</i>", "<<b>b>x", "</", "<", ">", "<!--c-->", "<![CDATA[x]]>", "<style>a</style>",
+	"<scr<script>ipt>alert(1)</scr</script>ipt>", "<a href=\"javascript:x\">y</a>", "javascjavascript:ript:",
+	"eval((x))", "&lt;script&gt;", "expression(alert(1))", "user@Example.COM", "https://www.example.com/p?q=1#x",
+	"192.168.0.1", "2001:db8::1", "/path/../to/file.txt", "Crème brûlée", "Ünïcödé", "\ufb01", "ß", "İ",
+	"line1\nline2\r\n\tline3", "\x00\x07\x1b[31m", "a\u00a0b\u200bc", "\u202egpj.exe", "e\u0301\u0301\u0301",
+	"\U0001F468\u200d\U0001F469", "\xff\xfe", "\u0661\u0662\u0663",
+}
+
+// Corpus returns the deterministic test corpus (every pair of the built-in fragments), it
+// covers markup, scripts, unicode edge cases, control characters and invalid UTF-8
+func Corpus() []string {
+	samples := make([]string, 0, len(fragments)*len(fragments))
+	for _, first := range fragments {
+		for _, second := range fragments {
+			samples = append(samples, first+second)
+		}
+	}
+	return samples
+}
+
+// AssertOnlyRunes checks that the output of fn for every sample of the corpus (and the extra
+// samples) only contains the allowed runes and is valid UTF-8, it returns false on failure
+func AssertOnlyRunes(t testing.TB, fn sanitize.SanitizeFunc, allowed func(r rune) bool, samples ...string) bool {
+	t.Helper()
+	for _, sample := range append(Corpus(), samples...) {
+		output := fn(sample)
+		if !utf8.ValidString(output) {
+			t.Errorf("sanitizer output %q for %q is not valid UTF-8", output, sample)
+			return false
+		}
+		for _, r := range output {
+			if !allowed(r) {
+				t.Errorf("sanitizer output %q for %q contains the rune %q (%U)", output, sample, r, r)
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// AssertIdempotent checks that running fn on its own output does not change it (f(f(x)) == f(x))
+// for every sample of the corpus (and the extra samples), it returns false on failure
+func AssertIdempotent(t testing.TB, fn sanitize.SanitizeFunc, samples ...string) bool {
+	t.Helper()
+	for _, sample := range append(Corpus(), samples...) {
+		once := fn(sample)
+		if twice := fn(once); twice != once {
+			t.Errorf("sanitizer is not idempotent for %q: %q => %q", sample, once, twice)
+			return false
+		}
+	}
+	return true
+}
+
+// GoldenCase is an input and its expected output in a golden file
+type GoldenCase struct {
+	Input  string `json:"input"`
+	Output string `json:"output"`
+}
+
+// AssertGolden checks fn against the golden file (a JSON array of GoldenCase values): each
+// input must give its output and each output must be left as-is by fn (a round-trip). When
+// the UpdateEnv variable is set the file is rewritten with the inputs and the current outputs,
+// a missing file is created with the corpus as the inputs.
+func AssertGolden(t testing.TB, fn sanitize.SanitizeFunc, path string) bool {
+	t.Helper()
+
+	var cases []GoldenCase
+	data, err := os.ReadFile(path) //nolint:gosec // the path of the golden file is given by the test
+	switch {
+	case err == nil:
+		if err = json.Unmarshal(data, &cases); err != nil {
+			t.Errorf("golden file %s is invalid: %s", path, err)
+			return false
+		}
+	case os.IsNotExist(err) && len(os.Getenv(UpdateEnv)) > 0:
+		for _, sample := range Corpus() {
+			cases = append(cases, GoldenCase{Input: sample})
+		}
+	default:
+		t.Errorf("golden file %s can not be read (set %s=1 to create it): %s", path, UpdateEnv, err)
+		return false
+	}
+
+	if len(os.Getenv(UpdateEnv)) > 0 {
+		for i := range cases {
+			cases[i].Output = fn(cases[i].Input)
+		}
+		return writeGolden(t, path, cases)
+	}
+
+	ok := true
+	for _, c := range cases {
+		if output := fn(c.Input); output != c.Output {
+			t.Errorf("sanitizer output for %q is %q, golden file %s expects %q", c.Input, output, path, c.Output)
+			ok = false
+		} else if again := fn(output); again != output {
+			t.Errorf("sanitizer output %q for %q changes when sanitized again: %q", output, c.Input, again)
+			ok = false
+		}
+	}
+	return ok
+}
+
+// writeGolden writes the golden file (creating its directory)
+func writeGolden(t testing.TB, path string, cases []GoldenCase) bool {
+	t.Helper()
+	data, err := json.MarshalIndent(cases, "", "  ")
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(path), 0o750)
+	}
+	if err == nil {
+		err = os.WriteFile(path, append(data, '\n'), 0o600)
+	}
+	if err != nil {
+		t.Errorf("golden file %s can not be written: %s", path, err)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+package sanitizetest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/mrz1836/go-sanitize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a testing.TB that records the errors instead of failing the test
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+// Helper does nothing
+func (r *recorder) Helper() {}
+
+// Errorf records the error
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// TestCorpus tests the Corpus method
+func TestCorpus(t *testing.T) {
+	t.Parallel()
+
+	corpus := Corpus()
+	assert.Len(t, corpus, len(fragments)*len(fragments))
+	assert.Equal(t, corpus, Corpus())
+	assert.Contains(t, corpus, "<<b>b>x<")
+}
+
+// TestAssertOnlyRunes tests the AssertOnlyRunes method
+func TestAssertOnlyRunes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("passes", func(t *testing.T) {
+		assert.True(t, AssertOnlyRunes(t, sanitize.Funcs.Alpha, isASCIILetter))
+		assert.True(t, AssertOnlyRunes(t, sanitize.Funcs.Numeric, unicode.IsDigit, "x1"))
+	})
+
+	t.Run("fails", func(t *testing.T) {
+		r := &recorder{}
+		assert.False(t, AssertOnlyRunes(r, sanitize.Funcs.AlphaWithSpaces, isASCIILetter))
+		require.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], "' '")
+	})
+
+	t.Run("invalid utf-8", func(t *testing.T) {
+		r := &recorder{}
+		assert.False(t, AssertOnlyRunes(r, func(s string) string { return s }, func(rune) bool { return true }))
+		require.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], "not valid UTF-8")
+	})
+}
+
+// isASCIILetter returns true for a-z and A-Z
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// TestAssertIdempotent tests the AssertIdempotent method
+func TestAssertIdempotent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("passes", func(t *testing.T) {
+		assert.True(t, AssertIdempotent(t, sanitize.Funcs.HTML))
+		assert.True(t, AssertIdempotent(t, sanitize.Presets.FormInput))
+	})
+
+	t.Run("fails", func(t *testing.T) {
+		r := &recorder{}
+		removeOnce := func(s string) string { return strings.Replace(s, "ab", "", 1) }
+		assert.False(t, AssertIdempotent(r, removeOnce, "aabb"))
+		require.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], "not idempotent")
+	})
+}
+
+// TestAssertGolden tests the AssertGolden method (not parallel, it sets the UpdateEnv variable)
+func TestAssertGolden(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "golden", "email.json")
+
+	t.Run("missing file", func(t *testing.T) {
+		r := &recorder{}
+		assert.False(t, AssertGolden(r, sanitize.Funcs.Email, path))
+		require.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], UpdateEnv)
+	})
+
+	t.Run("created", func(t *testing.T) {
+		t.Setenv(UpdateEnv, "1")
+		assert.True(t, AssertGolden(t, sanitize.Funcs.Email, path))
+		assert.FileExists(t, path)
+	})
+
+	t.Run("passes", func(t *testing.T) {
+		assert.True(t, AssertGolden(t, sanitize.Funcs.Email, path))
+	})
+
+	t.Run("output changed", func(t *testing.T) {
+		r := &recorder{}
+		assert.False(t, AssertGolden(r, sanitize.Funcs.EmailPreserveCase, path))
+		assert.NotEmpty(t, r.errors)
+	})
+
+	t.Run("round-trip", func(t *testing.T) {
+		file := filepath.Join(dir, "round-trip.json")
+		require.NoError(t, os.WriteFile(file, []byte(`[{"input":"aabb","output":"ab"}]`), 0o600))
+		r := &recorder{}
+		removeOnce := func(s string) string { return strings.Replace(s, "ab", "", 1) }
+		assert.False(t, AssertGolden(r, removeOnce, file))
+		require.Len(t, r.errors, 1)
+		assert.Contains(t, r.errors[0], "changes when sanitized again")
+	})
+
+	t.Run("updated", func(t *testing.T) {
+		file := filepath.Join(dir, "update.json")
+		require.NoError(t, os.WriteFile(file, []byte(`[{"input":" A@B.COM ","output":"x"}]`), 0o600))
+		t.Setenv(UpdateEnv, "1")
+		assert.True(t, AssertGolden(t, sanitize.Funcs.Email, file))
+		data, err := os.ReadFile(file) //nolint:gosec // test file
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"output": "a@b.com"`)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		file := filepath.Join(dir, "invalid.json")
+		require.NoError(t, os.WriteFile(file, []byte(`{`), 0o600))
+		r := &recorder{}
+		assert.False(t, AssertGolden(r, sanitize.Funcs.Email, file))
+		require.Len(t, r.errors, 1)
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		t.Setenv(UpdateEnv, "1")
+		r := &recorder{}
+		assert.False(t, AssertGolden(r, sanitize.Funcs.Email, filepath.Join(path, "not-a-dir", "x.json")))
+		assert.NotEmpty(t, r.errors)
+	})
+}
+
+// ExampleCorpus example using Corpus()
+func ExampleCorpus() {
+	corpus := Corpus()
+	fmt.Println(len(corpus) > 1000, corpus[0] == "")
+	// Output: true true
+}
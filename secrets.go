@@ -0,0 +1,94 @@
+package sanitize
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+)
+
+// redacted replaces the secrets removed by Secrets()
+const redacted = "[REDACTED]"
+
+// secretRule is a secret format and its replacement ($1 keeps the captured prefix)
+type secretRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// secretRules are the secret formats detected by Secrets() (in order)
+var secretRules = []secretRule{
+	{ // PEM private key blocks (unterminated blocks run to the end of the input)
+		regexp.MustCompile(`(?s)-----BEGIN [A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----.*?(-----END [A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----|$)`),
+		redacted,
+	},
+	{ // JSON web tokens (header.payload.signature)
+		regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+		redacted,
+	},
+	{ // AWS access key ids
+		regexp.MustCompile(`\b(AKIA|ASIA|AGPA|AIDA|AIPA|ANPA|ANVA|AROA)[A-Z0-9]{16}\b`),
+		redacted,
+	},
+	{ // GitHub tokens
+		regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{22,255})\b`),
+		redacted,
+	},
+	{ // Slack tokens
+		regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`),
+		redacted,
+	},
+	{ // Stripe secret and restricted keys
+		regexp.MustCompile(`\b[rs]k_(live|test)_[A-Za-z0-9]{16,}\b`),
+		redacted,
+	},
+	{ // Google API keys
+		regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`),
+		redacted,
+	},
+	{ // Bearer and basic authorization credentials
+		regexp.MustCompile(`(?i)\b((bearer|basic)\s+)[A-Za-z0-9._~+/=-]{8,}`),
+		"${1}" + redacted,
+	},
+	{ // Secret assignments (password=..., "api_key": "...")
+		regexp.MustCompile(`(?i)\b((password|passwd|pwd|secret|client_secret|aws_secret_access_key|api[_-]?key|` +
+			`access[_-]?token|auth[_-]?token|refresh[_-]?token)["']?\s*[:=]\s*["']?)[^\s"',;&]+`),
+		"${1}" + redacted,
+	},
+}
+
+// Secrets masks the common secret formats found in the text with [REDACTED]: private key blocks,
+// JSON web tokens, AWS access key ids, GitHub, Slack and Stripe tokens, Google API keys, bearer
+// and basic credentials and the values of secret assignments (password=hunter2). This is
+// intended for scrubbing logs and error messages, it is not a guarantee that no secret is left.
+//
+//	View examples: secrets_test.go
+func Secrets(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	for _, rule := range secretRules {
+		if rule.pattern.MatchString(original) {
+			original = rule.pattern.ReplaceAllString(original, rule.replacement)
+		}
+	}
+	return o.after(original)
+}
+
+// HashToken sanitizes the original value with fn (if not nil) and returns the hex encoded
+// HMAC-SHA256 of the result keyed with the salt, so personal data can be normalized and
+// pseudonymized in one step (the same email in any case hashes to the same token with
+// Funcs.Email). An empty string is returned if the sanitized value is empty, so blank
+// values never share a token.
+//
+//	View examples: secrets_test.go
+func HashToken(original string, fn SanitizeFunc, salt []byte) string {
+	if fn != nil {
+		original = fn(original)
+	}
+	if len(original) == 0 {
+		return ""
+	}
+	mac := hmac.New(sha256.New, salt)
+	_, _ = mac.Write([]byte(original))
+	return hex.EncodeToString(mac.Sum(nil))
+}
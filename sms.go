@@ -0,0 +1,87 @@
+package sanitize
+
+import (
+	"strings"
+)
+
+// GSM-7 alphabet (3GPP TS 23.038) and the segment sizes for SMS messages
+const (
+	gsmBasicCharset = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+		"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+	gsmExtendedCharset   = "^{}\\[~]|€" // Requires an escape character (counts as two septets)
+	smsMultiSegmentSize  = 153          // Septets per segment when a message is split (UDH takes the rest)
+	smsSingleSegmentSize = 160          // Septets in a single message
+)
+
+// smsReplacements maps common characters outside the GSM-7 alphabet to a GSM-7 equivalent,
+// any rune not found here falls back to the ASCII transliterations
+var smsReplacements = map[rune]string{
+	'‘': "'", '’': "'", '‚': "'", '‛': "'", '“': `"`, '”': `"`, '„': `"`, '‟': `"`, '«': `"`, '»': `"`,
+	'‐': "-", '‑': "-", '‒': "-", '–': "-", '—': "-", '―': "-", '…': "...", '•': "-",
+	'\t': " ", '\u00a0': " ", '\u2002': " ", '\u2003': " ", '\u2009': " ", '\u200b': "",
+	'á': "a", 'â': "a", 'ã': "a", 'í': "i", 'î': "i", 'ï': "i", 'ó': "o", 'ô': "o", 'õ': "o", 'ú': "u",
+	'û': "u", 'ç': "Ç", 'ê': "e", 'ë': "e", 'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'È': "E", 'Ê': "E",
+}
+
+// SMS returns a string that only contains characters from the GSM-7 alphabet, along
+// with the number of SMS segments the message requires. Smart quotes, dashes and accented
+// characters are converted to their GSM-7 equivalents, anything else is removed.
+//
+//	View examples: sms_test.go
+func SMS(original string) (string, int) {
+	var b strings.Builder
+	b.Grow(len(original))
+	septets := 0
+	for _, r := range original {
+		if size := gsmSeptets(r); size > 0 {
+			b.WriteRune(r)
+			septets += size
+			continue
+		}
+
+		// Try to convert the rune to a GSM-7 equivalent
+		replacement, ok := smsReplacements[r]
+		if !ok {
+			replacement = asciiTransliterations[r]
+		}
+		for _, c := range replacement {
+			if size := gsmSeptets(c); size > 0 {
+				b.WriteRune(c)
+				septets += size
+			}
+		}
+	}
+
+	return b.String(), smsSegments(b.String(), septets)
+}
+
+// gsmSeptets returns the number of septets needed to encode the rune in GSM-7 (0 if not supported)
+func gsmSeptets(r rune) int {
+	if strings.ContainsRune(gsmBasicCharset, r) {
+		return 1
+	} else if strings.ContainsRune(gsmExtendedCharset, r) {
+		return 2
+	}
+	return 0
+}
+
+// smsSegments returns the number of segments needed to send the GSM-7 message,
+// an escaped character is never split across two segments
+func smsSegments(message string, septets int) int {
+	if septets == 0 {
+		return 0
+	} else if septets <= smsSingleSegmentSize {
+		return 1
+	}
+
+	segments, used := 1, 0
+	for _, r := range message {
+		size := gsmSeptets(r)
+		if used+size > smsMultiSegmentSize {
+			segments++
+			used = 0
+		}
+		used += size
+	}
+	return segments
+}
@@ -0,0 +1,57 @@
+package sanitize
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSMS tests the SMS sanitize method
+func TestSMS(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name             string
+		input            string
+		expected         string
+		expectedSegments int
+	}{
+		{"empty", "", "", 0},
+		{"plain text", "Your code is 1234", "Your code is 1234", 1},
+		{"gsm accents", "Café à Zürich", "Café à Zürich", 1},
+		{"smart quotes", "“Hello” it’s me – John…", `"Hello" it's me - John...`, 1},
+		{"accent conversion", "São Tomé ç", "Sao Tomé Ç", 1},
+		{"emoji removed", "Thanks ☺!", "Thanks !", 1},
+		{"extended characters", "{json} [x] €5", "{json} [x] €5", 1},
+		{"single segment limit", strings.Repeat("a", 160), strings.Repeat("a", 160), 1},
+		{"two segments", strings.Repeat("a", 161), strings.Repeat("a", 161), 2},
+		{"three segments", strings.Repeat("a", 307), strings.Repeat("a", 307), 3},
+		{"extended counts double", strings.Repeat("€", 80), strings.Repeat("€", 80), 1},
+		{"extended overflow", strings.Repeat("€", 81), strings.Repeat("€", 81), 2},
+		{"escape not split", strings.Repeat("a", 152) + "€" + strings.Repeat("a", 10),
+			strings.Repeat("a", 152) + "€" + strings.Repeat("a", 10), 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, segments := SMS(test.input)
+			assert.Equal(t, test.expected, output)
+			assert.Equal(t, test.expectedSegments, segments)
+		})
+	}
+}
+
+// BenchmarkSMS benchmarks the SMS method
+func BenchmarkSMS(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = SMS("“Hello” John, your code is 1234 – thanks…")
+	}
+}
+
+// ExampleSMS example using SMS()
+func ExampleSMS() {
+	fmt.Println(SMS("“Hello” José – your code is 1234 ☺"))
+	// Output: "Hello" José - your code is 1234  1
+}
@@ -0,0 +1,253 @@
+package sanitize
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Hashtag and mention limits
+const (
+	maxHashtagLength = 100 // Characters after the #
+	maxMentionLength = 30  // Characters after the @
+)
+
+// Hashtag returns the hashtag with a single leading # and only letters, digits and underscores
+// (#Go Lang! => #GoLang), truncated to 100 characters. An empty string is returned if nothing
+// is left or the tag is only digits (#2024 is not a hashtag).
+//
+//	View examples: social_test.go
+func Hashtag(original string) string {
+	tag := filterSocial(strings.TrimLeft(strings.TrimSpace(original), "#"), isHashtagRune, maxHashtagLength)
+	if !isValidHashtag(tag) {
+		return ""
+	}
+	return "#" + tag
+}
+
+// Mention returns the mention with a single leading @ and only ASCII letters, digits and
+// underscores (@john.doe => @johndoe), truncated to 30 characters. An empty string is
+// returned if nothing is left.
+//
+//	View examples: social_test.go
+func Mention(original string) string {
+	name := filterSocial(strings.TrimLeft(strings.TrimSpace(original), "@"), isMentionRune, maxMentionLength)
+	if len(name) == 0 {
+		return ""
+	}
+	return "@" + name
+}
+
+// ExtractHashtags returns the unique hashtags found in the text (in the order they appear),
+// a hashtag starts with a # that is not part of a word (a#b and &#39; are not hashtags)
+//
+//	View examples: social_test.go
+func ExtractHashtags(text string) []string {
+	return extractSocial(text, '#', isHashtagRune, maxHashtagLength, isValidHashtag)
+}
+
+// ExtractMentions returns the unique mentions found in the text (in the order they appear),
+// a mention starts with an @ that is not part of a word (email addresses are not mentions)
+//
+//	View examples: social_test.go
+func ExtractMentions(text string) []string {
+	return extractSocial(text, '@', isMentionRune, maxMentionLength, func(name string) bool {
+		return len(name) > 0
+	})
+}
+
+// filterSocial keeps the allowed runes of the value, up to the maximum number of runes
+func filterSocial(value string, allowed func(rune) bool, maxLength int) string {
+	var b strings.Builder
+	count := 0
+	for _, r := range value {
+		if !allowed(r) {
+			continue
+		}
+		if count == maxLength {
+			break
+		}
+		b.WriteRune(r)
+		count++
+	}
+	return b.String()
+}
+
+// extractSocial returns the unique tags that start with the prefix, tags longer than
+// the maximum length are skipped
+func extractSocial(text string, prefix byte, allowed func(rune) bool, maxLength int,
+	valid func(string) bool) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for i := 0; i < len(text); i++ {
+		if text[i] != prefix {
+			continue
+		}
+
+		// The prefix can not be part of a word (a#b, user@example.com) or an entity (&#39;)
+		if i > 0 {
+			before, _ := utf8.DecodeLastRuneInString(text[:i])
+			if allowed(before) || before == '&' || before == rune(prefix) {
+				continue
+			}
+		}
+
+		end := i + 1
+		for end < len(text) {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if !allowed(r) {
+				break
+			}
+			end += size
+		}
+		tag := text[i+1 : end]
+		i = end - 1
+		if utf8.RuneCountInString(tag) > maxLength || !valid(tag) {
+			continue
+		}
+		if key := strings.ToLower(tag); !seen[key] {
+			seen[key] = true
+			tags = append(tags, string(prefix)+tag)
+		}
+	}
+	return tags
+}
+
+// isHashtagRune returns true for the runes allowed in a hashtag
+func isHashtagRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+// isMentionRune returns true for the runes allowed in a mention
+func isMentionRune(r rune) bool {
+	return r == '_' || (r < utf8.RuneSelf && (isASCIILetter(byte(r)) || (r >= '0' && r <= '9')))
+}
+
+// isValidHashtag returns true if the tag is not empty and not only digits
+func isValidHashtag(tag string) bool {
+	return len(tag) > 0 && strings.IndexFunc(tag, func(r rune) bool { return !unicode.IsDigit(r) }) >= 0
+}
+
+// Platform is a social network supported by SocialHandle()
+type Platform string
+
+// Supported platforms
+const (
+	PlatformFacebook  Platform = "facebook"
+	PlatformGitHub    Platform = "github"
+	PlatformInstagram Platform = "instagram"
+	PlatformLinkedIn  Platform = "linkedin"
+	PlatformTikTok    Platform = "tiktok"
+	PlatformTwitter   Platform = "twitter" // Twitter and X
+	PlatformYouTube   Platform = "youtube"
+)
+
+// socialPlatform are the rules of a platform's handles and profile urls
+type socialPlatform struct {
+	hosts    []string       // Profile url hosts (without www.)
+	pattern  *regexp.Regexp // Valid (case folded) handles
+	prefix   string         // Path segment before the handle (in for linkedin.com/in/handle)
+	reserved []string       // Paths that are not profiles
+}
+
+// socialPlatforms are the supported platforms
+var socialPlatforms = map[Platform]socialPlatform{
+	PlatformFacebook: {
+		hosts:    []string{"facebook.com", "fb.com", "m.facebook.com"},
+		pattern:  regexp.MustCompile(`^[a-z0-9.]{5,50}$`),
+		reserved: []string{"events", "groups", "help", "login", "pages", "profile.php", "sharer", "watch"},
+	},
+	PlatformGitHub: {
+		hosts:    []string{"github.com"},
+		pattern:  regexp.MustCompile(`^[a-z0-9]([a-z0-9]|-[a-z0-9]){0,38}$`),
+		reserved: []string{"about", "explore", "login", "marketplace", "orgs", "settings", "sponsors", "topics"},
+	},
+	PlatformInstagram: {
+		hosts:    []string{"instagram.com", "instagr.am"},
+		pattern:  regexp.MustCompile(`^[a-z0-9_]([a-z0-9_]|\.[a-z0-9_]){0,29}$`),
+		reserved: []string{"accounts", "explore", "p", "reel", "reels", "stories"},
+	},
+	PlatformLinkedIn: {
+		hosts:   []string{"linkedin.com"},
+		pattern: regexp.MustCompile(`^[a-z0-9-]{3,100}$`),
+		prefix:  "in",
+	},
+	PlatformTikTok: {
+		hosts:   []string{"tiktok.com"},
+		pattern: regexp.MustCompile(`^[a-z0-9_.]{2,24}$`),
+	},
+	PlatformTwitter: {
+		hosts:    []string{"twitter.com", "x.com", "mobile.twitter.com"},
+		pattern:  regexp.MustCompile(`^[a-z0-9_]{1,15}$`),
+		reserved: []string{"explore", "hashtag", "home", "i", "intent", "messages", "search", "settings", "share"},
+	},
+	PlatformYouTube: {
+		hosts:    []string{"youtube.com", "m.youtube.com"},
+		pattern:  regexp.MustCompile(`^[a-z0-9_.-]{3,30}$`),
+		reserved: []string{"channel", "results", "watch"},
+	},
+}
+
+// SocialHandle returns the canonical (lowercase, without an @) handle of a bare handle or
+// profile url (https://twitter.com/Gopher?s=20 => gopher) or an error if the handle is not
+// valid for the platform or the url is not a profile url of the platform.
+//
+//	View examples: social_test.go
+func SocialHandle(original string, platform Platform) (string, error) {
+	rules, ok := socialPlatforms[platform]
+	if !ok {
+		return "", fmt.Errorf("%w: unsupported platform %q", ErrInvalidHandle, platform)
+	}
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %q %s", ErrInvalidHandle, original, reason)
+	}
+
+	handle := strings.TrimSpace(original)
+	if strings.Contains(handle, "/") {
+		var err error
+		if handle, err = socialProfileHandle(handle, rules); err != nil {
+			return "", invalid(err.Error())
+		}
+	}
+
+	handle = strings.ToLower(strings.TrimPrefix(handle, "@"))
+	if !rules.pattern.MatchString(handle) {
+		return "", invalid("is not a valid " + string(platform) + " handle")
+	}
+	if containsFold(rules.reserved, handle) {
+		return "", invalid("is not a profile")
+	}
+	return handle, nil
+}
+
+// socialProfileHandle returns the handle from a profile url
+func socialProfileHandle(profile string, rules socialPlatform) (string, error) {
+	if !strings.Contains(profile, "://") {
+		profile = "https://" + profile
+	}
+	u, err := url.Parse(profile)
+	if err != nil {
+		return "", errors.New("is not a valid url")
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if !containsFold(rules.hosts, host) {
+		return "", errors.New("is not a profile url of the platform")
+	}
+
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+	if len(rules.prefix) > 0 {
+		if len(segments) == 0 || !strings.EqualFold(segments[0], rules.prefix) {
+			return "", errors.New("is not a profile url")
+		}
+		segments = segments[1:]
+	}
+	if len(segments) == 0 {
+		return "", errors.New("has no handle")
+	}
+	return segments[0], nil
+}
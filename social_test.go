@@ -0,0 +1,254 @@
+package sanitize
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestHashtag tests the Hashtag method
+func TestHashtag(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"hash only", "#", ""},
+		{"no hash", "golang", "#golang"},
+		{"hash", "#golang", "#golang"},
+		{"multiple hashes", "##golang", "#golang"},
+		{"spaces and symbols", " #Go Lang! ", "#GoLang"},
+		{"underscore and digits", "#go_1_22", "#go_1_22"},
+		{"unicode", "#café", "#café"},
+		{"digits only", "#2024", ""},
+		{"too long", strings.Repeat("a", 150), "#" + strings.Repeat("a", maxHashtagLength)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Hashtag(test.input))
+		})
+	}
+}
+
+// BenchmarkHashtag benchmarks the Hashtag method
+func BenchmarkHashtag(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Hashtag("#Go Lang!")
+	}
+}
+
+// ExampleHashtag example using Hashtag()
+func ExampleHashtag() {
+	fmt.Println(Hashtag("#Go Lang!"))
+	// Output: #GoLang
+}
+
+// TestMention tests the Mention method
+func TestMention(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"at only", "@", ""},
+		{"no at", "gopher", "@gopher"},
+		{"at", "@gopher", "@gopher"},
+		{"multiple at", "@@gopher", "@gopher"},
+		{"dots", "@john.doe", "@johndoe"},
+		{"unicode removed", "@josé", "@jos"},
+		{"underscore and digits", "@go_lang_2", "@go_lang_2"},
+		{"too long", "@" + strings.Repeat("a", 40), "@" + strings.Repeat("a", maxMentionLength)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Mention(test.input))
+		})
+	}
+}
+
+// BenchmarkMention benchmarks the Mention method
+func BenchmarkMention(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Mention("@john.doe")
+	}
+}
+
+// ExampleMention example using Mention()
+func ExampleMention() {
+	fmt.Println(Mention("@john.doe"))
+	// Output: @johndoe
+}
+
+// TestExtractHashtags tests the ExtractHashtags method
+func TestExtractHashtags(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"empty", "", nil},
+		{"none", "no tags here", nil},
+		{"single", "I love #golang!", []string{"#golang"}},
+		{"multiple", "#go and #rust, #zig.", []string{"#go", "#rust", "#zig"}},
+		{"duplicates", "#Go #go #GO", []string{"#Go"}},
+		{"in a word", "a#b c#d", nil},
+		{"entity", "it&#39;s", nil},
+		{"digits only", "#1 fan of #go2", []string{"#go2"}},
+		{"unicode", "#café au lait", []string{"#café"}},
+		{"double hash", "##go", nil},
+		{"too long", "#" + strings.Repeat("a", 101) + " #ok", []string{"#ok"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ExtractHashtags(test.input))
+		})
+	}
+}
+
+// BenchmarkExtractHashtags benchmarks the ExtractHashtags method
+func BenchmarkExtractHashtags(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = ExtractHashtags("I love #golang and #gophers!")
+	}
+}
+
+// ExampleExtractHashtags example using ExtractHashtags()
+func ExampleExtractHashtags() {
+	fmt.Println(ExtractHashtags("I love #golang and #gophers!"))
+	// Output: [#golang #gophers]
+}
+
+// TestExtractMentions tests the ExtractMentions method
+func TestExtractMentions(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"empty", "", nil},
+		{"none", "no mentions here", nil},
+		{"single", "thanks @gopher!", []string{"@gopher"}},
+		{"multiple", "@alice, @bob and @carol.", []string{"@alice", "@bob", "@carol"}},
+		{"duplicates", "@Bob @bob", []string{"@Bob"}},
+		{"email", "mail me at user@example.com", nil},
+		{"at only", "meet @ noon", nil},
+		{"too long", "@" + strings.Repeat("a", 31) + " @ok", []string{"@ok"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ExtractMentions(test.input))
+		})
+	}
+}
+
+// BenchmarkExtractMentions benchmarks the ExtractMentions method
+func BenchmarkExtractMentions(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = ExtractMentions("thanks @alice and @bob!")
+	}
+}
+
+// ExampleExtractMentions example using ExtractMentions()
+func ExampleExtractMentions() {
+	fmt.Println(ExtractMentions("thanks @alice and @bob!"))
+	// Output: [@alice @bob]
+}
+
+// TestSocialHandle tests the SocialHandle method
+func TestSocialHandle(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			platform Platform
+			expected string
+		}{
+			{"twitter handle", "@Gopher", PlatformTwitter, "gopher"},
+			{"twitter bare handle", " golang_dev ", PlatformTwitter, "golang_dev"},
+			{"twitter url", "https://twitter.com/Gopher?s=20", PlatformTwitter, "gopher"},
+			{"x url", "https://x.com/gopher/status/123", PlatformTwitter, "gopher"},
+			{"twitter url without scheme", "www.twitter.com/gopher", PlatformTwitter, "gopher"},
+			{"instagram dots", "@john.doe", PlatformInstagram, "john.doe"},
+			{"instagram url", "https://www.instagram.com/John.Doe/", PlatformInstagram, "john.doe"},
+			{"tiktok url", "https://www.tiktok.com/@Gopher?lang=en", PlatformTikTok, "gopher"},
+			{"github url", "https://github.com/mrz1836/go-sanitize", PlatformGitHub, "mrz1836"},
+			{"github hyphen", "go-gopher", PlatformGitHub, "go-gopher"},
+			{"linkedin url", "https://www.linkedin.com/in/John-Doe-123/", PlatformLinkedIn, "john-doe-123"},
+			{"facebook url", "https://fb.com/john.doe.5", PlatformFacebook, "john.doe.5"},
+			{"youtube url", "https://www.youtube.com/@GoLang", PlatformYouTube, "golang"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := SocialHandle(test.input, test.platform)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			platform Platform
+		}{
+			{"empty", "", PlatformTwitter},
+			{"unsupported platform", "gopher", Platform("myspace")},
+			{"twitter too long", "@abcdefghijklmnop", PlatformTwitter},
+			{"twitter invalid characters", "@go.pher", PlatformTwitter},
+			{"twitter reserved", "https://twitter.com/home", PlatformTwitter},
+			{"wrong platform url", "https://instagram.com/gopher", PlatformTwitter},
+			{"url without handle", "https://twitter.com/", PlatformTwitter},
+			{"instagram double dot", "john..doe", PlatformInstagram},
+			{"instagram trailing dot", "johndoe.", PlatformInstagram},
+			{"github leading hyphen", "-gopher", PlatformGitHub},
+			{"github double hyphen", "go--pher", PlatformGitHub},
+			{"linkedin company url", "https://linkedin.com/company/acme", PlatformLinkedIn},
+			{"facebook profile id", "https://facebook.com/profile.php?id=123", PlatformFacebook},
+			{"lookalike host", "https://twitter.com.evil.com/gopher", PlatformTwitter},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := SocialHandle(test.input, test.platform)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidHandle)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkSocialHandle benchmarks the SocialHandle method
+func BenchmarkSocialHandle(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = SocialHandle("https://twitter.com/Gopher?s=20", PlatformTwitter)
+	}
+}
+
+// ExampleSocialHandle example using SocialHandle()
+func ExampleSocialHandle() {
+	handle, err := SocialHandle("https://twitter.com/Gopher?s=20", PlatformTwitter)
+	fmt.Println(handle, err)
+	// Output: gopher <nil>
+}
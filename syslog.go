@@ -0,0 +1,87 @@
+package sanitize
+
+import (
+	"strings"
+	"unicode"
+)
+
+// cefValueEscaper escapes the characters with a meaning in CEF extension values
+var cefValueEscaper = strings.NewReplacer(`\`, `\\`, "=", `\=`, "\r\n", `\n`, "\n", `\n`, "\r", `\r`)
+
+// cefHeaderEscaper escapes the characters with a meaning in CEF header fields
+var cefHeaderEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`)
+
+// SyslogMsg returns a value that is safe to use as (part of) the message of a syslog event
+// (RFC 5424 and RFC 3164). New lines, including the Unicode line breaks (NEL, U+2028 and
+// U+2029), are replaced with a space so that a value can not start a forged event in new
+// line delimited transports, terminal escape sequences (see ANSI), invalid UTF-8 and the
+// other control characters except tabs are removed.
+//
+//	View examples: syslog_test.go
+func SyslogMsg(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(logLine(original, "\t"))
+}
+
+// CEFField escapes a value for an extension field of a CEF event (key=value): backslashes
+// and equal signs are escaped (\\ and \=) and new lines are written as \n and \r, so the
+// value can not add fields or events. Terminal escape sequences, invalid UTF-8 and the other
+// control characters are removed, tabs are replaced with a space. Use CEFHeader for the
+// pipe delimited header fields (vendor, product, name).
+//
+//	View examples: syslog_test.go
+func CEFField(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(cefValueEscaper.Replace(logLine(original, "\r\n")))
+}
+
+// CEFHeader escapes a value for a header field of a CEF event (CEF:0|vendor|product|...):
+// backslashes and pipes are escaped (\\ and \|). New lines and tabs are replaced with a
+// space, terminal escape sequences, invalid UTF-8 and the other control characters are
+// removed.
+//
+//	View examples: syslog_test.go
+func CEFHeader(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(cefHeaderEscaper.Replace(logLine(original, "")))
+}
+
+// LEEFField returns a value for an attribute of a LEEF event (key=value pairs separated
+// by tabs, or by the delimiter of a LEEF 2.0 header, use 0 for the default tab). LEEF has
+// no escapes, so the tabs, new lines and the delimiter are replaced with a space, terminal
+// escape sequences, invalid UTF-8 and the other control characters are removed.
+//
+//	View examples: syslog_test.go
+func LEEFField(original string, delimiter rune, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	value := logLine(original, "")
+	if delimiter > ' ' {
+		value = strings.ReplaceAll(value, string(delimiter), " ")
+	}
+	return o.after(value)
+}
+
+// logLine removes the terminal escapes, invalid UTF-8 and control characters from the value,
+// new lines and tabs are replaced with a space unless they are in keep. The Unicode line
+// breaks (NEL, line and paragraph separators) are always replaced with a space.
+func logLine(value, keep string) string {
+	value = strings.ToValidUTF8(stripANSI(value, false), "")
+	if !strings.Contains(keep, "\n") {
+		value = strings.ReplaceAll(value, "\r\n", " ")
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case strings.ContainsRune(keep, r):
+			return r
+		case r == '\n' || r == '\r' || r == '\t' || r == '\u0085' || unicode.In(r, unicode.Zl, unicode.Zp):
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, value)
+}
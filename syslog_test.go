@@ -0,0 +1,164 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSyslogMsg tests the SyslogMsg method
+func TestSyslogMsg(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"plain", "user bob logged in", "user bob logged in"},
+		{"forged event", "bad login\n<34>1 2024-01-01T00:00:00Z host app - - - admin logged in",
+			"bad login <34>1 2024-01-01T00:00:00Z host app - - - admin logged in"},
+		{"crlf", "a\r\nb\rc", "a b c"},
+		{"tabs", "a\tb", "a\tb"},
+		{"unicode line breaks", "a\u2028<13>forged\u2029b\u0085c", "a <13>forged b c"},
+		{"controls", "a\x00b\x07c\u0080d", "abcd"},
+		{"terminal escapes", "\x1b[31merror\x1b[0m \x1b]0;title\a", "error "},
+		{"invalid utf-8", "caf\xe9", "caf"},
+		{"structured data", "[exampleSDID@32473 iut=\"3\"]", "[exampleSDID@32473 iut=\"3\"]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, SyslogMsg(test.input))
+		})
+	}
+}
+
+// BenchmarkSyslogMsg benchmarks the SyslogMsg method
+func BenchmarkSyslogMsg(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = SyslogMsg("login failed for bob\n<34>1 forged event")
+	}
+}
+
+// ExampleSyslogMsg example using SyslogMsg()
+func ExampleSyslogMsg() {
+	fmt.Println(SyslogMsg("login failed for bob\n<34>1 forged event"))
+	// Output: login failed for bob <34>1 forged event
+}
+
+// TestCEFField tests the CEFField method
+func TestCEFField(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"plain", "bob", "bob"},
+		{"equals", "a=b suser=admin", `a\=b suser\=admin`},
+		{"backslash", `C:\temp\`, `C:\\temp\\`},
+		{"new lines", "a\r\nb\nc\rd", `a\nb\nc\rd`},
+		{"pipe", "a|b", "a|b"},
+		{"tab", "a\tb", "a b"},
+		{"controls", "a\x00\x1b[31mb", "ab"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, CEFField(test.input))
+		})
+	}
+}
+
+// BenchmarkCEFField benchmarks the CEFField method
+func BenchmarkCEFField(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = CEFField("bob suser=admin\nCEF:0|forged")
+	}
+}
+
+// ExampleCEFField example using CEFField()
+func ExampleCEFField() {
+	fmt.Println("suser=" + CEFField("bob act=allow"))
+	// Output: suser=bob act\=allow
+}
+
+// TestCEFHeader tests the CEFHeader method
+func TestCEFHeader(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"plain", "Security Vendor", "Security Vendor"},
+		{"pipe", "a|b", `a\|b`},
+		{"backslash", `a\b`, `a\\b`},
+		{"equals", "a=b", "a=b"},
+		{"new lines", "a\r\nb\tc", "a b c"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, CEFHeader(test.input))
+		})
+	}
+}
+
+// BenchmarkCEFHeader benchmarks the CEFHeader method
+func BenchmarkCEFHeader(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = CEFHeader("Login|Failed\n")
+	}
+}
+
+// ExampleCEFHeader example using CEFHeader()
+func ExampleCEFHeader() {
+	fmt.Println("CEF:0|Acme|" + CEFHeader("Web|Portal") + "|1.0")
+	// Output: CEF:0|Acme|Web\|Portal|1.0
+}
+
+// TestLEEFField tests the LEEFField method
+func TestLEEFField(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name      string
+		input     string
+		delimiter rune
+		expected  string
+	}{
+		{"empty", "", 0, ""},
+		{"plain", "bob", 0, "bob"},
+		{"tab", "bob\tusrName=admin", 0, "bob usrName=admin"},
+		{"new lines", "a\r\nb\nc", 0, "a b c"},
+		{"custom delimiter", "a^b\tc", '^', "a b c"},
+		{"controls", "a\x00\x1b]0;x\ab", 0, "ab"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, LEEFField(test.input, test.delimiter))
+		})
+	}
+}
+
+// BenchmarkLEEFField benchmarks the LEEFField method
+func BenchmarkLEEFField(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = LEEFField("bob\tusrName=admin", 0)
+	}
+}
+
+// ExampleLEEFField example using LEEFField()
+func ExampleLEEFField() {
+	fmt.Printf("%q\n", "usrName="+LEEFField("bob\tsev=1", 0))
+	// Output: "usrName=bob sev=1"
+}
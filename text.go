@@ -0,0 +1,334 @@
+package sanitize
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// defaultTabWidth is the number of spaces per tab used by Indentation()
+const defaultTabWidth = 4
+
+// smartPunctuation converts the typographic quotes, dashes and ellipsis to plain punctuation
+var smartPunctuation = strings.NewReplacer(
+	"\u2018", "'", "\u2019", "'", "\u201A", "'", "\u201B", "'",
+	"\u201C", `"`, "\u201D", `"`, "\u201E", `"`, "\u201F", `"`,
+	"\u2010", "-", "\u2011", "-", "\u2012", "-", "\u2013", "-", "\u2014", "-", "\u2015", "-",
+	"\u2026", "...",
+)
+
+// Indentation normalizes the indentation of pasted text (code snippets, YAML, etc.). The tabs
+// in the leading white space of each line are expanded to spaces (4 per tab stop, see
+// WithTabWidth), trailing white space is removed from every line (line endings become \n) and
+// the white space common to the start of all the non-blank lines is removed. Use WithIndentTabs
+// to indent with tabs instead of spaces.
+//
+//	View examples: text_test.go
+func Indentation(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+
+	tabWidth, useTabs := defaultTabWidth, false
+	if o != nil {
+		if o.tabWidth > 0 {
+			tabWidth = o.tabWidth
+		}
+		useTabs = o.indentTabs
+	}
+
+	// Expand the indentation of each line and find the common indentation
+	lines := strings.Split(original, "\n")
+	indents := make([]int, len(lines))
+	common := -1
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t\r\v\f")
+		width, start := 0, 0
+		for ; start < len(line) && (line[start] == ' ' || line[start] == '\t'); start++ {
+			if line[start] == '\t' {
+				width += tabWidth - width%tabWidth
+			} else {
+				width++
+			}
+		}
+		lines[i], indents[i] = line[start:], width
+		if len(lines[i]) > 0 && (common < 0 || width < common) {
+			common = width
+		}
+	}
+
+	// Write the lines with their indentation less the common indentation
+	var b strings.Builder
+	b.Grow(len(original))
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		if len(line) == 0 {
+			continue
+		}
+		indent := indents[i] - common
+		if useTabs {
+			b.WriteString(strings.Repeat("\t", indent/tabWidth))
+			indent %= tabWidth
+		}
+		b.WriteString(strings.Repeat(" ", indent))
+		b.WriteString(line)
+	}
+	return o.after(b.String())
+}
+
+// TruncateWords truncates the text to the maximum number of words and appends the ellipsis
+// if anything was removed. Words are separated by white space, the white space between the
+// kept words is preserved and trailing punctuation (, ; : -) before the ellipsis is removed.
+// Use WithMaxLength to also limit the length of the result (in characters, including the
+// ellipsis), a maxWords of zero or less only applies that limit. Text that is not truncated
+// is returned as-is.
+//
+//	View examples: text_test.go
+func TruncateWords(original string, maxWords int, ellipsis string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	maxLength := 0
+	if o != nil {
+		maxLength = o.maxLength
+	}
+
+	text := strings.TrimSpace(original)
+	ends := wordEnds(text)
+	n := len(ends)
+	if maxWords > 0 && n > maxWords {
+		n = maxWords
+	}
+
+	// Drop words until the text fits the character budget
+	if maxLength > 0 && (n < len(ends) || utf8.RuneCountInString(text) > maxLength) {
+		budget := maxLength - utf8.RuneCountInString(ellipsis)
+		for n > 0 && utf8.RuneCountInString(text[:ends[n-1]]) > budget {
+			n--
+		}
+		if n == 0 {
+			// The first word does not fit, so it is cut
+			return o.after(strings.TrimRightFunc(truncateRunes(text, budget), unicode.IsSpace) + ellipsis)
+		}
+	}
+
+	if n == len(ends) {
+		return o.after(original)
+	}
+	kept := strings.TrimRightFunc(text[:ends[n-1]], func(r rune) bool {
+		return r == ',' || r == ';' || r == ':' || r == '-'
+	})
+	return o.after(kept + ellipsis)
+}
+
+// TruncateUTF16 truncates the string to at most maxUnits UTF-16 code units, the length that
+// JavaScript, SQL Server nvarchar columns and push notification services count. Characters
+// outside of the Basic Multilingual Plane (most emoji) are two units and are never split into
+// half of a surrogate pair. A maxUnits of zero or less returns an empty string.
+//
+//	View examples: text_test.go
+func TruncateUTF16(original string, maxUnits int) string {
+	units := 0
+	for i, r := range original {
+		size := 1
+		if r > 0xFFFF {
+			size = 2
+		}
+		if units+size > maxUnits {
+			return original[:i]
+		}
+		units += size
+	}
+	return original
+}
+
+// Between returns the text between the first start string and the first end string that
+// follows it ("[id: 42]" with "[" and "]" => "id: 42"), for pulling a value out of a noisy
+// wrapper before it is sanitized. An empty start extracts from the beginning and an empty
+// end to the end of the text. An empty string is returned if start or end is not found.
+//
+//	View examples: text_test.go
+func Between(original, start, end string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+
+	i := strings.Index(original, start)
+	if i < 0 {
+		return ""
+	}
+	value := original[i+len(start):]
+	if end == "" {
+		return o.after(value)
+	}
+	j := strings.Index(value, end)
+	if j < 0 {
+		return ""
+	}
+	return o.after(value[:j])
+}
+
+// Before returns the text before the first separator ("42 USD" with " " => "42"). An empty
+// string is returned if the separator is not found.
+//
+//	View examples: text_test.go
+func Before(original, separator string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	i := strings.Index(original, separator)
+	if i < 0 {
+		return ""
+	}
+	return o.after(original[:i])
+}
+
+// After returns the text after the first separator, for values that follow a label
+// ("Price: $12" with "Price:" => " $12"). An empty string is returned if the separator
+// is not found.
+//
+//	View examples: text_test.go
+func After(original, separator string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	i := strings.Index(original, separator)
+	if i < 0 {
+		return ""
+	}
+	return o.after(original[i+len(separator):])
+}
+
+// wordEnds returns the byte offset of the end of each (white space separated) word
+func wordEnds(text string) []int {
+	var ends []int
+	inWord := false
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			if inWord {
+				ends = append(ends, i)
+			}
+			inWord = false
+			continue
+		}
+		inWord = true
+	}
+	if inWord {
+		ends = append(ends, len(text))
+	}
+	return ends
+}
+
+// truncateRunes truncates the value to at most n runes
+func truncateRunes(value string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	for i := range value {
+		if n == 0 {
+			return value[:i]
+		}
+		n--
+	}
+	return value
+}
+
+// LimitRepeats limits every run of the same character to maxRun characters ("!!!!!!" => "!!!"
+// and "looooool" => "loool" with a maxRun of 3), which defuses the stretched words and
+// punctuation used in spam. The text is returned as-is if maxRun is less than one.
+//
+//	View examples: text_test.go
+func LimitRepeats(original string, maxRun int, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	if maxRun < 1 {
+		return o.after(original)
+	}
+
+	var b *strings.Builder
+	var prev rune
+	run := 0
+	for i, r := range original {
+		if r == prev {
+			run++
+		} else {
+			prev, run = r, 1
+		}
+		if run <= maxRun {
+			if b != nil {
+				b.WriteRune(r)
+			}
+			continue
+		}
+
+		// Start writing on the first character that is removed
+		if b == nil {
+			b = new(strings.Builder)
+			b.Grow(len(original))
+			b.WriteString(original[:i])
+		}
+	}
+	if b == nil {
+		return o.after(original)
+	}
+	return o.after(b.String())
+}
+
+// CombiningMarks limits the number of combining marks after each base character to maxPerBase
+// and removes the rest, this defuses "zalgo" text (stacked marks) while keeping the accents of
+// normal text (precomposed characters such as é have no combining marks, a decomposed e + U+0301
+// has one). A maxPerBase of zero removes all the combining marks.
+//
+//	View examples: text_test.go
+func CombiningMarks(original string, maxPerBase int, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	if maxPerBase < 0 {
+		maxPerBase = 0
+	}
+
+	var b *strings.Builder
+	marks := 0
+	for i, r := range original {
+		if !unicode.In(r, unicode.Mn, unicode.Me) {
+			marks = 0
+		} else if marks++; marks > maxPerBase {
+			// Start writing on the first mark that is removed
+			if b == nil {
+				b = new(strings.Builder)
+				b.Grow(len(original))
+				b.WriteString(original[:i])
+			}
+			continue
+		}
+		if b != nil {
+			b.WriteRune(r)
+		}
+	}
+	if b == nil {
+		return o.after(original)
+	}
+	return o.after(b.String())
+}
+
+// Title returns a clean single line title or heading for CMS content. Html tags are removed,
+// control and invisible formatting characters (zero width spaces, bidi controls, etc.) are
+// removed, smart quotes, dashes and ellipsis are converted to plain punctuation and all white
+// space is collapsed into single spaces. Use WithTitleCase to also apply title-case.
+//
+//	View examples: text_test.go
+func Title(original string, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+
+	// The invisible characters are removed before the tags, so they can not hide a tag (<\u200bimg>)
+	title := stripTags(strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsSpace(r):
+			return ' '
+		case unicode.IsControl(r), unicode.Is(unicode.Cf, r):
+			return -1
+		}
+		return r
+	}, original), false, nil)
+	title = smartPunctuation.Replace(title)
+
+	return o.after(strings.Join(strings.Fields(title), " "))
+}
@@ -0,0 +1,416 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIndentation tests the Indentation method
+func TestIndentation(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		opts     []Option
+		expected string
+	}{
+		{"empty", "", nil, ""},
+		{"no indentation", "a\nb", nil, "a\nb"},
+		{"dedent", "    a\n      b\n    c", nil, "a\n  b\nc"},
+		{"tabs to spaces", "\tif x {\n\t\treturn\n\t}", nil, "if x {\n    return\n}"},
+		{"tab stops", "  \ta\n\tb", nil, "a\nb"},
+		{"tab width", "a:\n\tb: 1", []Option{WithTabWidth(2)}, "a:\n  b: 1"},
+		{"trailing white space", "a  \t\nb \r\nc", nil, "a\nb\nc"},
+		{"blank lines", "    a\n\n   \n    b", nil, "a\n\n\nb"},
+		{"spaces to tabs", "    a\n        b\n          c", []Option{WithIndentTabs()}, "a\n\tb\n\t  c"},
+		{"tabs with width", "  a\n    b", []Option{WithIndentTabs(), WithTabWidth(2)}, "a\n\tb"},
+		{"inner tabs kept", "\ta\tb", nil, "a\tb"},
+		{"only blank lines", " \n\t\n", nil, "\n\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Indentation(test.input, test.opts...))
+		})
+	}
+}
+
+// BenchmarkIndentation benchmarks the Indentation method
+func BenchmarkIndentation(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Indentation("\tfunc main() {\n\t\tfmt.Println(\"hi\")  \n\t}\n")
+	}
+}
+
+// ExampleIndentation example using Indentation()
+func ExampleIndentation() {
+	fmt.Println(Indentation("\t\tkey:\n\t\t\tvalue: 1  ", WithTabWidth(2)))
+	// Output:
+	// key:
+	//   value: 1
+}
+
+// TestTruncateWords tests the TruncateWords method
+func TestTruncateWords(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		maxWords int
+		ellipsis string
+		opts     []Option
+		expected string
+	}{
+		{"empty", "", 3, "…", nil, ""},
+		{"fits", "one two three", 3, "…", nil, "one two three"},
+		{"fits unchanged", " one  two ", 3, "…", nil, " one  two "},
+		{"truncated", "one two three four", 2, "…", nil, "one two…"},
+		{"inner spaces kept", "one  two\tthree", 2, "...", nil, "one  two..."},
+		{"trailing punctuation", "Hello, world - again", 1, "…", nil, "Hello…"},
+		{"no ellipsis", "one two three", 1, "", nil, "one"},
+		{"no word limit", "one two three", 0, "…", nil, "one two three"},
+		{"max length", "one two three", 0, "…", []Option{WithMaxLength(9)}, "one two…"},
+		{"max length fits", "one two", 0, "…", []Option{WithMaxLength(7)}, "one two"},
+		{"max length and words", "one two three", 2, "…", []Option{WithMaxLength(6)}, "one…"},
+		{"max length cuts first word", "extraordinary", 0, "…", []Option{WithMaxLength(6)}, "extra…"},
+		{"multibyte", "ça va très bien", 3, "…", nil, "ça va très…"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, TruncateWords(test.input, test.maxWords, test.ellipsis, test.opts...))
+		})
+	}
+}
+
+// BenchmarkTruncateWords benchmarks the TruncateWords method
+func BenchmarkTruncateWords(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = TruncateWords("The quick brown fox jumps over the lazy dog", 5, "…")
+	}
+}
+
+// ExampleTruncateWords example using TruncateWords()
+func ExampleTruncateWords() {
+	fmt.Println(TruncateWords("The quick brown fox jumps over the lazy dog", 4, "…"))
+	// Output: The quick brown fox…
+}
+
+// TestTruncateUTF16 tests the TruncateUTF16 method
+func TestTruncateUTF16(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		maxUnits int
+		expected string
+	}{
+		{"empty", "", 5, ""},
+		{"fits", "hello", 5, "hello"},
+		{"truncated", "hello world", 5, "hello"},
+		{"zero", "hello", 0, ""},
+		{"negative", "hello", -1, ""},
+		{"multibyte bmp", "très bien", 4, "très"},
+		{"emoji fits", "ok\U0001F600", 4, "ok\U0001F600"},
+		{"surrogate pair not split", "ok\U0001F600", 3, "ok"},
+		{"after emoji", "\U0001F600\U0001F600x", 5, "\U0001F600\U0001F600x"},
+		{"cjk", "日本語のテキスト", 3, "日本語"},
+		{"combining mark", "e\u0301e\u0301", 3, "e\u0301e"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := TruncateUTF16(test.input, test.maxUnits)
+			assert.Equal(t, test.expected, output)
+			assert.LessOrEqual(t, len(utf16.Encode([]rune(output))), maxInt(test.maxUnits, 0))
+		})
+	}
+}
+
+// maxInt returns the larger of the two integers
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// BenchmarkTruncateUTF16 benchmarks the TruncateUTF16 method
+func BenchmarkTruncateUTF16(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = TruncateUTF16("Hello \U0001F600 world, this is a notification", 20)
+	}
+}
+
+// ExampleTruncateUTF16 example using TruncateUTF16()
+func ExampleTruncateUTF16() {
+	fmt.Println(TruncateUTF16("Hi\U0001F600!", 3))
+	// Output: Hi
+}
+
+// TestBetween tests the Between method
+func TestBetween(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		start    string
+		end      string
+		opts     []Option
+		expected string
+	}{
+		{"empty", "", "[", "]", nil, ""},
+		{"brackets", "user [id: 42] active", "[", "]", nil, "id: 42"},
+		{"first match", "(a) (b)", "(", ")", nil, "a"},
+		{"end after start", "] x [y]", "[", "]", nil, "y"},
+		{"multi-character", "<value>42</value>", "<value>", "</value>", nil, "42"},
+		{"empty start", "42 USD", "", " ", nil, "42"},
+		{"empty end", "Price: 12", ":", "", nil, " 12"},
+		{"start not found", "no brackets", "[", "]", nil, ""},
+		{"end not found", "[unclosed", "[", "]", nil, ""},
+		{"trim", "[ 42 ]", "[", "]", []Option{WithTrim()}, "42"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Between(test.input, test.start, test.end, test.opts...))
+		})
+	}
+}
+
+// BenchmarkBetween benchmarks the Between method
+func BenchmarkBetween(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Between("Order #1234 [status: shipped] received", "[", "]")
+	}
+}
+
+// ExampleBetween example using Between()
+func ExampleBetween() {
+	fmt.Println(Numeric(Between("Order [id: 1234] shipped", "[", "]")))
+	// Output: 1234
+}
+
+// TestBefore tests the Before method
+func TestBefore(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name      string
+		input     string
+		separator string
+		opts      []Option
+		expected  string
+	}{
+		{"empty", "", " ", nil, ""},
+		{"first separator", "42 USD total", " ", nil, "42"},
+		{"multi-character", "user@example.com", "@", nil, "user"},
+		{"not found", "42", " ", nil, ""},
+		{"empty separator", "42", "", nil, ""},
+		{"trim", " 42 | USD", "|", []Option{WithTrim()}, "42"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Before(test.input, test.separator, test.opts...))
+		})
+	}
+}
+
+// BenchmarkBefore benchmarks the Before method
+func BenchmarkBefore(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Before("42.50 USD", " ")
+	}
+}
+
+// ExampleBefore example using Before()
+func ExampleBefore() {
+	fmt.Println(Before("42.50 USD", " "))
+	// Output: 42.50
+}
+
+// TestAfter tests the After method
+func TestAfter(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name      string
+		input     string
+		separator string
+		opts      []Option
+		expected  string
+	}{
+		{"empty", "", ":", nil, ""},
+		{"label", "Price: $12", "Price:", nil, " $12"},
+		{"first separator", "a=b=c", "=", nil, "b=c"},
+		{"not found", "Price 12", ":", nil, ""},
+		{"empty separator", "42", "", nil, "42"},
+		{"trim", "Name:  Bob ", ":", []Option{WithTrim()}, "Bob"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, After(test.input, test.separator, test.opts...))
+		})
+	}
+}
+
+// BenchmarkAfter benchmarks the After method
+func BenchmarkAfter(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = After("Price: $12.50", "Price:")
+	}
+}
+
+// ExampleAfter example using After()
+func ExampleAfter() {
+	fmt.Println(Decimal(After("Price: $12.50", "Price:")))
+	// Output: 12.50
+}
+
+// TestLimitRepeats tests the LimitRepeats method
+func TestLimitRepeats(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		maxRun   int
+		expected string
+	}{
+		{"empty", "", 3, ""},
+		{"no repeats", "hello", 3, "hello"},
+		{"punctuation", "wow!!!!!!!!", 3, "wow!!!"},
+		{"stretched word", "looooool", 3, "loool"},
+		{"max run of one", "aabbcc", 1, "abc"},
+		{"white space", "a     b", 2, "a  b"},
+		{"multiple runs", "nooooo waaaay!!!!", 2, "noo waay!!"},
+		{"multibyte", "ééééé 😀😀😀😀", 2, "éé 😀😀"},
+		{"zero is unlimited", "aaaa", 0, "aaaa"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, LimitRepeats(test.input, test.maxRun))
+		})
+	}
+}
+
+// BenchmarkLimitRepeats benchmarks the LimitRepeats method
+func BenchmarkLimitRepeats(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = LimitRepeats("this is sooooo cool!!!!!!!", 3)
+	}
+}
+
+// ExampleLimitRepeats example using LimitRepeats()
+func ExampleLimitRepeats() {
+	fmt.Println(LimitRepeats("this is sooooo cool!!!!!!!", 3))
+	// Output: this is sooo cool!!!
+}
+
+// TestCombiningMarks tests the CombiningMarks method
+func TestCombiningMarks(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name       string
+		input      string
+		maxPerBase int
+		expected   string
+	}{
+		{"empty", "", 1, ""},
+		{"plain text", "hello", 1, "hello"},
+		{"precomposed accents", "café naïve", 0, "café naïve"},
+		{"decomposed accent kept", "cafe\u0301", 1, "cafe\u0301"},
+		{"decomposed accent removed", "cafe\u0301", 0, "cafe"},
+		{"zalgo", "h\u0336\u0322\u0317e\u0334\u0315l\u0337l\u0338o", 1, "h\u0336e\u0334l\u0337l\u0338o"},
+		{"two per base", "e\u0323\u0302\u0301", 2, "e\u0323\u0302"},
+		{"leading marks", "\u0301\u0301a", 1, "\u0301a"},
+		{"enclosing mark", "1\u20dd\u20dd", 1, "1\u20dd"},
+		{"negative is zero", "e\u0301", -1, "e"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, CombiningMarks(test.input, test.maxPerBase))
+		})
+	}
+}
+
+// BenchmarkCombiningMarks benchmarks the CombiningMarks method
+func BenchmarkCombiningMarks(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = CombiningMarks("h\u0336\u0322\u0317e\u0334\u0315l\u0337l\u0338o", 1)
+	}
+}
+
+// ExampleCombiningMarks example using CombiningMarks()
+func ExampleCombiningMarks() {
+	fmt.Println(CombiningMarks("z\u0337\u0322\u0317a\u0334\u0315l\u0336g\u0338o\u0335\u0321", 0))
+	// Output: zalgo
+}
+
+// TestTitle tests the Title method
+func TestTitle(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"plain", "Hello World", "Hello World"},
+		{"html", "<h1>Hello <b>World</b></h1>", "Hello World"},
+		{"script", "<script>alert(1)</script>News", "alert(1)News"},
+		{"white space", "  Hello \t\n  World  ", "Hello World"},
+		{"no-break space", "Hello\u00A0World", "Hello World"},
+		{"control characters", "Hello\x00\x07 World\x7f", "Hello World"},
+		{"zero width space", "Hel\u200Blo", "Hello"},
+		{"bidi controls", "Hello \u202Edlrow", "Hello dlrow"},
+		{"smart quotes", "\u201CIt\u2019s\u201D", `"It's"`},
+		{"dashes", "2020\u20132024 \u2014 Review", "2020-2024 - Review"},
+		{"ellipsis", "Wait\u2026", "Wait..."},
+		{"unicode kept", "Caf\u00E9 na\u00EFve", "Caf\u00E9 na\u00EFve"},
+		{"entities kept", "Tom &amp; Jerry", "Tom &amp; Jerry"},
+		{"zero width space in a tag", "<\u200bimg src=x onerror=alert(1)>News", "News"},
+		{"bidi control in a tag", "<\u202eb>News</\u202eb>", "News"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := Title(test.input)
+			assert.Equal(t, test.expected, output)
+			assert.Equal(t, output, Title(output), "output should be stable")
+		})
+	}
+
+	t.Run("title case", func(t *testing.T) {
+		assert.Equal(t, "The Big Story", Title("<p>the  big story</p>", WithTitleCase()))
+	})
+}
+
+// BenchmarkTitle benchmarks the Title method
+func BenchmarkTitle(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Title("<h1>\u201CBreaking\u201D  news \u2014 <b>today</b></h1>")
+	}
+}
+
+// ExampleTitle example using Title()
+func ExampleTitle() {
+	fmt.Println(Title("<h1>\u201CBreaking\u201D  news \u2014 <b>today</b></h1>"))
+	fmt.Println(Title("<h1>breaking news</h1>", WithTitleCase()))
+	// Output:
+	// "Breaking" news - today
+	// Breaking News
+}
@@ -0,0 +1,121 @@
+package sanitize
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strings"
+	"sync"
+)
+
+// urlKey is the 128-bit hash of a canonical url, the set stores the hashes instead of the
+// urls so that millions of entries fit in memory
+type urlKey [16]byte
+
+// URLSet is a set of canonical urls for de-duplicating large lists of urls (sitemaps, crawl
+// frontiers, link exports). Urls are normalized with NormalizeURL using WithSortQuery and
+// WithRemoveTrailingSlash (and the options of the set), so urls that only differ in the case
+// of the scheme and host, default ports, percent-encoding, trailing slashes or the order of
+// the query parameters are the same entry. Only a 128-bit hash of each url is kept in memory.
+// A URLSet is safe for concurrent use.
+//
+//	View examples: urlset_test.go
+type URLSet struct {
+	keys map[urlKey]struct{}
+	mu   sync.Mutex
+	opts []Option
+}
+
+// NewURLSet returns an empty set, the options are used to normalize each url (see NormalizeURL)
+//
+//	View examples: urlset_test.go
+func NewURLSet(opts ...Option) *URLSet {
+	return &URLSet{
+		keys: make(map[urlKey]struct{}),
+		opts: append([]Option{WithSortQuery(), WithRemoveTrailingSlash()}, opts...),
+	}
+}
+
+// Add normalizes the url and adds it to the set. The canonical url is returned along with
+// true if it was not in the set yet, an invalid url returns an error (see NormalizeURL).
+func (s *URLSet) Add(original string) (string, bool, error) {
+	canonical, key, err := s.key(original)
+	if err != nil {
+		return "", false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.keys[key]; ok {
+		return canonical, false, nil
+	}
+	s.keys[key] = struct{}{}
+	return canonical, true, nil
+}
+
+// Contains returns true if the canonical form of the url is in the set
+func (s *URLSet) Contains(original string) bool {
+	_, key, err := s.key(original)
+	if err != nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.keys[key]
+	return ok
+}
+
+// Len returns the number of urls in the set
+func (s *URLSet) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.keys)
+}
+
+// Stream reads one url per line from r and writes the canonical form of each url that is not
+// in the set yet to w (one per line), the urls are added to the set. Blank lines are skipped
+// and an error is returned (with the line number) for an invalid url.
+//
+//	View examples: urlset_test.go
+func (s *URLSet) Stream(r io.Reader, w io.Writer) error {
+	reader := bufio.NewReader(r)
+	writer := bufio.NewWriter(w)
+
+	for n := 1; ; n++ {
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if link := strings.TrimSpace(line); len(link) > 0 {
+			canonical, added, aerr := s.Add(link)
+			if aerr != nil {
+				return fmt.Errorf("%w (line %d)", aerr, n)
+			}
+			if added {
+				if _, werr := writer.WriteString(canonical + "\n"); werr != nil {
+					return werr
+				}
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	return writer.Flush()
+}
+
+// key returns the canonical url and its hash
+func (s *URLSet) key(original string) (string, urlKey, error) {
+	var key urlKey
+	canonical, err := NormalizeURL(original, s.opts...)
+	if err != nil {
+		return "", key, err
+	}
+	h := fnv.New128a()
+	_, _ = h.Write([]byte(canonical))
+	h.Sum(key[:0])
+	return canonical, key, nil
+}
@@ -0,0 +1,148 @@
+package sanitize
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestURLSet tests the URLSet type
+func TestURLSet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("add", func(t *testing.T) {
+		var tests = []struct {
+			input     string
+			canonical string
+			added     bool
+		}{
+			{"https://example.com/a/?b=2&a=1", "https://example.com/a?a=1&b=2", true},
+			{"HTTPS://EXAMPLE.com:443/a?a=1&b=2", "https://example.com/a?a=1&b=2", false},
+			{"https://example.com/%61/?a=1&b=2", "https://example.com/a?a=1&b=2", false},
+			{"https://example.com/a?a=1", "https://example.com/a?a=1", true},
+			{"http://example.com/a?a=1", "http://example.com/a?a=1", true},
+			{"https://example.com", "https://example.com/", true},
+			{"https://example.com/", "https://example.com/", false},
+			{"https://ex\u00e1mple.com/", "https://xn--exmple-qta.com/", true},
+			{"https://exa\u0301mple.com/", "https://xn--exmple-qta.com/", false},
+		}
+
+		set := NewURLSet()
+		for _, test := range tests {
+			canonical, added, err := set.Add(test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.canonical, canonical, test.input)
+			assert.Equal(t, test.added, added, test.input)
+		}
+		assert.Equal(t, 5, set.Len())
+		assert.True(t, set.Contains("https://Example.com/a/?b=2&a=1"))
+		assert.False(t, set.Contains("https://example.com/b"))
+		assert.False(t, set.Contains("not a url"))
+	})
+
+	t.Run("invalid urls", func(t *testing.T) {
+		set := NewURLSet()
+		canonical, added, err := set.Add("/relative")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidURL)
+		assert.Equal(t, "", canonical)
+		assert.False(t, added)
+		assert.Equal(t, 0, set.Len())
+	})
+
+	t.Run("options", func(t *testing.T) {
+		set := NewURLSet(WithStripTrackingParams(), WithStripFragment())
+		_, added, err := set.Add("https://example.com/a?utm_source=x#top")
+		require.NoError(t, err)
+		assert.True(t, added)
+		assert.True(t, set.Contains("https://example.com/a"))
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		set := NewURLSet()
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					_, _, _ = set.Add(fmt.Sprintf("https://example.com/%d", j))
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 100, set.Len())
+	})
+}
+
+// TestURLSet_Stream tests the Stream method of the URLSet type
+func TestURLSet_Stream(t *testing.T) {
+	t.Parallel()
+
+	t.Run("de-duplicates", func(t *testing.T) {
+		input := "https://example.com/a/\n\nHTTPS://EXAMPLE.COM/a\r\n" +
+			" https://example.com/b?y=1&x=2 \nhttps://example.com/b?x=2&y=1"
+		var out bytes.Buffer
+		set := NewURLSet()
+		require.NoError(t, set.Stream(strings.NewReader(input), &out))
+		assert.Equal(t, "https://example.com/a\nhttps://example.com/b?x=2&y=1\n", out.String())
+		assert.Equal(t, 2, set.Len())
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		var out bytes.Buffer
+		err := NewURLSet().Stream(strings.NewReader("https://example.com/\n\nexample"), &out)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrInvalidURL)
+		assert.Contains(t, err.Error(), "(line 3)")
+	})
+
+	t.Run("reader error", func(t *testing.T) {
+		errRead := errors.New("read failed")
+		err := NewURLSet().Stream(iotest.ErrReader(errRead), &bytes.Buffer{})
+		assert.ErrorIs(t, err, errRead)
+	})
+
+	t.Run("writer error", func(t *testing.T) {
+		err := NewURLSet().Stream(strings.NewReader("https://example.com/page\n"), failingWriter{})
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrInvalidURL)
+	})
+}
+
+// BenchmarkURLSet_Add benchmarks the Add method of the URLSet type
+func BenchmarkURLSet_Add(b *testing.B) {
+	set := NewURLSet()
+	for i := 0; i < b.N; i++ {
+		_, _, _ = set.Add("https://Example.com/page/?b=2&a=1")
+	}
+}
+
+// ExampleURLSet example using a URLSet
+func ExampleURLSet() {
+	set := NewURLSet()
+	for _, link := range []string{"https://Example.com/a/?y=2&x=1", "https://example.com:443/a?x=1&y=2"} {
+		canonical, added, _ := set.Add(link)
+		fmt.Println(canonical, added)
+	}
+	// Output:
+	// https://example.com/a?x=1&y=2 true
+	// https://example.com/a?x=1&y=2 false
+}
+
+// ExampleURLSet_Stream example using the Stream method of a URLSet
+func ExampleURLSet_Stream() {
+	input := "https://example.com/a\nHTTPS://EXAMPLE.COM/a/\nhttps://example.com/b\n"
+	_ = NewURLSet().Stream(strings.NewReader(input), os.Stdout)
+	// Output:
+	// https://example.com/a
+	// https://example.com/b
+}
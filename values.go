@@ -0,0 +1,155 @@
+package sanitize
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// booleanValues are the accepted spellings of true and false (lowercase)
+var booleanValues = map[string]bool{
+	"1": true, "t": true, "true": true, "y": true, "yes": true, "on": true,
+	"checked": true, "enable": true, "enabled": true, "x": true,
+	"0": false, "f": false, "false": false, "n": false, "no": false, "off": false,
+	"unchecked": false, "disable": false, "disabled": false,
+}
+
+// Boolean returns the boolean value of the common spellings of true (1, t, true, y, yes, on,
+// checked, enabled, x) and false (0, f, false, n, no, off, unchecked, disabled), after removing
+// the surrounding white space and ignoring the case. An error is returned for any other value,
+// including an empty value (browsers do not submit unchecked checkboxes).
+//
+//	View examples: values_test.go
+func Boolean(original string) (bool, error) {
+	value, ok := booleanValues[strings.ToLower(strings.TrimSpace(original))]
+	if !ok {
+		return false, fmt.Errorf("%w: %q", ErrInvalidBoolean, original)
+	}
+	return value, nil
+}
+
+// Enum returns the canonical allowed value that matches the original value after removing the
+// surrounding white space, an error is returned if the value is not in the allowed list. If foldCase
+// is true the value is matched ignoring the case (NAME => name when name is allowed).
+//
+//	View examples: values_test.go
+func Enum(original string, allowed []string, foldCase bool) (string, error) {
+	value := strings.TrimSpace(original)
+	for _, candidate := range allowed {
+		if value == candidate || (foldCase && strings.EqualFold(value, candidate)) {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidEnum, original)
+}
+
+// ListOption is a functional option that adjusts how List splits and filters the items
+//
+//	View examples: values_test.go
+type ListOption func(*listOptions)
+
+// listOptions holds the settings collected from a list of ListOption values
+type listOptions struct {
+	delimiters     string
+	keepDuplicates bool
+	maxItems       int
+}
+
+// WithListDelimiters sets the characters that separate the items (a comma by default)
+func WithListDelimiters(delimiters string) ListOption {
+	return func(o *listOptions) {
+		o.delimiters = delimiters
+	}
+}
+
+// WithListDuplicates keeps the duplicate items (they are removed by default)
+func WithListDuplicates() ListOption {
+	return func(o *listOptions) {
+		o.keepDuplicates = true
+	}
+}
+
+// WithListMaxItems limits the number of items returned, the remaining items are dropped
+func WithListMaxItems(n int) ListOption {
+	return func(o *listOptions) {
+		o.maxItems = n
+	}
+}
+
+// List splits the original value on the delimiters (a comma by default), trims and sanitizes
+// each item with itemFn (if not nil) and returns the items in order. Empty items and duplicates
+// (after sanitizing) are dropped, and the number of items can be capped with WithListMaxItems.
+//
+//	View examples: values_test.go
+func List(original string, itemFn SanitizeFunc, opts ...ListOption) []string {
+	o := &listOptions{delimiters: ","}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	fields := strings.FieldsFunc(original, func(r rune) bool {
+		return strings.ContainsRune(o.delimiters, r)
+	})
+
+	items := make([]string, 0, len(fields))
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		if o.maxItems > 0 && len(items) >= o.maxItems {
+			break
+		}
+		item := strings.TrimSpace(field)
+		if itemFn != nil {
+			item = strings.TrimSpace(itemFn(item))
+		}
+		if len(item) == 0 || (!o.keepDuplicates && seen[item]) {
+			continue
+		}
+		seen[item] = true
+		items = append(items, item)
+	}
+	return items
+}
+
+// KeyValues parses and sanitizes a "k=v;k2=v2" style string (cookies, tags, connection strings)
+// into a map. The pairs are split on pairSep, each pair is split on the first kvSep and the key
+// and value are trimmed and sanitized with keyFn and valFn (if not nil). Empty pairs are
+// skipped and a repeated key keeps the last value. A pair without kvSep or with an empty key
+// (after sanitizing) returns ErrInvalidKeyValue, as do invalid or identical separators.
+//
+//	View examples: values_test.go
+func KeyValues(original string, pairSep, kvSep rune, keyFn, valFn SanitizeFunc) (map[string]string, error) {
+	for _, sep := range []rune{pairSep, kvSep} {
+		if !utf8.ValidRune(sep) || sep == utf8.RuneError {
+			return nil, fmt.Errorf("%w: invalid separator %q", ErrInvalidKeyValue, sep)
+		}
+	}
+	if pairSep == kvSep {
+		return nil, fmt.Errorf("%w: the pair and key separators are both %q", ErrInvalidKeyValue, kvSep)
+	}
+
+	values := make(map[string]string)
+	for i, pair := range strings.Split(original, string(pairSep)) {
+		if len(strings.TrimSpace(pair)) == 0 {
+			continue
+		}
+
+		key, value, found := strings.Cut(pair, string(kvSep))
+		if !found {
+			return nil, fmt.Errorf("%w: missing %q in pair %d", ErrInvalidKeyValue, kvSep, i+1)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if keyFn != nil {
+			key = strings.TrimSpace(keyFn(key))
+		}
+		if valFn != nil {
+			value = valFn(value)
+		}
+		if len(key) == 0 {
+			return nil, fmt.Errorf("%w: empty key in pair %d", ErrInvalidKeyValue, i+1)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
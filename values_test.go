@@ -0,0 +1,298 @@
+package sanitize
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBoolean tests the Boolean method
+func TestBoolean(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			input    string
+			expected bool
+		}{
+			{"1", true},
+			{"t", true},
+			{"TRUE", true},
+			{"True", true},
+			{"y", true},
+			{"Yes", true},
+			{"on", true},
+			{"checked", true},
+			{"enabled", true},
+			{"x", true},
+			{"  yes \n", true},
+			{"0", false},
+			{"F", false},
+			{"false", false},
+			{"N", false},
+			{"no", false},
+			{"OFF", false},
+			{"unchecked", false},
+			{"disabled", false},
+			{" false ", false},
+		}
+
+		for _, test := range tests {
+			t.Run(test.input, func(t *testing.T) {
+				output, err := Boolean(test.input)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		for _, input := range []string{"", "   ", "maybe", "2", "-1", "yess", "tru", "o"} {
+			output, err := Boolean(input)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrInvalidBoolean, input)
+			assert.False(t, output)
+		}
+	})
+}
+
+// BenchmarkBoolean benchmarks the Boolean method
+func BenchmarkBoolean(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = Boolean(" Yes ")
+	}
+}
+
+// ExampleBoolean example using Boolean()
+func ExampleBoolean() {
+	fmt.Println(Boolean(" Yes "))
+	fmt.Println(Boolean("off"))
+	fmt.Println(Boolean("maybe"))
+	// Output:
+	// true <nil>
+	// false <nil>
+	// false invalid boolean: "maybe"
+}
+
+// TestEnum tests the Enum method
+func TestEnum(t *testing.T) {
+	t.Parallel()
+
+	allowed := []string{"name", "created_at", "Price"}
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			foldCase bool
+			expected string
+		}{
+			{"exact", "name", false, "name"},
+			{"spaces", "  created_at\n", false, "created_at"},
+			{"canonical case", "Price", false, "Price"},
+			{"fold case", "NAME", true, "name"},
+			{"fold case canonical", "price", true, "Price"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := Enum(test.input, allowed, test.foldCase)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			allowed  []string
+			foldCase bool
+		}{
+			{"empty", "", allowed, true},
+			{"not allowed", "email", allowed, true},
+			{"case without folding", "NAME", allowed, false},
+			{"partial", "nam", allowed, true},
+			{"inner spaces", "created at", allowed, true},
+			{"empty allowed", "name", nil, true},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := Enum(test.input, test.allowed, test.foldCase)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidEnum)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkEnum benchmarks the Enum method
+func BenchmarkEnum(b *testing.B) {
+	allowed := []string{"name", "created_at", "price"}
+	for i := 0; i < b.N; i++ {
+		_, _ = Enum(" Price ", allowed, true)
+	}
+}
+
+// ExampleEnum example using Enum()
+func ExampleEnum() {
+	fmt.Println(Enum(" NAME ", []string{"name", "created_at"}, true))
+	_, err := Enum("email", []string{"name", "created_at"}, true)
+	fmt.Println(err)
+	// Output:
+	// name <nil>
+	// value is not allowed: "email"
+}
+
+// TestList tests the List method
+func TestList(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		input    string
+		itemFn   SanitizeFunc
+		opts     []ListOption
+		expected []string
+	}{
+		{"empty", "", nil, nil, []string{}},
+		{"only delimiters", " , ,, ", nil, nil, []string{}},
+		{"trimmed", " go , rust ,python ", nil, nil, []string{"go", "rust", "python"}},
+		{"duplicates removed", "go,rust,go", nil, nil, []string{"go", "rust"}},
+		{"duplicates kept", "go,rust,go", nil, []ListOption{WithListDuplicates()}, []string{"go", "rust", "go"}},
+		{"sanitized duplicates", "#Go, go!, GO", Funcs.Alpha, []ListOption{nil}, []string{"Go", "go", "GO"}},
+		{"sanitized then deduped", "Go,GO", strings.ToLower, nil, []string{"go"}},
+		{"sanitized to empty", "123, go, !!", Funcs.Alpha, nil, []string{"go"}},
+		{
+			"emails", "Bob@Example.com; alice@example.com\nbob@example.com", Funcs.Email,
+			[]ListOption{WithListDelimiters(";\n")}, []string{"bob@example.com", "alice@example.com"},
+		},
+		{"max items", "1,2,3,4", Funcs.Numeric, []ListOption{WithListMaxItems(2)}, []string{"1", "2"}},
+		{"max items after empties", ",,1,,2,3", nil, []ListOption{WithListMaxItems(2)}, []string{"1", "2"}},
+		{"max items zero", "1,2,3", nil, []ListOption{WithListMaxItems(0)}, []string{"1", "2", "3"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := List(test.input, test.itemFn, test.opts...)
+			assert.Equal(t, test.expected, output)
+		})
+	}
+}
+
+// BenchmarkList benchmarks the List method
+func BenchmarkList(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = List(" go, Rust ,go,,python", Funcs.Alpha, WithListMaxItems(10))
+	}
+}
+
+// ExampleList example using List()
+func ExampleList() {
+	fmt.Println(List(" #go, Rust ,go,,python!", Funcs.Alpha, WithListMaxItems(2)))
+	// Output: [go Rust]
+}
+
+// TestKeyValues tests the KeyValues method
+func TestKeyValues(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			pairSep  rune
+			kvSep    rune
+			keyFn    SanitizeFunc
+			valFn    SanitizeFunc
+			expected map[string]string
+		}{
+			{"empty", "", ';', '=', nil, nil, map[string]string{}},
+			{"cookie", "session=abc123; theme=dark", ';', '=', nil, nil,
+				map[string]string{"session": "abc123", "theme": "dark"}},
+			{"empty pairs", ";a=1;; ;b=2;", ';', '=', nil, nil, map[string]string{"a": "1", "b": "2"}},
+			{"value with separator", "token=a=b", ';', '=', nil, nil, map[string]string{"token": "a=b"}},
+			{"empty value", "flag=", ';', '=', nil, nil, map[string]string{"flag": ""}},
+			{"last value wins", "a=1;a=2", ';', '=', nil, nil, map[string]string{"a": "2"}},
+			{"tags", "Env:Prod, team:<b>core</b>", ',', ':', strings.ToLower, Funcs.HTML,
+				map[string]string{"env": "Prod", "team": "core"}},
+			{"connection string", "Server=db1;Port=5432;Password=secret", ';', '=', strings.ToLower, nil,
+				map[string]string{"server": "db1", "port": "5432", "password": "secret"}},
+			{"multibyte separator", "a→1|b→2", '|', '→', nil, nil, map[string]string{"a": "1", "b": "2"}},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := KeyValues(test.input, test.pairSep, test.kvSep, test.keyFn, test.valFn)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name  string
+			input string
+			keyFn SanitizeFunc
+		}{
+			{"missing separator", "a=1;broken", nil},
+			{"empty key", "=1", nil},
+			{"key sanitized to empty", "123=1", Funcs.Alpha},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := KeyValues(test.input, ';', '=', test.keyFn, nil)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidKeyValue)
+				assert.Nil(t, output)
+			})
+		}
+	})
+
+	t.Run("invalid separators", func(t *testing.T) {
+		var tests = []struct {
+			name    string
+			pairSep rune
+			kvSep   rune
+		}{
+			{"negative key separator", ';', -1},
+			{"surrogate key separator", ';', 0xD800},
+			{"replacement character key separator", ';', utf8.RuneError},
+			{"out of range pair separator", 0x110000, '='},
+			{"replacement character pair separator", utf8.RuneError, '='},
+			{"same separators", '=', '='},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := KeyValues("a=1;b=\ufffd2", test.pairSep, test.kvSep, nil, nil)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidKeyValue)
+				assert.Nil(t, output)
+			})
+		}
+	})
+}
+
+// BenchmarkKeyValues benchmarks the KeyValues method
+func BenchmarkKeyValues(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = KeyValues("session=abc123; theme=dark; lang=en", ';', '=', nil, Funcs.AlphaNumeric)
+	}
+}
+
+// ExampleKeyValues example using KeyValues()
+func ExampleKeyValues() {
+	values, _ := KeyValues("Server=db-1!; Port=5432 ", ';', '=', strings.ToLower, Funcs.AlphaNumeric)
+	fmt.Println(values["server"], values["port"])
+	// Output: db1 5432
+}
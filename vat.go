@@ -0,0 +1,392 @@
+package sanitize
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// vatRule is the format and checksum of a country's VAT numbers (without the country prefix)
+type vatRule struct {
+	format *regexp.Regexp
+	valid  func(number string) bool // Checksum (nil if the country has no published checksum)
+}
+
+// vatRules are the EU VAT number rules by their VAT prefix (Greece uses EL)
+var vatRules = map[string]vatRule{
+	"AT": {regexp.MustCompile(`^U\d{8}$`), vatChecksumAT},
+	"BE": {regexp.MustCompile(`^[01]\d{9}$`), vatChecksumBE},
+	"BG": {regexp.MustCompile(`^\d{9,10}$`), vatChecksumBG},
+	"CY": {regexp.MustCompile(`^[013459]\d{7}[A-Z]$`), vatChecksumCY},
+	"CZ": {regexp.MustCompile(`^\d{8,10}$`), vatChecksumCZ},
+	"DE": {regexp.MustCompile(`^\d{9}$`), vatChecksumMod1110},
+	"DK": {regexp.MustCompile(`^\d{8}$`), vatChecksumDK},
+	"EE": {regexp.MustCompile(`^10\d{7}$`), vatChecksumEE},
+	"EL": {regexp.MustCompile(`^\d{9}$`), vatChecksumEL},
+	"ES": {regexp.MustCompile(`^[A-Z0-9]\d{7}[A-Z0-9]$`), vatChecksumES},
+	"FI": {regexp.MustCompile(`^\d{8}$`), vatChecksumFI},
+	"FR": {regexp.MustCompile(`^[A-HJ-NP-Z0-9]{2}\d{9}$`), vatChecksumFR},
+	"HR": {regexp.MustCompile(`^\d{11}$`), vatChecksumMod1110},
+	"HU": {regexp.MustCompile(`^\d{8}$`), vatChecksumHU},
+	"IE": {regexp.MustCompile(`^\d{7}[A-W][A-IW]?$`), vatChecksumIE},
+	"IT": {regexp.MustCompile(`^\d{11}$`), checksumLuhn},
+	"LT": {regexp.MustCompile(`^(\d{9}|\d{12})$`), vatChecksumLT},
+	"LU": {regexp.MustCompile(`^\d{8}$`), vatChecksumLU},
+	"LV": {regexp.MustCompile(`^\d{11}$`), nil},
+	"MT": {regexp.MustCompile(`^[1-9]\d{7}$`), vatChecksumMT},
+	"NL": {regexp.MustCompile(`^\d{9}B\d{2}$`), vatChecksumNL},
+	"PL": {regexp.MustCompile(`^\d{10}$`), vatChecksumPL},
+	"PT": {regexp.MustCompile(`^\d{9}$`), vatChecksumPT},
+	"RO": {regexp.MustCompile(`^[1-9]\d{1,9}$`), vatChecksumRO},
+	"SE": {regexp.MustCompile(`^\d{10}01$`), func(n string) bool { return checksumLuhn(n[:10]) }},
+	"SI": {regexp.MustCompile(`^[1-9]\d{7}$`), vatChecksumSI},
+	"SK": {regexp.MustCompile(`^[1-9]\d{9}$`), vatChecksumSK},
+}
+
+// einPrefixes are the valid IRS campus prefixes of a US employer identification number
+var einPrefixes = "01 02 03 04 05 06 10 11 12 13 14 15 16 20 21 22 23 24 25 26 27 30 31 32 33 34 35 36 37 38 39 " +
+	"40 41 42 43 44 45 46 47 48 50 51 52 53 54 55 56 57 58 59 60 61 62 63 64 65 66 67 68 71 72 73 74 75 76 77 " +
+	"80 81 82 83 84 85 86 87 88 90 91 92 93 94 95 98 99"
+
+// VATNumber returns the tax identification number in its canonical form or an error if it
+// is invalid. The number is uppercased and stripped of spaces and punctuation, the country
+// prefix and checksum of EU VAT numbers are validated (de 136.695.976 => DE136695976) and
+// US employer identification numbers (country US) are returned as 12-3456789.
+//
+// The country is the ISO 3166-1 alpha-2 code (GR and EL are both accepted for Greece), it
+// can be empty if the number starts with its VAT prefix.
+//
+//	View examples: vat_test.go
+func VATNumber(original string, country string) (string, error) {
+	number := removeMatches(vatStripRegExp, strings.ToUpper(original))
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == "GR" {
+		country = "EL"
+	}
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w: %q %s", ErrInvalidVATNumber, original, reason)
+	}
+
+	// US employer identification number (12-3456789)
+	if country == "US" {
+		number = strings.TrimPrefix(number, "US")
+		if len(number) != 9 || !isDigitsASCII(number) {
+			return "", invalid("is not 9 digits")
+		}
+		if !strings.Contains(einPrefixes, number[:2]) {
+			return "", invalid("has an invalid prefix")
+		}
+		return number[:2] + "-" + number[2:], nil
+	}
+
+	// Use (or check) the prefix of the number
+	if len(number) >= 2 && isAlphaASCII(number[:2]) {
+		if _, ok := vatRules[number[:2]]; ok {
+			if len(country) > 0 && number[:2] != country {
+				return "", invalid("does not match the country " + country)
+			}
+			country, number = number[:2], number[2:]
+		}
+	}
+	rule, ok := vatRules[country]
+	if !ok {
+		return "", invalid("has an unsupported country")
+	}
+	if !rule.format.MatchString(number) {
+		return "", invalid("has an invalid format")
+	}
+	if rule.valid != nil && !rule.valid(number) {
+		return "", invalid("has an invalid checksum")
+	}
+	return country + number, nil
+}
+
+// vatStripRegExp are the characters removed from a VAT number
+var vatStripRegExp = regexp.MustCompile(`[^A-Z0-9]`)
+
+// vatDigits returns the digit values of the (ASCII digit) string
+func vatDigits(number string) []int {
+	digits := make([]int, len(number))
+	for i := 0; i < len(number); i++ {
+		digits[i] = int(number[i] - '0')
+	}
+	return digits
+}
+
+// vatWeightedSum returns the sum of the digits multiplied by their weights
+func vatWeightedSum(digits []int, weights ...int) int {
+	sum := 0
+	for i, weight := range weights {
+		sum += digits[i] * weight
+	}
+	return sum
+}
+
+// checksumLuhn validates the Luhn checksum of the digits
+func checksumLuhn(number string) bool {
+	sum := 0
+	for i, d := range vatDigits(number) {
+		if (len(number)-i)%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
+// vatChecksumMod1110 validates the ISO 7064 MOD 11,10 checksum (Germany and Croatia)
+func vatChecksumMod1110(number string) bool {
+	digits := vatDigits(number)
+	product := 10
+	for _, d := range digits[:len(digits)-1] {
+		sum := (d + product) % 10
+		if sum == 0 {
+			sum = 10
+		}
+		product = (2 * sum) % 11
+	}
+	check := 11 - product
+	if check == 10 {
+		check = 0
+	}
+	return check == digits[len(digits)-1]
+}
+
+// vatChecksumAT validates an Austrian VAT number (U12345678)
+func vatChecksumAT(number string) bool {
+	digits := vatDigits(number[1:])
+	sum := 0
+	for i, d := range digits[:7] {
+		if i%2 == 1 {
+			d *= 2
+			d = d/10 + d%10
+		}
+		sum += d
+	}
+	return (10-(sum+4)%10)%10 == digits[7]
+}
+
+// vatChecksumBE validates a Belgian VAT number (97 - first 8 digits mod 97)
+func vatChecksumBE(number string) bool {
+	return 97-vatMod(number[:8], 97) == vatMod(number[8:], 100)
+}
+
+// vatChecksumBG validates a Bulgarian VAT number (9 digit legal entities, 10 digit persons)
+func vatChecksumBG(number string) bool {
+	digits := vatDigits(number)
+	if len(digits) == 9 {
+		check := vatWeightedSum(digits, 1, 2, 3, 4, 5, 6, 7, 8) % 11
+		if check == 10 {
+			check = vatWeightedSum(digits, 3, 4, 5, 6, 7, 8, 9, 10) % 11 % 10
+		}
+		return check == digits[8]
+	}
+	check := vatWeightedSum(digits, 2, 4, 8, 5, 10, 9, 7, 3, 6) % 11 % 10
+	return check == digits[9]
+}
+
+// vatChecksumCY validates a Cypriot VAT number (8 digits and a check letter)
+func vatChecksumCY(number string) bool {
+	odd := []int{1, 0, 5, 7, 9, 13, 15, 17, 19, 21}
+	sum := 0
+	for i, d := range vatDigits(number[:8]) {
+		if i%2 == 0 {
+			d = odd[d]
+		}
+		sum += d
+	}
+	return byte('A'+sum%26) == number[8]
+}
+
+// vatChecksumCZ validates a Czech VAT number (8 digit legal entities, 9-10 digit persons)
+func vatChecksumCZ(number string) bool {
+	digits := vatDigits(number)
+	switch len(digits) {
+	case 8:
+		check := 11 - vatWeightedSum(digits, 8, 7, 6, 5, 4, 3, 2)%11
+		return check%10 == digits[7]
+	case 10:
+		return vatMod(number, 11) == 0
+	}
+	return true
+}
+
+// vatChecksumDK validates a Danish VAT number
+func vatChecksumDK(number string) bool {
+	return vatWeightedSum(vatDigits(number), 2, 7, 6, 5, 4, 3, 2, 1)%11 == 0
+}
+
+// vatChecksumEE validates an Estonian VAT number
+func vatChecksumEE(number string) bool {
+	digits := vatDigits(number)
+	return (10-vatWeightedSum(digits, 3, 7, 1, 3, 7, 1, 3, 7)%10)%10 == digits[8]
+}
+
+// vatChecksumEL validates a Greek VAT number
+func vatChecksumEL(number string) bool {
+	digits := vatDigits(number)
+	return vatWeightedSum(digits, 256, 128, 64, 32, 16, 8, 4, 2)%11%10 == digits[8]
+}
+
+// vatChecksumES validates a Spanish VAT number (NIF of persons, NIE of foreigners or CIF of legal entities)
+func vatChecksumES(number string) bool {
+	const nifLetters = "TRWAGMYFPDXBNJZSQVHLCKE"
+	first, last := number[0], number[8]
+
+	// NIF (12345678Z) or NIE (X1234567L)
+	if i := strings.IndexByte("XYZ", first); i >= 0 || isDigitsASCII(number[:8]) {
+		digits := number[:8]
+		if i >= 0 {
+			digits = string(rune('0'+i)) + number[1:8]
+		}
+		return isDigitsASCII(digits) && nifLetters[vatMod(digits, 23)] == last
+	}
+
+	// CIF (A12345674 or P1234567D)
+	if !isASCIILetter(first) || !isDigitsASCII(number[1:8]) {
+		return false
+	}
+	sum := 0
+	for i, d := range vatDigits(number[1:8]) {
+		if i%2 == 0 {
+			d *= 2
+			d = d/10 + d%10
+		}
+		sum += d
+	}
+	check := (10 - sum%10) % 10
+	return last == byte('0'+check) || last == "JABCDEFGHI"[check]
+}
+
+// vatChecksumFI validates a Finnish VAT number
+func vatChecksumFI(number string) bool {
+	digits := vatDigits(number)
+	r := vatWeightedSum(digits, 7, 9, 10, 5, 8, 4, 2) % 11
+	if r == 1 {
+		return false
+	}
+	return (11-r)%11 == digits[7]
+}
+
+// vatChecksumFR validates a French VAT number (a 2 character key and the SIREN number)
+func vatChecksumFR(number string) bool {
+	if !checksumLuhn(number[2:]) {
+		return false
+	}
+	if !isDigitsASCII(number[:2]) {
+		return true // Alphabetic keys have no published checksum
+	}
+	return vatMod(number[:2], 100) == (12+3*vatMod(number[2:], 97))%97
+}
+
+// vatChecksumHU validates a Hungarian VAT number
+func vatChecksumHU(number string) bool {
+	digits := vatDigits(number)
+	return (10-vatWeightedSum(digits, 9, 7, 3, 1, 9, 7, 3)%10)%10 == digits[7]
+}
+
+// vatChecksumIE validates an Irish VAT number (7 digits, a check letter and an optional letter)
+func vatChecksumIE(number string) bool {
+	sum := vatWeightedSum(vatDigits(number[:7]), 8, 7, 6, 5, 4, 3, 2)
+	if len(number) == 9 && number[8] != 'W' {
+		sum += int(number[8]-'A'+1) * 9
+	}
+	return "WABCDEFGHIJKLMNOPQRSTUV"[sum%23] == number[7]
+}
+
+// vatChecksumLT validates a Lithuanian VAT number (9 digit legal entities, 12 digit temporary numbers)
+func vatChecksumLT(number string) bool {
+	digits := vatDigits(number)
+	n := len(digits) - 1
+	sum := 0
+	for i := 0; i < n; i++ {
+		sum += digits[i] * (1 + i%9)
+	}
+	check := sum % 11
+	if check == 10 {
+		sum = 0
+		for i := 0; i < n; i++ {
+			sum += digits[i] * (1 + (i+2)%9)
+		}
+		check = sum % 11 % 10
+	}
+	return check == digits[n]
+}
+
+// vatChecksumLU validates a Luxembourgish VAT number (first 6 digits mod 89)
+func vatChecksumLU(number string) bool {
+	return vatMod(number[:6], 89) == vatMod(number[6:], 100)
+}
+
+// vatChecksumMT validates a Maltese VAT number
+func vatChecksumMT(number string) bool {
+	sum := vatWeightedSum(vatDigits(number), 3, 4, 6, 7, 8, 9)
+	return 37-sum%37 == vatMod(number[6:], 100)
+}
+
+// vatChecksumNL validates a Dutch VAT number (the 11-test of legal entities, or the
+// mod 97 test of the numbers issued to sole proprietors)
+func vatChecksumNL(number string) bool {
+	digits := vatDigits(number[:9])
+	if vatWeightedSum(digits, 9, 8, 7, 6, 5, 4, 3, 2, -1)%11 == 0 {
+		return true
+	}
+
+	// NL, the number and the B as digits (N=23, L=21, B=11) mod 97
+	return vatMod("2321"+number[:9]+"11"+number[10:], 97) == 1
+}
+
+// vatChecksumPL validates a Polish VAT number
+func vatChecksumPL(number string) bool {
+	digits := vatDigits(number)
+	return vatWeightedSum(digits, 6, 5, 7, 2, 3, 4, 5, 6, 7)%11 == digits[9]
+}
+
+// vatChecksumPT validates a Portuguese VAT number
+func vatChecksumPT(number string) bool {
+	digits := vatDigits(number)
+	check := 11 - vatWeightedSum(digits, 9, 8, 7, 6, 5, 4, 3, 2)%11
+	if check >= 10 {
+		check = 0
+	}
+	return check == digits[8]
+}
+
+// vatChecksumRO validates a Romanian VAT number (2 to 10 digits)
+func vatChecksumRO(number string) bool {
+	weights := []int{7, 5, 3, 2, 1, 7, 5, 3, 2}
+	digits := vatDigits(number)
+	n := len(digits) - 1
+	sum := 0
+	for i := 0; i < n; i++ {
+		sum += digits[i] * weights[len(weights)-n+i]
+	}
+	return sum*10%11%10 == digits[n]
+}
+
+// vatChecksumSI validates a Slovenian VAT number
+func vatChecksumSI(number string) bool {
+	digits := vatDigits(number)
+	check := 11 - vatWeightedSum(digits, 8, 7, 6, 5, 4, 3, 2)%11
+	if check == 11 {
+		return false
+	}
+	return check%10 == digits[7]
+}
+
+// vatChecksumSK validates a Slovak VAT number (divisible by 11)
+func vatChecksumSK(number string) bool {
+	return vatMod(number, 11) == 0
+}
+
+// vatMod returns the (ASCII digit) number modulo m
+func vatMod(number string, m int) int {
+	r := 0
+	for i := 0; i < len(number); i++ {
+		r = (r*10 + int(number[i]-'0')) % m
+	}
+	return r
+}
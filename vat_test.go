@@ -0,0 +1,109 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVATNumber tests the VATNumber method
+func TestVATNumber(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid cases", func(t *testing.T) {
+		var tests = []struct {
+			name     string
+			input    string
+			country  string
+			expected string
+		}{
+			{"austria", "ATU13585627", "", "ATU13585627"},
+			{"belgium", "BE 0411.905.847", "", "BE0411905847"},
+			{"bulgaria", "BG175074752", "", "BG175074752"},
+			{"croatia", "HR33392005961", "", "HR33392005961"},
+			{"cyprus", "cy10259033p", "", "CY10259033P"},
+			{"czech republic", "CZ25123891", "", "CZ25123891"},
+			{"denmark", "DK 13 58 56 28", "", "DK13585628"},
+			{"estonia", "EE100931558", "", "EE100931558"},
+			{"finland", "FI20774740", "", "FI20774740"},
+			{"france", "FR 40 303 265 045", "", "FR40303265045"},
+			{"germany", "de 136.695.976", "", "DE136695976"},
+			{"germany with country", "136695976", "de", "DE136695976"},
+			{"greece", "094259216", "GR", "EL094259216"},
+			{"hungary", "HU12892312", "", "HU12892312"},
+			{"ireland", "IE6388047V", "", "IE6388047V"},
+			{"italy", "IT00743110157", "", "IT00743110157"},
+			{"lithuania", "LT119511515", "", "LT119511515"},
+			{"luxembourg", "LU15027442", "", "LU15027442"},
+			{"latvia", "LV40003521600", "", "LV40003521600"},
+			{"malta", "MT11679112", "", "MT11679112"},
+			{"netherlands", "NL004495445B01", "", "NL004495445B01"},
+			{"poland", "PL 856-734-62-15", "", "PL8567346215"},
+			{"portugal", "PT501964843", "", "PT501964843"},
+			{"romania", "RO18547290", "", "RO18547290"},
+			{"slovakia", "SK2022749619", "", "SK2022749619"},
+			{"slovenia", "SI50223054", "", "SI50223054"},
+			{"spain company", "ESA28015865", "", "ESA28015865"},
+			{"spain person", "ES12345678Z", "", "ES12345678Z"},
+			{"spain foreigner", "ESX1234567L", "", "ESX1234567L"},
+			{"sweden", "SE556188840401", "", "SE556188840401"},
+			{"us ein", "12-3456789", "US", "12-3456789"},
+			{"us ein no dash", "123456789", "us", "12-3456789"},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := VATNumber(test.input, test.country)
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, output)
+			})
+		}
+	})
+
+	t.Run("invalid cases", func(t *testing.T) {
+		var tests = []struct {
+			name    string
+			input   string
+			country string
+		}{
+			{"empty", "", ""},
+			{"no country", "136695976", ""},
+			{"unsupported country", "123456789", "CA"},
+			{"country mismatch", "DE136695976", "FR"},
+			{"bad checksum", "DE136695977", ""},
+			{"bad format", "DE13669597", ""},
+			{"austria without U", "AT13585627", ""},
+			{"belgium checksum", "BE0411905848", ""},
+			{"netherlands checksum", "NL004495446B01", ""},
+			{"spain letter", "ES12345678A", ""},
+			{"us ein prefix", "07-3456789", "US"},
+			{"us ein length", "12-345678", "US"},
+			{"letters", "DEABCDEFGHI", ""},
+		}
+
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				output, err := VATNumber(test.input, test.country)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrInvalidVATNumber)
+				assert.Equal(t, "", output)
+			})
+		}
+	})
+}
+
+// BenchmarkVATNumber benchmarks the VATNumber method
+func BenchmarkVATNumber(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = VATNumber("de 136.695.976", "")
+	}
+}
+
+// ExampleVATNumber example using VATNumber()
+func ExampleVATNumber() {
+	number, err := VATNumber("de 136.695.976", "")
+	fmt.Println(number, err)
+	// Output: DE136695976 <nil>
+}
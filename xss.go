@@ -0,0 +1,101 @@
+package sanitize
+
+import (
+	"html"
+	"strings"
+)
+
+// DroppedKind is the kind of dangerous construct removed by a sanitizer (see WithDropped)
+type DroppedKind string
+
+// The kinds of dangerous constructs reported by WithDropped
+const (
+	DroppedHandler  DroppedKind = "handler"  // An event handler attribute (onclick, onerror)
+	DroppedPattern  DroppedKind = "pattern"  // A known XSS string removed by XSS (<script, javascript:)
+	DroppedProtocol DroppedKind = "protocol" // A script or data protocol in an attribute (javascript)
+	DroppedTag      DroppedKind = "tag"      // A dangerous element (script, iframe, object)
+)
+
+// Dropped is a dangerous construct that was removed by a sanitizer (see WithDropped)
+type Dropped struct {
+	Kind  DroppedKind // The kind of construct
+	Value string      // The tag name, attribute name, protocol or XSS string (lowercase)
+}
+
+// droppedTags are the elements reported as dangerous when they are removed by HTML
+var droppedTags = map[string]bool{
+	"applet": true, "base": true, "embed": true, "form": true, "frame": true, "frameset": true,
+	"iframe": true, "link": true, "meta": true, "object": true, "script": true, "style": true,
+}
+
+// droppedProtocols are the url schemes reported as dangerous in attribute values
+var droppedProtocols = map[string]bool{
+	"data": true, "javascript": true, "livescript": true, "vbscript": true,
+}
+
+// reportAttrs reports the event handlers and script protocols in the attributes of the tag
+func reportAttrs(token htmlToken, report func(Dropped)) {
+	for _, attr := range token.attrs {
+		reportAttr(attr, report)
+	}
+}
+
+// reportAttr reports the attribute if it is an event handler or its value uses a script protocol
+func reportAttr(attr htmlAttr, report func(Dropped)) {
+	if strings.HasPrefix(attr.key, "on") && len(attr.key) > 2 {
+		report(Dropped{Kind: DroppedHandler, Value: attr.key})
+	}
+	// Check the scheme of both the value and the entity decoded value (&#106;avascript:)
+	scheme := linkScheme(attr.val)
+	if !droppedProtocols[scheme] {
+		scheme = linkScheme(html.UnescapeString(attr.val))
+	}
+	if droppedProtocols[scheme] {
+		report(Dropped{Kind: DroppedProtocol, Value: scheme})
+	}
+}
+
+// XSSPolicy adds organization specific signatures to the strings removed by XSSWithPolicy.
+// Like the built-in strings (see XSSPatterns) they are matched ignoring case.
+type XSSPolicy struct {
+	ExtraHandlers []string // Additional attribute names removed along with their "=" (onpointerenter, srcdoc)
+	Patterns      []string // Additional strings to remove (formaction=, vbscript:)
+}
+
+// XSSPatterns returns a copy of the built-in strings removed by XSS (lowercase)
+//
+//	View examples: xss_test.go
+func XSSPatterns() []string {
+	return append([]string(nil), xssTokens...)
+}
+
+// XSSWithPolicy removes the known XSS attack strings like XSS and the additional strings
+// of the policy, ignoring case. An attribute name of ExtraHandlers is only removed when it
+// is directly followed by "=" (srcdoc="..." => "..."). Empty strings in the policy are ignored.
+//
+//	View examples: xss_test.go
+func XSSWithPolicy(original string, policy XSSPolicy, opts ...Option) string {
+	o := newOptions(opts)
+	original = o.before(original)
+	return o.after(removeTokens(original, policy.tokens(), true, o.report()))
+}
+
+// tokens returns the built-in strings and the (lowercase) strings of the policy
+func (p XSSPolicy) tokens() []string {
+	if len(p.Patterns) == 0 && len(p.ExtraHandlers) == 0 {
+		return xssTokens
+	}
+	tokens := make([]string, 0, len(xssTokens)+len(p.Patterns)+len(p.ExtraHandlers))
+	tokens = append(tokens, xssTokens...)
+	for _, pattern := range p.Patterns {
+		if pattern != "" {
+			tokens = append(tokens, strings.ToLower(pattern))
+		}
+	}
+	for _, handler := range p.ExtraHandlers {
+		if handler = strings.TrimSuffix(strings.TrimSpace(handler), "="); handler != "" {
+			tokens = append(tokens, strings.ToLower(handler)+"=")
+		}
+	}
+	return tokens
+}
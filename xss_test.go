@@ -0,0 +1,80 @@
+package sanitize
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestXSSWithPolicy tests the XSSWithPolicy method
+func TestXSSWithPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := XSSPolicy{
+		ExtraHandlers: []string{"srcdoc", "OnPointerEnter="},
+		Patterns:      []string{"formaction=", "VBScript:", ""},
+	}
+
+	var tests = []struct {
+		name     string
+		input    string
+		policy   XSSPolicy
+		expected string
+	}{
+		{"empty", "", policy, ""},
+		{"clean", "no xss here", policy, "no xss here"},
+		{"built-in", "<SCRIPT>alert(1)</SCRIPT>", policy, ">alert(1)</"},
+		{"built-in mixed case", "JaVaScRiPt:alert(1)", XSSPolicy{}, "alert(1)"},
+		{"pattern", `<button formaction="x">`, policy, `<button "x">`},
+		{"pattern ignores case", "VBSCRIPT:msgbox(1)", policy, "msgbox(1)"},
+		{"handler", `<iframe SRCDOC="x">`, policy, `<iframe "x">`},
+		{"handler with equals", `<div onpointerenter=alert(1)>`, policy, "<div alert(1)>"},
+		{"handler without equals", "srcdoc is kept", policy, "srcdoc is kept"},
+		{"re-formed", "formformaction=action=x", policy, "x"},
+		{"no policy", "srcdoc=x", XSSPolicy{}, "srcdoc=x"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output := XSSWithPolicy(test.input, test.policy)
+			assert.Equal(t, test.expected, output)
+			assert.Equal(t, output, XSSWithPolicy(output, test.policy))
+		})
+	}
+
+	t.Run("options", func(t *testing.T) {
+		assert.Equal(t, "x", XSSWithPolicy(" srcdoc=x ", policy, WithTrim()))
+	})
+}
+
+// TestXSSPatterns tests the XSSPatterns method
+func TestXSSPatterns(t *testing.T) {
+	t.Parallel()
+
+	patterns := XSSPatterns()
+	assert.Contains(t, patterns, "javascript:")
+	patterns[0] = "changed"
+	assert.NotEqual(t, "changed", XSSPatterns()[0])
+}
+
+// BenchmarkXSSWithPolicy benchmarks the XSSWithPolicy method
+func BenchmarkXSSWithPolicy(b *testing.B) {
+	policy := XSSPolicy{ExtraHandlers: []string{"srcdoc"}, Patterns: []string{"formaction="}}
+	for i := 0; i < b.N; i++ {
+		_ = XSSWithPolicy(`<iframe srcdoc="<SCRIPT>Test This!</SCRIPT>">`, policy)
+	}
+}
+
+// ExampleXSSWithPolicy example using XSSWithPolicy()
+func ExampleXSSWithPolicy() {
+	policy := XSSPolicy{ExtraHandlers: []string{"srcdoc"}, Patterns: []string{"formaction="}}
+	fmt.Println(XSSWithPolicy(`<iframe SRCDOC="x"><button FormAction="y">`, policy))
+	// Output: <iframe "x"><button "y">
+}
+
+// ExampleXSSPatterns example using XSSPatterns()
+func ExampleXSSPatterns() {
+	fmt.Println(XSSPatterns()[:3])
+	// Output: [<script script> eval(]
+}